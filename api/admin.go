@@ -0,0 +1,281 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/A-pen-app/kickstart/api/middleware"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/gin-gonic/gin"
+)
+
+type adminHandler struct {
+	a     service.Admin
+	audit service.Audit
+}
+
+func addAdminRoutes(root *gin.RouterGroup, a service.Admin, audit service.Audit, auth service.Auth, leader service.Leader) {
+	h := &adminHandler{
+		a:     a,
+		audit: audit,
+	}
+
+	g := root.Group("admin")
+	g.Use(middleware.AuthUser(auth))
+	g.Use(middleware.NeedPermission(models.Admin))
+	g.GET("orders", h.listOrders)
+	g.GET("users/:user_id/activity", h.getUserActivity)
+	g.GET("audit", h.listAudit)
+	g.GET("audit/verify", middleware.Audited(models.AuditAdminVerifyAudit), h.verifyAudit)
+
+	// cancelling changes the board, served by the matcher leader if there is one
+	forward := middleware.ForwardToLeader(leader)
+	g.POST("orders/:order_id/cancel", forward, h.cancelOrder)
+	g.POST("users/:user_id/orders/cancel", forward, h.cancelUserOrders)
+}
+
+type listOrdersReq struct {
+	pageReq
+	UserID *string             `form:"user_id" binding:"omitempty,uuid"`
+	Action *models.OrderAction `form:"action" binding:"omitempty,oneof=buy sell"`
+	Status *models.OrderStatus `form:"status" binding:"omitempty,oneof=open pending filled cancelled"`
+}
+
+// adminOrder shows the owner of an order to operators
+type adminOrder struct {
+	*models.Order
+	UserID *string `json:"user_id" example:"uuid"`
+}
+
+//	@Summary		List orders of all users
+//	@Description	List orders of all users, including filled and cancelled ones, newest first, optionally filtered by user, action and status
+//	@Tags			admin
+//	@Param			input	query	listOrdersReq	true	"filters and page"
+//	@Produce		json
+//	@Success		200	{object}	pageResp{data=[]adminOrder}
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/orders [get]
+//	@Security		Bearer
+func (h *adminHandler) listOrders(ctx *gin.Context) {
+	p := listOrdersReq{}
+	if err := ctx.BindQuery(&p); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	if p.Count <= 0 {
+		p.Count = 10
+	}
+
+	orders, next, err := h.a.ListOrders(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		&models.OrderFilter{
+			UserID: p.UserID,
+			Action: p.Action,
+			Status: p.Status,
+		},
+		p.Next,
+		p.Count,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	data := make([]*adminOrder, 0, len(orders))
+	for _, order := range orders {
+		data = append(data, &adminOrder{Order: order, UserID: order.UserID})
+	}
+	ctx.JSON(http.StatusOK, pageResp{
+		Data: data,
+		Next: next,
+	})
+}
+
+type cancelBody struct {
+	// why the operator cancels, kept in the audit trail
+	Reason string `json:"reason" binding:"required" example:"erroneous order reported by the user"`
+}
+
+//	@Summary		Cancel an order of any user
+//	@Description	Force-cancel an order along with its one-cancels-other group, the action is recorded in the audit trail
+//	@Tags			admin
+//	@Param			order_id	path	string		true	"ID of order"
+//	@Param			jsonBody	body	cancelBody	true	"reason of cancelling"
+//	@Produce		json
+//	@Success		200
+//	@Failure		400	{object}	errorResp
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/orders/{order_id}/cancel [post]
+//	@Security		Bearer
+func (h *adminHandler) cancelOrder(ctx *gin.Context) {
+	u := orderUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	b := cancelBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	if err := h.a.CancelOrder(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		u.OrderID,
+		b.Reason,
+	); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type adminUserUri struct {
+	UserID string `uri:"user_id" binding:"required,uuid"`
+}
+
+type cancelUserOrdersResp struct {
+	Cancelled int `json:"cancelled" example:"3"`
+}
+
+//	@Summary		Cancel all orders of a user
+//	@Description	Force-cancel every open and pending order of a user, the action is recorded in the audit trail
+//	@Tags			admin
+//	@Param			user_id		path	string		true	"ID of user"
+//	@Param			jsonBody	body	cancelBody	true	"reason of cancelling"
+//	@Produce		json
+//	@Success		200	{object}	cancelUserOrdersResp
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/users/{user_id}/orders/cancel [post]
+//	@Security		Bearer
+func (h *adminHandler) cancelUserOrders(ctx *gin.Context) {
+	u := adminUserUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	b := cancelBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cancelled, err := h.a.CancelUserOrders(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		u.UserID,
+		b.Reason,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &cancelUserOrdersResp{
+		Cancelled: cancelled,
+	})
+}
+
+//	@Summary		Get activity of a user
+//	@Description	Get the position, recent orders and recent trades of a user, the access is recorded in the audit trail
+//	@Tags			admin
+//	@Param			user_id	path	string	true	"ID of user"
+//	@Produce		json
+//	@Success		200	{object}	models.UserActivity
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/users/{user_id}/activity [get]
+//	@Security		Bearer
+func (h *adminHandler) getUserActivity(ctx *gin.Context) {
+	u := adminUserUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	activity, err := h.a.GetUserActivity(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		u.UserID,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, activity)
+}
+
+type listAuditReq struct {
+	pageReq
+	ActorID *string             `form:"actor_id" binding:"omitempty,uuid"`
+	Action  *models.AuditAction `form:"action"`
+	Target  *string             `form:"target"`
+	// RFC 3339 time, entries created at or after it
+	From *time.Time `form:"from"`
+	// RFC 3339 time, entries created before it
+	To *time.Time `form:"to"`
+}
+
+//	@Summary		List the audit trail
+//	@Description	List entries of the audit trail, newest first, optionally filtered by actor, action, target and time, the access is recorded as well
+//	@Tags			admin
+//	@Param			input	query	listAuditReq	true	"filters and page"
+//	@Produce		json
+//	@Success		200	{object}	pageResp{data=[]models.AuditEntry}
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/audit [get]
+//	@Security		Bearer
+func (h *adminHandler) listAudit(ctx *gin.Context) {
+	p := listAuditReq{}
+	if err := ctx.BindQuery(&p); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	if p.Count <= 0 {
+		p.Count = 10
+	}
+
+	entries, next, err := h.audit.List(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		&models.AuditFilter{
+			ActorID: p.ActorID,
+			Action:  p.Action,
+			Target:  p.Target,
+			From:    p.From,
+			To:      p.To,
+		},
+		p.Next,
+		p.Count,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, pageResp{
+		Data: entries,
+		Next: next,
+	})
+}
+
+//	@Summary		Verify the audit trail
+//	@Description	Check the hash chain of the whole audit trail, the first entry whose hash does not match is reported if it is broken
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	models.AuditVerification
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/audit/verify [get]
+//	@Security		Bearer
+func (h *adminHandler) verifyAudit(ctx *gin.Context) {
+	result, err := h.audit.Verify(ctx.Request.Context())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
@@ -3,6 +3,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -10,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/A-pen-app/kickstart/api/middleware"
+	"github.com/A-pen-app/kickstart/api/rpc"
 	"github.com/A-pen-app/kickstart/config"
 	"github.com/A-pen-app/kickstart/database"
 	"github.com/A-pen-app/kickstart/global"
@@ -17,28 +19,29 @@ import (
 	"github.com/A-pen-app/kickstart/service"
 	"github.com/A-pen-app/kickstart/store"
 	"github.com/A-pen-app/logging"
-	"github.com/A-pen-app/mq"
 )
 
-// NewRouter returns the global HTTP router instance.
-func NewRouter() *gin.Engine {
-	return initializeRouter()
+// NewRouter returns the global HTTP router instance and the gRPC server sharing
+// its services, background workers of the services run until ctx is done.
+func NewRouter(ctx context.Context) (*gin.Engine, *rpc.Server) {
+	return initializeRouter(ctx)
 }
 
 // initializeSingletons is the function called by sync.Once to intialize the
 // HTTP engine and router group singleton instances.
-func initializeRouter() *gin.Engine {
+func initializeRouter(ctx context.Context) (*gin.Engine, *rpc.Server) {
 	var router *gin.Engine
+	var rpcServer *rpc.Server
 	// Create router and group instances. Check whether we should use the
 	// microservice name as root router group URL prefix. This depends on
 	// whether or our Kubernetes ingress is configured to use path-based
 	// routing or name-based virtual hosting.
 	if config.GetBool("SERVICE_NAME_AS_ROOT") {
-		router = createRouterAndGroup(global.ServiceName)
+		router, rpcServer = createRouterAndGroup(ctx, global.ServiceName)
 	} else {
-		router = createRouterAndGroup("")
+		router, rpcServer = createRouterAndGroup(ctx, "")
 	}
-	return router
+	return router, rpcServer
 }
 
 //	@title						Order (aka Broadcast Service) API
@@ -52,9 +55,7 @@ func initializeRouter() *gin.Engine {
 //	@in							header
 //	@name						Authorization
 //	@description				Type "Bearer" followed by a space and JWT.
-func createRouterAndGroup(prefix string) *gin.Engine {
-	ctx := context.Background()
-
+func createRouterAndGroup(ctx context.Context, prefix string) (*gin.Engine, *rpc.Server) {
 	// Create a clean HTTP router engine.
 	engine := gin.New()
 
@@ -68,36 +69,74 @@ func createRouterAndGroup(prefix string) *gin.Engine {
 	// Create from the engine a router group with the given prefix.
 	root := engine.Group(prefix)
 
-	// Install common middleware to the router group.
-	installCommonMiddleware(root)
-
 	// initialize dependencies for injection
 	db := database.GetPostgres()
 
-	pubsub := mq.GetPubsub()
 	cryptoStore := store.NewCrypto(ctx)
 	orderStore := store.NewOrder(db)
+	positionStore := store.NewPosition(db)
+	instrumentStore := store.NewInstrument(db)
+	userStore := store.NewUser(db)
+	parentStore := store.NewParent(db)
+	rfqStore := store.NewRFQ(db)
+	auditStore := store.NewAudit(db)
+	tickerStore := store.NewTicker(db)
 
 	authSvc := service.NewAuth(ctx, cryptoStore)
-	orderSvc := service.NewOrder(orderStore, pubsub)
+	templateSvc := service.NewTemplate()
+	orderSvc := service.NewOrder(orderStore, positionStore, instrumentStore, userStore, templateSvc)
+	marketSvc := service.NewMarket(instrumentStore, tickerStore)
+	userSvc := service.NewUser(userStore)
+	parentSvc := service.NewParent(parentStore, instrumentStore, orderSvc)
+	rfqSvc := service.NewRFQ(rfqStore, instrumentStore, userStore, templateSvc)
+	auditSvc := service.NewAudit(auditStore)
+	adminSvc := service.NewAdmin(orderStore, positionStore, auditSvc, orderSvc)
+
+	// Install common middleware to the router group.
+	installCommonMiddleware(root, auditSvc)
+
+	// child orders share the board state of orderSvc, so they are sent from this process
+	var leaderSvc service.Leader
+	switch config.GetString("MATCHER_MODE") {
+	case "leader":
+		// a single process matches orders, the others forward order commands to it
+		leaderSvc = service.NewLeader(store.NewLease(db), parentSvc.Run, orderSvc.Run)
+		go leaderSvc.Run(ctx)
+	default:
+		// every process matches orders, serialized by the book lock
+		go parentSvc.Run(ctx)
+		go orderSvc.Run(ctx)
+	}
 
 	// register routes
 	addDocRoutes(root)
 	addProbesRoutes(root)
 	addSystemRoutes(root)
-	addOrderRoutes(root, orderSvc, authSvc)
-
-	return engine
+	addOrderRoutes(root, orderSvc, authSvc, leaderSvc)
+	addMarketRoutes(root, marketSvc, authSvc)
+	addUserRoutes(root, userSvc, authSvc)
+	addNotificationRoutes(root, templateSvc, authSvc)
+	addParentRoutes(root, parentSvc, authSvc, leaderSvc)
+	addRFQRoutes(root, rfqSvc, authSvc, leaderSvc)
+	addAdminRoutes(root, adminSvc, auditSvc, authSvc, leaderSvc)
+
+	// the gRPC API serves the same order service, so both share the board state
+	rpcServer := rpc.NewServer(orderSvc, authSvc, auditSvc, leaderSvc)
+
+	return engine, rpcServer
 }
 
 // installCommonMiddleware installs common middleware to the router group.
-func installCommonMiddleware(root *gin.RouterGroup) {
+func installCommonMiddleware(root *gin.RouterGroup, a service.Audit) {
 	// support open tracing
 	root.Use(otelgin.Middleware("kickstart"))
 
 	// Install logger middleware, a middleware to log requests.
 	root.Use(logging.RequestLogger([]string{"/alive", "/ready"}))
 
+	// Install audit middleware, a middleware to record audited requests to the audit trail.
+	root.Use(middleware.Audit(a))
+
 	// Install recovery middleware, a middleware to recover & log panics.
 	// NOTE: The recovery middleware should always be the last one installed.
 	root.Use(middleware.Recovery())
@@ -105,6 +144,7 @@ func installCommonMiddleware(root *gin.RouterGroup) {
 
 type errorResp struct {
 	Error     string `json:"error"`
+	Field     string `json:"field,omitempty"` // the offending request field, if the error is caused by a specific one
 	RequestID string `json:"request_id"`
 }
 
@@ -128,6 +168,12 @@ func handleError(ctx *gin.Context, err error) {
 		Error:     err.Error(),
 		RequestID: requestID,
 	}
+	var fieldErr *models.FieldError
+	if errors.As(err, &fieldErr) {
+		resp.Field = fieldErr.Field
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, resp)
+		return
+	}
 	switch err {
 	case models.ErrorNotFound:
 		ctx.AbortWithStatusJSON(http.StatusNotFound, resp)
@@ -137,6 +183,10 @@ func handleError(ctx *gin.Context, err error) {
 		ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, resp)
 	case models.ErrorNotAllowed:
 		ctx.AbortWithStatusJSON(http.StatusForbidden, resp)
+	case models.ErrorMarketHalted, models.ErrorMarketClosed, models.ErrorMarketAuction:
+		ctx.AbortWithStatusJSON(http.StatusConflict, resp)
+	case models.ErrorBookBusy, models.ErrorNoLeader:
+		ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, resp)
 	default:
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, resp)
 	}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/A-pen-app/kickstart/api/middleware"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/gin-gonic/gin"
+)
+
+type marketHandler struct {
+	m service.Market
+}
+
+func addMarketRoutes(root *gin.RouterGroup, m service.Market, auth service.Auth) {
+	h := &marketHandler{
+		m: m,
+	}
+
+	root.GET("instruments/:symbol", h.getInstrument)
+	root.GET("ticker", h.getTickers)
+
+	g := root.Group("admin/instruments")
+	g.Use(middleware.AuthUser(auth))
+	g.Use(middleware.NeedPermission(models.Admin))
+	g.POST(":symbol/halt", middleware.Audited(models.AuditAdminHalt), h.halt)
+	g.POST(":symbol/resume", middleware.Audited(models.AuditAdminResume), h.resume)
+	g.PUT(":symbol/time-zone", middleware.Audited(models.AuditAdminTimeZone), h.setTimeZone)
+}
+
+type instrumentUri struct {
+	Symbol string `uri:"symbol" binding:"required"`
+}
+
+type getInstrumentResp struct {
+	*models.Instrument
+	MarketState models.MarketState `json:"market_state" example:"open"`
+}
+
+//	@Summary		Get an instrument
+//	@Description	Get trading rules, trading session and current market state of an instrument
+//	@Tags			market
+//	@Param			symbol	path	string	true	"symbol of instrument"
+//	@Produce		json
+//	@Success		200	{object}	getInstrumentResp
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/instruments/{symbol} [get]
+func (h *marketHandler) getInstrument(ctx *gin.Context) {
+	u := instrumentUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	instrument, state, err := h.m.GetInstrument(ctx.Request.Context(), u.Symbol)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &getInstrumentResp{
+		Instrument:  instrument,
+		MarketState: state,
+	})
+}
+
+//	@Summary		Get 24h tickers
+//	@Description	Get open, high, low, last, volume, VWAP and change percentage of trades on the board over the last 24 hours per instrument.
+//	@Description	Statistics are kept per minute, so the window starts at the beginning of the minute 24 hours ago. Off-book trades are not counted.
+//	@Tags			market
+//	@Produce		json
+//	@Success		200	{array}		models.Ticker
+//	@Failure		500	{object}	errorResp
+//	@Router			/ticker [get]
+func (h *marketHandler) getTickers(ctx *gin.Context) {
+	tickers, err := h.m.GetTickers(ctx.Request.Context())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tickers)
+}
+
+type haltBody struct {
+	Reason string `json:"reason" binding:"required,max=200" example:"system maintenance"`
+}
+
+//	@Summary		Halt trading of an instrument
+//	@Description	Halt trading of an instrument until it is resumed, orders cannot be made or taken while halted.
+//	@Tags			market
+//	@Param			symbol		path	string		true	"symbol of instrument"
+//	@Param			jsonBody	body	haltBody	true	"reason of the halt"
+//	@Produce		json
+//	@Success		200
+//	@Failure		400	{object}	errorResp
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/instruments/{symbol}/halt [post]
+//	@Security		Bearer
+func (h *marketHandler) halt(ctx *gin.Context) {
+	u := instrumentUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	b := haltBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	if err := h.m.Halt(ctx.Request.Context(), u.Symbol, b.Reason); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, nil)
+}
+
+//	@Summary		Resume trading of an instrument
+//	@Description	Resume trading of an instrument halted by an operator or a circuit breaker
+//	@Tags			market
+//	@Param			symbol	path	string	true	"symbol of instrument"
+//	@Produce		json
+//	@Success		200
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/instruments/{symbol}/resume [post]
+//	@Security		Bearer
+func (h *marketHandler) resume(ctx *gin.Context) {
+	u := instrumentUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	if err := h.m.Resume(ctx.Request.Context(), u.Symbol); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, nil)
+}
+
+type timeZoneBody struct {
+	TimeZone string `json:"time_zone" binding:"required,max=64" example:"Asia/Taipei"`
+}
+
+//	@Summary		Set the time zone of an instrument
+//	@Description	Set the IANA time zone the trading session of an instrument is in, sessions are in UTC until it is set.
+//	@Tags			market
+//	@Param			symbol		path	string			true	"symbol of instrument"
+//	@Param			jsonBody	body	timeZoneBody	true	"time zone of the session"
+//	@Produce		json
+//	@Success		200
+//	@Failure		400	{object}	errorResp
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/instruments/{symbol}/time-zone [put]
+//	@Security		Bearer
+func (h *marketHandler) setTimeZone(ctx *gin.Context) {
+	u := instrumentUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	b := timeZoneBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	if err := h.m.SetTimeZone(ctx.Request.Context(), u.Symbol, b.TimeZone); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, nil)
+}
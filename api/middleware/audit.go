@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/A-pen-app/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// auditForwardedKey marks requests forwarded to the matcher leader, which records them itself
+const auditForwardedKey = "audit_forwarded"
+
+// Audited marks the route, so Audit records action once a request is served whether it succeeds
+// or not. The handler may set "audit_target", otherwise the request path is the target.
+func Audited(action models.AuditAction) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set("audit_action", action)
+		ctx.Next()
+	}
+}
+
+// payloadHash hashes the request body as the handler reads it, so the body is never buffered. It
+// covers what has been read so far, or the query string if nothing of the body has been read.
+type payloadHash struct {
+	hash  hash.Hash
+	n     int64
+	query string
+}
+
+func (p *payloadHash) Write(b []byte) (int, error) {
+	p.n += int64(len(b))
+	return p.hash.Write(b)
+}
+
+// String returns the hex encoded SHA-256 of the payload, or an empty string if there is none
+func (p *payloadHash) String() string {
+	if p.n > 0 {
+		return hex.EncodeToString(p.hash.Sum(nil))
+	}
+	if p.query == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(p.query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Audit records requests marked by Audited or denied by NeedPermission to the audit trail. It puts
+// the client IP and payload hash into the request context, so entries recorded by services while
+// serving the request carry them as well. The payload hash covers the part of the body read by the
+// time an entry is recorded. It should be installed before routes are registered.
+func Audit(a service.Audit) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := &payloadHash{
+			hash:  sha256.New(),
+			query: ctx.Request.URL.RawQuery,
+		}
+		if ctx.Request.Body != nil && ctx.Request.Body != http.NoBody {
+			ctx.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.TeeReader(ctx.Request.Body, payload), ctx.Request.Body}
+		}
+		c := context.WithValue(ctx.Request.Context(), "client_ip", ctx.ClientIP())
+		c = context.WithValue(c, "payload_hash", payload)
+		ctx.Request = ctx.Request.WithContext(c)
+		ctx.Next()
+
+		v, exists := ctx.Get("audit_action")
+		if !exists || ctx.GetBool(auditForwardedKey) {
+			return
+		}
+		action, ok := v.(models.AuditAction)
+		if !ok {
+			return
+		}
+		target := ctx.GetString("audit_target")
+		if target == "" {
+			target = ctx.Request.URL.Path
+		}
+		// the request is served already, failing to record it is only logged
+		c = context.WithoutCancel(ctx.Request.Context())
+		if err := a.Record(c, ctx.GetString("user_id"), action, target, map[string]interface{}{
+			"method": ctx.Request.Method,
+			"status": ctx.Writer.Status(),
+		}); err != nil {
+			logging.Errorw(c, "record audit entry of request failed", "err", err, "action", action, "target", target)
+		}
+	}
+}
@@ -68,6 +68,8 @@ func NeedPermission(userType models.UserType) gin.HandlerFunc {
 		}
 
 		if scope < userType {
+			// denials are recorded by Audit
+			ctx.Set("audit_action", models.AuditPermissionDenied)
 			ctx.AbortWithStatus(http.StatusForbidden)
 			return
 		}
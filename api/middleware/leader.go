@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/A-pen-app/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// forwardedHeader marks requests forwarded by a follower, so they are never forwarded again
+const forwardedHeader = "X-Matcher-Forwarded"
+
+// ForwardToLeader proxies requests to the process holding the matcher lease unless it is this one,
+// requests are served locally if l is nil, i.e. every process matches orders.
+func ForwardToLeader(l service.Leader) gin.HandlerFunc {
+	if l == nil {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+	return func(ctx *gin.Context) {
+		if l.IsLeader() {
+			ctx.Next()
+			return
+		}
+		// leadership moved while the request was being forwarded, let the client retry
+		if ctx.GetHeader(forwardedHeader) != "" {
+			ctx.AbortWithStatus(http.StatusServiceUnavailable)
+			return
+		}
+		lease, err := l.Leader(ctx.Request.Context())
+		if err != nil {
+			ctx.AbortWithStatus(http.StatusServiceUnavailable)
+			return
+		}
+		target, err := url.Parse(lease.Address)
+		if err != nil {
+			logging.Errorw(ctx.Request.Context(), "parse matcher leader address failed", "err", err, "address", lease.Address)
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		proxy := httputil.NewSingleHostReverseProxy(target)
+		proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			logging.Errorw(r.Context(), "forward to matcher leader failed", "err", err, "address", lease.Address)
+			w.WriteHeader(http.StatusBadGateway)
+		}
+		ctx.Request.Header.Set(forwardedHeader, lease.Holder)
+		ctx.Set(auditForwardedKey, true)
+		proxy.ServeHTTP(ctx.Writer, ctx.Request)
+		ctx.Abort()
+	}
+}
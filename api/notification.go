@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/A-pen-app/kickstart/api/middleware"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/gin-gonic/gin"
+)
+
+type notificationHandler struct {
+	t service.Template
+}
+
+func addNotificationRoutes(root *gin.RouterGroup, t service.Template, auth service.Auth) {
+	h := &notificationHandler{
+		t: t,
+	}
+
+	g := root.Group("admin/notifications")
+	g.Use(middleware.AuthUser(auth))
+	g.Use(middleware.NeedPermission(models.Admin))
+	g.POST("preview", h.preview)
+}
+
+type previewNotificationBody struct {
+	Event  models.NotificationEvent `json:"event" binding:"required,oneof=order_created order_filled rfq_requested" example:"order_created"`
+	Locale string                   `json:"locale" binding:"max=16" example:"zh-TW"`
+	// sample data is used if absent
+	Data *models.NotificationData `json:"data"`
+}
+
+//	@Summary		Preview a notification
+//	@Description	Render the notification of an event for every channel in given locale, falling back to the default locale if it has no templates.
+//	@Tags			notification
+//	@Param			jsonBody	body	previewNotificationBody	true	"event, locale and template variables"
+//	@Produce		json
+//	@Success		200	{object}	models.Notification
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/admin/notifications/preview [post]
+//	@Security		Bearer
+func (h *notificationHandler) preview(ctx *gin.Context) {
+	b := previewNotificationBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	notification, err := h.t.Preview(ctx.Request.Context(), b.Event, b.Locale, b.Data)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, notification)
+}
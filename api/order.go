@@ -1,11 +1,16 @@
 package api
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/A-pen-app/kickstart/api/middleware"
 	"github.com/A-pen-app/kickstart/models"
 	"github.com/A-pen-app/kickstart/service"
+	"github.com/A-pen-app/logging"
 	"github.com/gin-gonic/gin"
 )
 
@@ -14,7 +19,7 @@ type orderHandler struct {
 	auth service.Auth
 }
 
-func addOrderRoutes(root *gin.RouterGroup, c service.Order, auth service.Auth) {
+func addOrderRoutes(root *gin.RouterGroup, c service.Order, auth service.Auth, leader service.Leader) {
 	h := &orderHandler{
 		c:    c,
 		auth: auth,
@@ -23,18 +28,29 @@ func addOrderRoutes(root *gin.RouterGroup, c service.Order, auth service.Auth) {
 	root.GET("board", h.getBoard)
 
 	// FIXME: temporary token generator for testing
-	root.GET("token", h.getToken)
+	root.GET("token", middleware.Audited(models.AuditTokenIssued), h.getToken)
 
 	g := root.Group("orders")
 	g.Use(middleware.AuthUser(auth))
 	g.Use(middleware.NeedPermission(models.Audience))
 
 	// FIXME: need to do pagination and filter, pagination should start from latest taker price and grow up and down
-	// FIXME: implement this get method
-	// g.GET(":order_id", h.get)
-	g.POST("make", h.make)
-	g.PATCH("take", h.take)
-	g.DELETE(":order_id", h.delete)
+	g.GET(":order_id", h.get)
+	g.GET("mine/export", h.exportHistory)
+
+	// commands changing the board are served by the matcher leader if there is one
+	forward := middleware.ForwardToLeader(leader)
+	g.POST("make", middleware.Audited(models.AuditOrderMake), forward, h.make)
+	g.POST("oco", middleware.Audited(models.AuditOrderMakeOCO), forward, h.makeOCO)
+	g.POST("trailing-stop", middleware.Audited(models.AuditOrderTrailingStop), forward, h.makeTrailingStop)
+	g.PATCH("take", middleware.Audited(models.AuditOrderTake), forward, h.take)
+	g.DELETE(":order_id", middleware.Audited(models.AuditOrderDelete), forward, h.delete)
+	g.POST("batch", middleware.Audited(models.AuditOrderBatch), forward, h.batch)
+
+	p := root.Group("positions")
+	p.Use(middleware.AuthUser(auth))
+	p.Use(middleware.NeedPermission(models.Audience))
+	p.GET("", h.getPosition)
 }
 
 type GetTokenReq struct {
@@ -61,6 +77,7 @@ func (h *orderHandler) getToken(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Set("audit_target", p.UserID)
 	rctx := ctx.Request.Context()
 	token, err := h.auth.IssueToken(rctx, p.UserID, models.Audience)
 	if err != nil {
@@ -109,21 +126,28 @@ func (h *orderHandler) getBoard(ctx *gin.Context) {
 	})
 }
 
-// FIXME: need to consider integer overflow here, for example price*quantity > int max value
-// FIXME: should use fixed type in64 or int32 instead of int to avoid overflow
+// price and quantity are fixed-point decimals encoded as strings, eg: "10.50"
 type makeOrderBody struct {
 	Action   models.OrderAction `json:"action" binding:"required" example:"buy"`
-	Price    int                `json:"price" binding:"required,min=1" example:"10"`
-	Quantity int                `json:"quantity" binding:"required,min=1" example:"100"`
+	Price    models.Decimal     `json:"price" binding:"required,gt=0" swaggertype:"string" example:"10.00"`
+	Quantity models.Decimal     `json:"quantity" binding:"required,gt=0" swaggertype:"string" example:"100.00"`
+	// makes an iceberg order only showing this quantity on the board at a time, the remainder
+	// replenishes it once it is filled
+	DisplayQuantity *models.Decimal `json:"display_quantity" binding:"omitempty,gt=0" swaggertype:"string" example:"10.00"`
+	// post-only orders never match immediately, they are rejected or repriced one tick away from
+	// the other side of the board if they would
+	PostOnly bool `json:"post_only" example:"false"`
 }
 
 //	@Summary		Make a order
-//	@Description	Make a order
+//	@Description	Make a order, orders crossing the other side of the board are rejected unless they are post-only and repricing is enabled.
+//	@Description	Iceberg orders only show their display quantity on the board, and take new time priority each time they are replenished.
 //	@Tags			order
 //	@Param			jsonBody	body	makeOrderBody	true	"order id to attend and user's email"
 //	@Produce		json
 //	@Success		200
 //	@Failure		400	{object}	errorResp
+//	@Failure		409	{object}	errorResp	"market halted or closed"
 //	@Failure		500	{object}	errorResp
 //	@Router			/orders/make [post]
 //	@Security		Bearer
@@ -134,11 +158,20 @@ func (h *orderHandler) make(ctx *gin.Context) {
 		return
 	}
 
+	options := []service.MakeOption{}
+	if b.DisplayQuantity != nil {
+		options = append(options, service.WithDisplayQuantity(*b.DisplayQuantity))
+	}
+	if b.PostOnly {
+		options = append(options, service.WithPostOnly())
+	}
 	if err := h.c.Make(
 		ctx.Request.Context(),
+		ctx.GetString("user_id"),
 		b.Action,
 		b.Price,
 		b.Quantity,
+		options...,
 	); err != nil {
 		handleError(ctx, err)
 		return
@@ -146,10 +179,146 @@ func (h *orderHandler) make(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, nil)
 }
 
+type makeOCOBody struct {
+	Action   models.OrderAction `json:"action" binding:"required,oneof=buy sell" example:"sell"`
+	Quantity models.Decimal     `json:"quantity" binding:"required,gt=0" swaggertype:"string" example:"100.00"`
+	// price of the take-profit limit order
+	TakeProfitPrice models.Decimal `json:"take_profit_price" binding:"required,gt=0" swaggertype:"string" example:"12.00"`
+	// the stop-loss order is put on the board once the latest price reaches stop price
+	StopPrice      models.Decimal `json:"stop_price" binding:"required,gt=0" swaggertype:"string" example:"9.00"`
+	StopLimitPrice models.Decimal `json:"stop_limit_price" binding:"required,gt=0" swaggertype:"string" example:"8.50"`
+}
+
+//	@Summary		Make one-cancels-other orders
+//	@Description	Make a take-profit limit order and a stop-loss stop-limit order linked together, filling either of them, even partially, reduces the other by the filled quantity.
+//	@Tags			order
+//	@Param			jsonBody	body	makeOCOBody	true	"prices and quantity of the linked orders"
+//	@Produce		json
+//	@Success		201	{object}	[]models.Order
+//	@Failure		400	{object}	errorResp
+//	@Failure		409	{object}	errorResp	"market halted or closed"
+//	@Failure		500	{object}	errorResp
+//	@Router			/orders/oco [post]
+//	@Security		Bearer
+func (h *orderHandler) makeOCO(ctx *gin.Context) {
+	b := makeOCOBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	orders, err := h.c.MakeOCO(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		b.Action,
+		b.Quantity,
+		b.TakeProfitPrice,
+		b.StopPrice,
+		b.StopLimitPrice,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	if len(orders) > 0 && orders[0].OCOGroupID != nil {
+		ctx.Set("audit_target", *orders[0].OCOGroupID)
+	}
+	ctx.JSON(http.StatusCreated, orders)
+}
+
+type makeTrailingStopBody struct {
+	Action   models.OrderAction `json:"action" binding:"required,oneof=buy sell" example:"sell"`
+	Quantity models.Decimal     `json:"quantity" binding:"required,gt=0" swaggertype:"string" example:"100.00"`
+	// distance between the stop price and the latest price, exclusive with trail_percent
+	TrailAmount *models.Decimal `json:"trail_amount" swaggertype:"string" example:"0.50"`
+	// distance between the stop price and the latest price in percent of the latest price, exclusive with trail_amount
+	TrailPercent *models.Decimal `json:"trail_percent" swaggertype:"string" example:"5.00"`
+	// distance between the limit price and the stop price, the limit price is below the stop price for sell orders
+	LimitOffset models.Decimal `json:"limit_offset" binding:"gte=0" swaggertype:"string" example:"0.10"`
+}
+
+//	@Summary		Make a trailing stop order
+//	@Description	Make a stop-limit order whose stop price follows the latest price, it only moves up for sell orders and down for buy orders, and is put on the board once the latest price reverses to it.
+//	@Tags			order
+//	@Param			jsonBody	body	makeTrailingStopBody	true	"trail and quantity of the order"
+//	@Produce		json
+//	@Success		201	{object}	models.Order
+//	@Failure		400	{object}	errorResp
+//	@Failure		409	{object}	errorResp	"market halted or closed"
+//	@Failure		500	{object}	errorResp
+//	@Router			/orders/trailing-stop [post]
+//	@Security		Bearer
+func (h *orderHandler) makeTrailingStop(ctx *gin.Context) {
+	b := makeTrailingStopBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	order, err := h.c.MakeTrailingStop(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		b.Action,
+		b.Quantity,
+		b.TrailAmount,
+		b.TrailPercent,
+		b.LimitOffset,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Set("audit_target", order.ID)
+	ctx.JSON(http.StatusCreated, order)
+}
+
+type orderUri struct {
+	OrderID string `uri:"order_id" binding:"required,uuid4"`
+}
+
+type getOrderResp struct {
+	*models.Order
+	// the other orders of its one-cancels-other group
+	Linked []*models.Order `json:"linked"`
+}
+
+//	@Summary		Get my order
+//	@Description	Get an open or pending order of current user, along with the other orders of its one-cancels-other group
+//	@Tags			order
+//	@Param			order_id	path	string	true	"ID of order"
+//	@Produce		json
+//	@Success		200	{object}	getOrderResp
+//	@Failure		400	{object}	errorResp
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/orders/{order_id} [get]
+//	@Security		Bearer
+func (h *orderHandler) get(ctx *gin.Context) {
+	u := orderUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	order, linked, err := h.c.GetOrder(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		u.OrderID,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &getOrderResp{
+		Order:  order,
+		Linked: linked,
+	})
+}
+
 type takeOrderBody struct {
 	// FIXME:user_id should be retrieved from the user's jwt token
 	Action   models.OrderAction `json:"action" binding:"required" example:"buy"`
-	Quantity int                `json:"quantity" binding:"required,min=1" example:"100"`
+	Quantity models.Decimal     `json:"quantity" binding:"required,gt=0" swaggertype:"string" example:"100.00"`
 }
 
 //	@Summary		Take a order
@@ -159,6 +328,7 @@ type takeOrderBody struct {
 //	@Produce		json
 //	@Success		200
 //	@Failure		400	{object}	errorResp
+//	@Failure		409	{object}	errorResp	"market halted, closed or in call auction"
 //	@Failure		500	{object}	errorResp
 //	@Router			/orders/take [patch]
 //	@Security		Bearer
@@ -171,6 +341,7 @@ func (h *orderHandler) take(ctx *gin.Context) {
 
 	if err := h.c.Take(
 		ctx.Request.Context(),
+		ctx.GetString("user_id"),
 		b.Action,
 		b.Quantity,
 	); err != nil {
@@ -180,24 +351,19 @@ func (h *orderHandler) take(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, nil)
 }
 
-type deleteOrderUri struct {
-	OrderID string `uri:"order_id" binding:"required,uuid4"`
-}
-
 //	@Summary		Delete a order
-//	@Description	Delete a order
+//	@Description	Delete a order of the user along with the other orders of its one-cancels-other group
 //	@Tags			order
 //	@Param			order_id	path	string	true	"ID of order"
 //	@Produce		json
 //	@Success		200
 //	@Failure		400	{object}	errorResp
+//	@Failure		404	{object}	errorResp	"the user has no such order"
 //	@Failure		500	{object}	errorResp
 //	@Router			/orders [delete]
 //	@Security		Bearer
 func (h *orderHandler) delete(ctx *gin.Context) {
-	// FIXME: a order must only be deleted by the creator
-
-	u := deleteOrderUri{}
+	u := orderUri{}
 	if err := ctx.BindUri(&u); err != nil {
 		handleError(ctx, err)
 		return
@@ -205,10 +371,211 @@ func (h *orderHandler) delete(ctx *gin.Context) {
 
 	if err := h.c.Delete(
 		ctx.Request.Context(),
+		ctx.GetString("user_id"),
 		u.OrderID,
-	); err != nil { // , p.Code
+	); err != nil {
 		handleError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusCreated, nil)
 }
+
+type batchOperationBody struct {
+	Type models.BatchOperationType `json:"type" binding:"required,oneof=make cancel" example:"make"`
+	// fields of the order to make, the same as making a single order
+	Action          models.OrderAction `json:"action" binding:"required_if=Type make,omitempty,oneof=buy sell" example:"buy"`
+	Price           models.Decimal     `json:"price" binding:"required_if=Type make,omitempty,gt=0" swaggertype:"string" example:"10.00"`
+	Quantity        models.Decimal     `json:"quantity" binding:"required_if=Type make,omitempty,gt=0" swaggertype:"string" example:"100.00"`
+	DisplayQuantity *models.Decimal    `json:"display_quantity" binding:"omitempty,gt=0" swaggertype:"string" example:"10.00"`
+	PostOnly        bool               `json:"post_only" example:"false"`
+	// ID of the order to cancel, along with its one-cancels-other group
+	OrderID string `json:"order_id" binding:"required_if=Type cancel,omitempty,uuid4" example:"uuid"`
+}
+
+type batchBody struct {
+	Operations []*batchOperationBody `json:"operations" binding:"required,min=1,dive"`
+	// executes all operations in a single transaction, none of them is executed if any fails
+	Atomic bool `json:"atomic" example:"false"`
+}
+
+type batchResp struct {
+	// in the order of operations
+	Results []*models.BatchResult `json:"results"`
+}
+
+//	@Summary		Make and cancel orders in a batch
+//	@Description	Make and cancel orders in the order of operations, the result of each operation is returned whether it succeeds or not.
+//	@Description	Operations are executed one by one unless atomic is set, in which case either all of them are executed in a single transaction or none is.
+//	@Description	Only orders of current user can be cancelled, the number of operations is limited by ORDER_BATCH_MAX_SIZE.
+//	@Tags			order
+//	@Param			jsonBody	body	batchBody	true	"operations to execute"
+//	@Produce		json
+//	@Success		200	{object}	batchResp
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/orders/batch [post]
+//	@Security		Bearer
+func (h *orderHandler) batch(ctx *gin.Context) {
+	b := batchBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	operations := make([]*models.BatchOperation, 0, len(b.Operations))
+	for _, o := range b.Operations {
+		operations = append(operations, &models.BatchOperation{
+			Type:            o.Type,
+			Action:          o.Action,
+			Price:           o.Price,
+			Quantity:        o.Quantity,
+			DisplayQuantity: o.DisplayQuantity,
+			PostOnly:        o.PostOnly,
+			OrderID:         o.OrderID,
+		})
+	}
+	results, err := h.c.Batch(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		operations,
+		b.Atomic,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &batchResp{
+		Results: results,
+	})
+}
+
+type exportHistoryReq struct {
+	Format string `form:"format" binding:"required,oneof=csv jsonl"`
+	// RFC 3339 time, orders and fills created at or after it
+	From *time.Time `form:"from"`
+	// RFC 3339 time, orders and fills created before it
+	To *time.Time `form:"to"`
+}
+
+//	@Summary		Export my order history
+//	@Description	Stream all orders and then all fills of current user as CSV or JSON Lines, each most recent first.
+//	@Description	Filled and cancelled orders are exported along with open and pending ones, with the time they were closed.
+//	@Description	The response is chunked, the connection is closed before the end of the response if the export fails midway.
+//	@Tags			order
+//	@Param			input	query	exportHistoryReq	true	"format and period"
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Success		200	{array}		models.HistoryRecord
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/orders/mine/export [get]
+//	@Security		Bearer
+func (h *orderHandler) exportHistory(ctx *gin.Context) {
+	p := exportHistoryReq{}
+	if err := ctx.BindQuery(&p); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	contentType := "application/x-ndjson"
+	header := func() error {
+		return nil
+	}
+	var encode func(*models.HistoryRecord) error
+	flush := func() error {
+		return nil
+	}
+	switch p.Format {
+	case "csv":
+		w := csv.NewWriter(ctx.Writer)
+		contentType = "text/csv"
+		header = func() error {
+			return w.Write(models.HistoryCSVHeader)
+		}
+		encode = func(r *models.HistoryRecord) error {
+			return w.Write(r.CSV())
+		}
+		flush = func() error {
+			w.Flush()
+			return w.Error()
+		}
+	default:
+		e := json.NewEncoder(ctx.Writer)
+		encode = func(r *models.HistoryRecord) error {
+			return e.Encode(r)
+		}
+	}
+
+	// the response starts with the first page, so failures before it are still reported as errors
+	started := false
+	start := func() error {
+		started = true
+		ctx.Header("Content-Type", contentType)
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="history.%s"`, p.Format))
+		ctx.Status(http.StatusOK)
+		return header()
+	}
+	write := func(records []*models.HistoryRecord) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		for _, r := range records {
+			if err := encode(r); err != nil {
+				return err
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		// sent as a chunk, so the history is never held in memory all at once
+		ctx.Writer.Flush()
+		return nil
+	}
+
+	err := h.c.ExportHistory(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		p.From,
+		p.To,
+		write,
+	)
+	if err == nil && !started {
+		// nothing to export, still an empty file
+		err = write(nil)
+	}
+	if err != nil {
+		if !started {
+			handleError(ctx, err)
+			return
+		}
+		// the status is sent already, closing the connection without ending the chunked response
+		// tells the client the export is incomplete
+		logging.Errorw(ctx.Request.Context(), "export history failed midway", "err", err)
+		if conn, _, err := ctx.Writer.Hijack(); err == nil {
+			conn.Close()
+		}
+		ctx.Abort()
+	}
+}
+
+//	@Summary		Get my position
+//	@Description	Get net quantity, average entry price, realized PnL and unrealized PnL marked at the latest price of current user
+//	@Tags			order
+//	@Produce		json
+//	@Success		200	{object}	models.Position
+//	@Failure		500	{object}	errorResp
+//	@Router			/positions [get]
+//	@Security		Bearer
+func (h *orderHandler) getPosition(ctx *gin.Context) {
+	position, err := h.c.GetPosition(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, position)
+}
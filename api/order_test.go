@@ -60,7 +60,7 @@ func TestGetBoardAPIIntegration(t *testing.T) {
 	if err = json.Unmarshal(rawBoard, &board); err != nil {
 		t.Fatal(err.Error())
 	}
-	t.Logf("LatestPrice %d", board.LatestPrice)
+	t.Logf("LatestPrice %s", board.LatestPrice)
 	t.Logf("Board: %+v", board)
 
 	require.Equal(t, true, board.LatestPrice != 0, "expect latest price to be non-zero")
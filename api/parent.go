@@ -0,0 +1,198 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/A-pen-app/kickstart/api/middleware"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/gin-gonic/gin"
+)
+
+type parentHandler struct {
+	p service.Parent
+}
+
+func addParentRoutes(root *gin.RouterGroup, p service.Parent, auth service.Auth, leader service.Leader) {
+	h := &parentHandler{
+		p: p,
+	}
+
+	g := root.Group("parent-orders")
+	g.Use(middleware.AuthUser(auth))
+	g.Use(middleware.NeedPermission(models.Audience))
+	g.GET(":parent_id", h.get)
+
+	// parent orders are scheduled by the matcher leader if there is one
+	forward := middleware.ForwardToLeader(leader)
+	g.POST("twap", middleware.Audited(models.AuditParentMakeTWAP), forward, h.makeTWAP)
+	g.POST(":parent_id/pause", middleware.Audited(models.AuditParentPause), forward, h.pause)
+	g.POST(":parent_id/resume", middleware.Audited(models.AuditParentResume), forward, h.resume)
+	g.POST(":parent_id/cancel", middleware.Audited(models.AuditParentCancel), forward, h.cancel)
+}
+
+type makeTWAPBody struct {
+	Action   models.OrderAction `json:"action" binding:"required,oneof=buy sell" example:"buy"`
+	Quantity models.Decimal     `json:"quantity" binding:"required,gt=0" swaggertype:"string" example:"1000.00"`
+	// child orders are limit orders at this price if given, otherwise they take from the board
+	LimitPrice *models.Decimal `json:"limit_price" swaggertype:"string" example:"10.00"`
+	// starts now if absent
+	StartAt *time.Time `json:"start_at" example:"2021-01-01T09:00:00Z"`
+	EndAt   time.Time  `json:"end_at" binding:"required" example:"2021-01-01T10:00:00Z"`
+	// number of child orders
+	Slices int `json:"slices" binding:"required,gt=0" example:"10"`
+}
+
+//	@Summary		Make a TWAP parent order
+//	@Description	Split a large quantity into child orders of even quantity sent evenly from start_at to end_at, the schedule survives restarts. A child order failing to be sent is skipped and its quantity is spread over the remaining ones.
+//	@Tags			parent order
+//	@Param			jsonBody	body	makeTWAPBody	true	"quantity and schedule of the parent order"
+//	@Produce		json
+//	@Success		201	{object}	models.ParentOrder
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/parent-orders/twap [post]
+//	@Security		Bearer
+func (h *parentHandler) makeTWAP(ctx *gin.Context) {
+	b := makeTWAPBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	startAt := time.Time{}
+	if b.StartAt != nil {
+		startAt = *b.StartAt
+	}
+
+	parent, err := h.p.MakeTWAP(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		b.Action,
+		b.Quantity,
+		b.LimitPrice,
+		startAt,
+		b.EndAt,
+		b.Slices,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Set("audit_target", parent.ID)
+	ctx.JSON(http.StatusCreated, parent)
+}
+
+type parentUri struct {
+	ParentID string `uri:"parent_id" binding:"required,uuid4"`
+}
+
+//	@Summary		Get my parent order
+//	@Description	Get a parent order of current user along with its progress
+//	@Tags			parent order
+//	@Param			parent_id	path	string	true	"ID of parent order"
+//	@Produce		json
+//	@Success		200	{object}	models.ParentOrder
+//	@Failure		400	{object}	errorResp
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/parent-orders/{parent_id} [get]
+//	@Security		Bearer
+func (h *parentHandler) get(ctx *gin.Context) {
+	u := parentUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	parent, err := h.p.GetParent(ctx.Request.Context(), ctx.GetString("user_id"), u.ParentID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, parent)
+}
+
+//	@Summary		Pause my parent order
+//	@Description	Stop sending child orders of an active parent order until it is resumed
+//	@Tags			parent order
+//	@Param			parent_id	path	string	true	"ID of parent order"
+//	@Produce		json
+//	@Success		200	{object}	models.ParentOrder
+//	@Failure		400	{object}	errorResp
+//	@Failure		403	{object}	errorResp	"parent order is not active"
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/parent-orders/{parent_id}/pause [post]
+//	@Security		Bearer
+func (h *parentHandler) pause(ctx *gin.Context) {
+	u := parentUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Set("audit_target", u.ParentID)
+
+	parent, err := h.p.Pause(ctx.Request.Context(), ctx.GetString("user_id"), u.ParentID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, parent)
+}
+
+//	@Summary		Resume my parent order
+//	@Description	Resume sending child orders of a paused parent order, slices due while paused are sent one after another
+//	@Tags			parent order
+//	@Param			parent_id	path	string	true	"ID of parent order"
+//	@Produce		json
+//	@Success		200	{object}	models.ParentOrder
+//	@Failure		400	{object}	errorResp
+//	@Failure		403	{object}	errorResp	"parent order is not paused"
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/parent-orders/{parent_id}/resume [post]
+//	@Security		Bearer
+func (h *parentHandler) resume(ctx *gin.Context) {
+	u := parentUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Set("audit_target", u.ParentID)
+
+	parent, err := h.p.Resume(ctx.Request.Context(), ctx.GetString("user_id"), u.ParentID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, parent)
+}
+
+//	@Summary		Cancel my parent order
+//	@Description	Stop sending child orders of an active or paused parent order, the child orders already sent are kept
+//	@Tags			parent order
+//	@Param			parent_id	path	string	true	"ID of parent order"
+//	@Produce		json
+//	@Success		200	{object}	models.ParentOrder
+//	@Failure		400	{object}	errorResp
+//	@Failure		403	{object}	errorResp	"parent order is completed or cancelled"
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/parent-orders/{parent_id}/cancel [post]
+//	@Security		Bearer
+func (h *parentHandler) cancel(ctx *gin.Context) {
+	u := parentUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Set("audit_target", u.ParentID)
+
+	parent, err := h.p.Cancel(ctx.Request.Context(), ctx.GetString("user_id"), u.ParentID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, parent)
+}
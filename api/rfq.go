@@ -0,0 +1,170 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/A-pen-app/kickstart/api/middleware"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/gin-gonic/gin"
+)
+
+type rfqHandler struct {
+	r service.RFQ
+}
+
+func addRFQRoutes(root *gin.RouterGroup, r service.RFQ, auth service.Auth, leader service.Leader) {
+	h := &rfqHandler{
+		r: r,
+	}
+
+	g := root.Group("rfq")
+	g.Use(middleware.AuthUser(auth))
+	g.Use(middleware.NeedPermission(models.Audience))
+	g.POST("", h.request)
+	g.GET(":rfq_id", h.get)
+
+	// accepting a quote trades, so it is served by the matcher leader if there is one
+	forward := middleware.ForwardToLeader(leader)
+	g.POST(":rfq_id/quotes/:quote_id/accept", middleware.Audited(models.AuditRFQAccept), forward, h.accept)
+
+	// quotes are only made by market makers
+	m := root.Group("rfq")
+	m.Use(middleware.AuthUser(auth))
+	m.Use(middleware.NeedPermission(models.Official))
+	m.POST(":rfq_id/quotes", h.quote)
+}
+
+type requestQuoteBody struct {
+	Action   models.OrderAction `json:"action" binding:"required,oneof=buy sell" example:"buy"`
+	Quantity models.Decimal     `json:"quantity" binding:"required,gt=0" swaggertype:"string" example:"10000.00"`
+}
+
+//	@Summary		Request for quote
+//	@Description	Broadcast a request for quote of a block trade to the market makers, it collects quotes for a limited time and never hits the board
+//	@Tags			rfq
+//	@Param			jsonBody	body	requestQuoteBody	true	"side and quantity of the block trade"
+//	@Produce		json
+//	@Success		201	{object}	models.RFQ
+//	@Failure		400	{object}	errorResp
+//	@Failure		409	{object}	errorResp	"market halted or closed"
+//	@Failure		500	{object}	errorResp
+//	@Router			/rfq [post]
+//	@Security		Bearer
+func (h *rfqHandler) request(ctx *gin.Context) {
+	b := requestQuoteBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rfq, err := h.r.Request(ctx.Request.Context(), ctx.GetString("user_id"), b.Action, b.Quantity)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, rfq)
+}
+
+type rfqUri struct {
+	RFQID string `uri:"rfq_id" binding:"required,uuid4"`
+}
+
+//	@Summary		Get an RFQ
+//	@Description	Get an RFQ along with all its quotes for the requester, or with their own quotes for market makers
+//	@Tags			rfq
+//	@Param			rfq_id	path	string	true	"ID of RFQ"
+//	@Produce		json
+//	@Success		200	{object}	models.RFQ
+//	@Failure		400	{object}	errorResp
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/rfq/{rfq_id} [get]
+//	@Security		Bearer
+func (h *rfqHandler) get(ctx *gin.Context) {
+	u := rfqUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	scope, _ := ctx.Get("scope")
+	userType, _ := scope.(models.UserType)
+
+	rfq, err := h.r.GetRFQ(ctx.Request.Context(), ctx.GetString("user_id"), userType, u.RFQID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rfq)
+}
+
+type quoteBody struct {
+	Price models.Decimal `json:"price" binding:"required,gt=0" swaggertype:"string" example:"10.00"`
+}
+
+//	@Summary		Quote an RFQ
+//	@Description	Offer a price for the quantity of an open RFQ as a market maker, the quote is valid for a limited time within the RFQ
+//	@Tags			rfq
+//	@Param			rfq_id		path	string		true	"ID of RFQ"
+//	@Param			jsonBody	body	quoteBody	true	"price to trade at"
+//	@Produce		json
+//	@Success		201	{object}	models.Quote
+//	@Failure		400	{object}	errorResp
+//	@Failure		403	{object}	errorResp	"RFQ is no longer open, or is requested by yourself"
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/rfq/{rfq_id}/quotes [post]
+//	@Security		Bearer
+func (h *rfqHandler) quote(ctx *gin.Context) {
+	u := rfqUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	b := quoteBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	quote, err := h.r.Quote(ctx.Request.Context(), ctx.GetString("user_id"), u.RFQID, b.Price)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, quote)
+}
+
+type quoteUri struct {
+	RFQID   string `uri:"rfq_id" binding:"required,uuid4"`
+	QuoteID string `uri:"quote_id" binding:"required,uuid4"`
+}
+
+//	@Summary		Accept a quote
+//	@Description	Accept a quote of my RFQ before either expires, it is executed as an off-book trade at the quoted price
+//	@Tags			rfq
+//	@Param			rfq_id		path	string	true	"ID of RFQ"
+//	@Param			quote_id	path	string	true	"ID of quote"
+//	@Produce		json
+//	@Success		201	{object}	models.Trade
+//	@Failure		400	{object}	errorResp
+//	@Failure		403	{object}	errorResp	"RFQ or quote is no longer open"
+//	@Failure		404	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/rfq/{rfq_id}/quotes/{quote_id}/accept [post]
+//	@Security		Bearer
+func (h *rfqHandler) accept(ctx *gin.Context) {
+	u := quoteUri{}
+	if err := ctx.BindUri(&u); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Set("audit_target", u.QuoteID)
+
+	trade, err := h.r.Accept(ctx.Request.Context(), ctx.GetString("user_id"), u.RFQID, u.QuoteID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, trade)
+}
@@ -0,0 +1,291 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/url"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/A-pen-app/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// forwardedKey marks calls forwarded by a follower, so they are never forwarded again
+	forwardedKey = "x-matcher-forwarded"
+	// forwardedForKey carries the IP of the client of calls forwarded by a follower
+	forwardedForKey = "x-forwarded-for"
+)
+
+// auditedMethods are recorded to the audit trail once served whether they succeed or not
+var auditedMethods = map[string]models.AuditAction{
+	OrderService_Make_FullMethodName:   models.AuditOrderMake,
+	OrderService_Take_FullMethodName:   models.AuditOrderTake,
+	OrderService_Delete_FullMethodName: models.AuditOrderDelete,
+}
+
+// serverStream replaces the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// statusError logs err and translates it to a status error
+func statusError(ctx context.Context, err error) error {
+	logging.Error(ctx, err.Error())
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, models.ErrorNotFound):
+		code = codes.NotFound
+	case errors.Is(err, models.ErrorWrongParams):
+		code = codes.InvalidArgument
+	case errors.Is(err, models.ErrorUnsupported):
+		code = codes.Unimplemented
+	case errors.Is(err, models.ErrorNotAllowed):
+		code = codes.PermissionDenied
+	case errors.Is(err, models.ErrorMarketHalted), errors.Is(err, models.ErrorMarketClosed), errors.Is(err, models.ErrorMarketAuction):
+		code = codes.FailedPrecondition
+	case errors.Is(err, models.ErrorBookBusy), errors.Is(err, models.ErrorNoLeader):
+		code = codes.Unavailable
+	}
+	// we use trace id as request id
+	requestID := trace.SpanContextFromContext(ctx).TraceID().String()
+	return status.Errorf(code, "%s (request_id: %s)", err.Error(), requestID)
+}
+
+// recoverUnary recovers & logs panics of unary calls
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Errorw(ctx, "panic recovered", "panic", r, "method", info.FullMethod, "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// recoverStream recovers & logs panics of streams
+func recoverStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Errorw(ss.Context(), "panic recovered", "panic", r, "method", info.FullMethod, "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// withClient puts the client IP and payload hash of req into ctx, the same as middleware.Audit does,
+// so entries recorded to the audit trail while serving the call carry them
+func withClient(ctx context.Context, req proto.Message) context.Context {
+	ip := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(forwardedKey)) > 0 {
+		if v := md.Get(forwardedForKey); len(v) > 0 {
+			ip = v[0]
+		}
+	}
+	ctx = context.WithValue(ctx, "client_ip", ip)
+
+	if req != nil {
+		if payload, err := (proto.MarshalOptions{Deterministic: true}).Marshal(req); err == nil && len(payload) > 0 {
+			sum := sha256.Sum256(payload)
+			ctx = context.WithValue(ctx, "payload_hash", hex.EncodeToString(sum[:]))
+		}
+	}
+	return ctx
+}
+
+// authorize validates the token in the authorization metadata of ctx and puts the user into ctx, the
+// same as middleware.AuthUser and middleware.NeedPermission do, denials are recorded to the audit trail
+func authorize(ctx context.Context, method string, a service.Auth, au service.Audit, userType models.UserType) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization")
+	}
+	parts := strings.SplitN(values[0], " ", 2)
+	token := parts[0]
+	if len(parts) > 1 {
+		if parts[0] != "Bearer" {
+			// we don't support other authorization schemes
+			return nil, status.Error(codes.Unauthenticated, "unsupported authorization scheme")
+		}
+		token = parts[1]
+	}
+
+	// validate if this token is issued by us
+	claims, err := a.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	ctx = context.WithValue(ctx, "userID", claims.Subject)
+	ctx = context.WithValue(ctx, "user_id", claims.Subject)
+	ctx = context.WithValue(ctx, "scope", claims.Scope.String())
+	ctx = context.WithValue(ctx, "aud", []string(claims.Audience))
+
+	if claims.Scope < userType {
+		if err := au.Record(context.WithoutCancel(ctx), claims.Subject, models.AuditPermissionDenied, method, map[string]interface{}{
+			"method": method,
+			"code":   codes.PermissionDenied.String(),
+		}); err != nil {
+			logging.Errorw(ctx, "record audit entry of call failed", "err", err, "method", method)
+		}
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	return ctx, nil
+}
+
+// authUnary authorizes unary calls of users at least of userType
+func authUnary(a service.Auth, au service.Audit, userType models.UserType) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		m, _ := req.(proto.Message)
+		ctx, err := authorize(withClient(ctx, m), info.FullMethod, a, au, userType)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStream authorizes streams of users at least of userType
+func authStream(a service.Auth, au service.Audit, userType models.UserType) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(withClient(ss.Context(), nil), info.FullMethod, a, au, userType)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// auditUnary records calls of auditedMethods to the audit trail
+func auditUnary(au service.Audit) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		action, ok := auditedMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		resp, err := handler(ctx, req)
+
+		target := info.FullMethod
+		if r, ok := req.(*DeleteRequest); ok {
+			target = r.OrderId
+		}
+		// the call is served already, failing to record it is only logged
+		c := context.WithoutCancel(ctx)
+		if err := au.Record(c, userID(ctx), action, target, map[string]interface{}{
+			"method": info.FullMethod,
+			"code":   status.Code(err).String(),
+		}); err != nil {
+			logging.Errorw(c, "record audit entry of call failed", "err", err, "action", action, "target", target)
+		}
+		return resp, err
+	}
+}
+
+// forwarder forwards calls of auditedMethods, the commands changing the board, to the gRPC server of
+// the process holding the matcher lease unless it is this one
+type forwarder struct {
+	l service.Leader
+	// the gRPC port of the leader, every process listens on the same port
+	port string
+
+	mu      sync.Mutex
+	address string
+	conn    *grpc.ClientConn
+}
+
+func newForwarder(l service.Leader, port string) *forwarder {
+	return &forwarder{
+		l:    l,
+		port: port,
+	}
+}
+
+// dial returns the connection to the leader listening on address, the connection to the previous
+// leader is closed once leadership moves
+func (f *forwarder) dial(address string) (*grpc.ClientConn, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn != nil && f.address == address {
+		return f.conn, nil
+	}
+
+	// the lease holds the address of the HTTP server of the leader
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(
+		net.JoinHostPort(u.Hostname(), f.port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if f.conn != nil {
+		f.conn.Close()
+	}
+	f.address, f.conn = address, conn
+	return conn, nil
+}
+
+// unary serves calls locally if there is no leader, i.e. every process matches orders
+func (f *forwarder) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if _, ok := auditedMethods[info.FullMethod]; !ok || f.l == nil || f.l.IsLeader() {
+		return handler(ctx, req)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	// leadership moved while the call was being forwarded, let the client retry
+	if len(md.Get(forwardedKey)) > 0 {
+		return nil, status.Error(codes.Unavailable, "matcher leadership moved")
+	}
+	lease, err := f.l.Leader(ctx)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	conn, err := f.dial(lease.Address)
+	if err != nil {
+		logging.Errorw(ctx, "dial matcher leader failed", "err", err, "address", lease.Address)
+		return nil, status.Error(codes.Internal, "dial matcher leader failed")
+	}
+
+	ip, _ := ctx.Value("client_ip").(string)
+	out := metadata.Pairs(forwardedKey, lease.Holder, forwardedForKey, ip)
+	out.Set("authorization", md.Get("authorization")...)
+	resp := &Empty{}
+	if err := conn.Invoke(metadata.NewOutgoingContext(ctx, out), info.FullMethod, req, resp); err != nil {
+		if status.Code(err) == codes.Unavailable {
+			logging.Errorw(ctx, "forward to matcher leader failed", "err", err, "address", lease.Address)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
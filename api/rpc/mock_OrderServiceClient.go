@@ -0,0 +1,215 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package rpc
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOrderServiceClient is an autogenerated mock type for the OrderServiceClient type
+type MockOrderServiceClient struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, in, opts
+func (_m *MockOrderServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *DeleteRequest, ...grpc.CallOption) (*Empty, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *DeleteRequest, ...grpc.CallOption) *Empty); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *DeleteRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBoard provides a mock function with given fields: ctx, in, opts
+func (_m *MockOrderServiceClient) GetBoard(ctx context.Context, in *GetBoardRequest, opts ...grpc.CallOption) (*Board, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBoard")
+	}
+
+	var r0 *Board
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *GetBoardRequest, ...grpc.CallOption) (*Board, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *GetBoardRequest, ...grpc.CallOption) *Board); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Board)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *GetBoardRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Make provides a mock function with given fields: ctx, in, opts
+func (_m *MockOrderServiceClient) Make(ctx context.Context, in *MakeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Make")
+	}
+
+	var r0 *Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *MakeRequest, ...grpc.CallOption) (*Empty, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *MakeRequest, ...grpc.CallOption) *Empty); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *MakeRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeBoard provides a mock function with given fields: ctx, in, opts
+func (_m *MockOrderServiceClient) SubscribeBoard(ctx context.Context, in *SubscribeBoardRequest, opts ...grpc.CallOption) (OrderService_SubscribeBoardClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeBoard")
+	}
+
+	var r0 OrderService_SubscribeBoardClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *SubscribeBoardRequest, ...grpc.CallOption) (OrderService_SubscribeBoardClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *SubscribeBoardRequest, ...grpc.CallOption) OrderService_SubscribeBoardClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(OrderService_SubscribeBoardClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *SubscribeBoardRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Take provides a mock function with given fields: ctx, in, opts
+func (_m *MockOrderServiceClient) Take(ctx context.Context, in *TakeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 *Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TakeRequest, ...grpc.CallOption) (*Empty, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *TakeRequest, ...grpc.CallOption) *Empty); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *TakeRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockOrderServiceClient creates a new instance of MockOrderServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderServiceClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderServiceClient {
+	mock := &MockOrderServiceClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
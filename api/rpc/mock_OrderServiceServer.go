@@ -0,0 +1,171 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package rpc
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOrderServiceServer is an autogenerated mock type for the OrderServiceServer type
+type MockOrderServiceServer struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *MockOrderServiceServer) Delete(_a0 context.Context, _a1 *DeleteRequest) (*Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *DeleteRequest) (*Empty, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *DeleteRequest) *Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *DeleteRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBoard provides a mock function with given fields: _a0, _a1
+func (_m *MockOrderServiceServer) GetBoard(_a0 context.Context, _a1 *GetBoardRequest) (*Board, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBoard")
+	}
+
+	var r0 *Board
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *GetBoardRequest) (*Board, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *GetBoardRequest) *Board); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Board)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *GetBoardRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Make provides a mock function with given fields: _a0, _a1
+func (_m *MockOrderServiceServer) Make(_a0 context.Context, _a1 *MakeRequest) (*Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Make")
+	}
+
+	var r0 *Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *MakeRequest) (*Empty, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *MakeRequest) *Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *MakeRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeBoard provides a mock function with given fields: _a0, _a1
+func (_m *MockOrderServiceServer) SubscribeBoard(_a0 *SubscribeBoardRequest, _a1 OrderService_SubscribeBoardServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeBoard")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*SubscribeBoardRequest, OrderService_SubscribeBoardServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Take provides a mock function with given fields: _a0, _a1
+func (_m *MockOrderServiceServer) Take(_a0 context.Context, _a1 *TakeRequest) (*Empty, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 *Empty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TakeRequest) (*Empty, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *TakeRequest) *Empty); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Empty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *TakeRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mustEmbedUnimplementedOrderServiceServer provides a mock function with given fields:
+func (_m *MockOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {
+	_m.Called()
+}
+
+// NewMockOrderServiceServer creates a new instance of MockOrderServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderServiceServer {
+	mock := &MockOrderServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
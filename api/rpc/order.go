@@ -0,0 +1,193 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/A-pen-app/logging"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type orderServer struct {
+	UnimplementedOrderServiceServer
+	c service.Order
+	// how often subscriptions check the board for changes
+	subscribeInterval time.Duration
+	// done once the server is shutting down
+	shutdown context.Context
+}
+
+func (s *orderServer) GetBoard(ctx context.Context, req *GetBoardRequest) (*Board, error) {
+	board, _, err := s.c.GetBoard(ctx, parseBoardType(req.BoardType))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return newBoard(board), nil
+}
+
+func (s *orderServer) Make(ctx context.Context, req *MakeRequest) (*Empty, error) {
+	action, err := parseAction(req.Action)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	price, err := parsePositiveDecimal("price", req.Price)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	quantity, err := parsePositiveDecimal("quantity", req.Quantity)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	options := []service.MakeOption{}
+	if req.DisplayQuantity != "" {
+		displayQuantity, err := parsePositiveDecimal("display_quantity", req.DisplayQuantity)
+		if err != nil {
+			return nil, statusError(ctx, err)
+		}
+		options = append(options, service.WithDisplayQuantity(displayQuantity))
+	}
+	if req.PostOnly {
+		options = append(options, service.WithPostOnly())
+	}
+	if err := s.c.Make(ctx, userID(ctx), action, price, quantity, options...); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *orderServer) Take(ctx context.Context, req *TakeRequest) (*Empty, error) {
+	action, err := parseAction(req.Action)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	quantity, err := parsePositiveDecimal("quantity", req.Quantity)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	if err := s.c.Take(ctx, userID(ctx), action, quantity); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &Empty{}, nil
+}
+
+func (s *orderServer) Delete(ctx context.Context, req *DeleteRequest) (*Empty, error) {
+	if _, err := uuid.Parse(req.OrderId); err != nil {
+		return nil, statusError(ctx, &models.FieldError{Field: "order_id", Reason: "should be a UUID"})
+	}
+	if err := s.c.Delete(ctx, userID(ctx), req.OrderId); err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return &Empty{}, nil
+}
+
+// SubscribeBoard polls the board every subscribeInterval and sends it when it differs from the last
+// one sent. The board is cached by the order service, so subscribers share the queries.
+func (s *orderServer) SubscribeBoard(req *SubscribeBoardRequest, stream OrderService_SubscribeBoardServer) error {
+	ctx := stream.Context()
+	boardType := parseBoardType(req.BoardType)
+	ticker := time.NewTicker(s.subscribeInterval)
+	defer ticker.Stop()
+
+	var last *Board
+	for {
+		board, _, err := s.c.GetBoard(ctx, boardType)
+		switch {
+		case err != nil && last == nil:
+			return statusError(ctx, err)
+		case err != nil:
+			// keep the subscription, the board is sent once it can be read again
+			logging.Errorw(ctx, "get board of subscription failed", "err", err, "boardType", boardType)
+		default:
+			m := newBoard(board)
+			if !proto.Equal(m, last) {
+				if err := stream.Send(m); err != nil {
+					return err
+				}
+				last = m
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.shutdown.Done():
+			// the client subscribes again, to another replica
+			return status.Error(codes.Unavailable, "server shutting down")
+		case <-ticker.C:
+		}
+	}
+}
+
+// userID returns the user authenticated by the auth interceptors
+func userID(ctx context.Context) string {
+	id, _ := ctx.Value("user_id").(string)
+	return id
+}
+
+func parseBoardType(boardType string) models.OrderBoardType {
+	if boardType == "" {
+		return models.Live
+	}
+	return models.OrderBoardType(boardType)
+}
+
+func parseAction(action string) (models.OrderAction, error) {
+	switch a := models.OrderAction(action); a {
+	case models.Buy, models.Sell:
+		return a, nil
+	}
+	return "", &models.FieldError{Field: "action", Reason: "should be buy or sell"}
+}
+
+func parsePositiveDecimal(field, s string) (models.Decimal, error) {
+	d, err := models.ParseDecimal(s)
+	if err != nil {
+		return 0, &models.FieldError{Field: field, Reason: "should be a decimal"}
+	}
+	if d <= 0 {
+		return 0, &models.FieldError{Field: field, Reason: "should be positive"}
+	}
+	return d, nil
+}
+
+func newBoard(board *models.Board) *Board {
+	m := &Board{
+		LatestPrice: board.LatestPrice.String(),
+		MarketState: string(board.MarketState),
+		BuyOrders:   make([]*Order, 0, len(board.BuyOrders)),
+		SellOrders:  make([]*Order, 0, len(board.SellOrders)),
+	}
+	if a := board.Auction; a != nil {
+		m.Auction = &Auction{
+			IndicativeVolume: a.Volume.String(),
+			Imbalance:        a.Imbalance.String(),
+		}
+		if a.Price != nil {
+			m.Auction.IndicativePrice = a.Price.String()
+		}
+	}
+	for _, o := range board.BuyOrders {
+		m.BuyOrders = append(m.BuyOrders, newOrder(o))
+	}
+	for _, o := range board.SellOrders {
+		m.SellOrders = append(m.SellOrders, newOrder(o))
+	}
+	return m
+}
+
+func newOrder(o *models.Order) *Order {
+	return &Order{
+		Id:        o.ID,
+		Action:    string(o.Action),
+		Price:     o.Price.String(),
+		Quantity:  o.Quantity.String(),
+		CreatedAt: o.CreatedAt.UTC().Format(time.RFC3339Nano),
+		Status:    string(o.Status),
+	}
+}
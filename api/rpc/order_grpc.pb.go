@@ -0,0 +1,308 @@
+// gRPC API of the order service, served next to the HTTP API on GRPC_LISTEN_PORT.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.26.1
+// source: api/rpc/order.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	OrderService_GetBoard_FullMethodName       = "/kickstart.v1.OrderService/GetBoard"
+	OrderService_Make_FullMethodName           = "/kickstart.v1.OrderService/Make"
+	OrderService_Take_FullMethodName           = "/kickstart.v1.OrderService/Take"
+	OrderService_Delete_FullMethodName         = "/kickstart.v1.OrderService/Delete"
+	OrderService_SubscribeBoard_FullMethodName = "/kickstart.v1.OrderService/SubscribeBoard"
+)
+
+// OrderServiceClient is the client API for OrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OrderService requires a JWT issued by the token API in the "authorization" metadata, as
+// "Bearer {{token}}" or the bare token. Prices and quantities are fixed-point decimals encoded
+// as strings, eg: "10.50".
+type OrderServiceClient interface {
+	GetBoard(ctx context.Context, in *GetBoardRequest, opts ...grpc.CallOption) (*Board, error)
+	// Make, Take and Delete are served by the matcher leader if there is one
+	Make(ctx context.Context, in *MakeRequest, opts ...grpc.CallOption) (*Empty, error)
+	Take(ctx context.Context, in *TakeRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Delete deletes an order of the caller along with its one-cancels-other group, NOT_FOUND if
+	// the caller has no such order
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+	// SubscribeBoard sends the board once subscribed and then every time it changes
+	SubscribeBoard(ctx context.Context, in *SubscribeBoardRequest, opts ...grpc.CallOption) (OrderService_SubscribeBoardClient, error)
+}
+
+type orderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderServiceClient(cc grpc.ClientConnInterface) OrderServiceClient {
+	return &orderServiceClient{cc}
+}
+
+func (c *orderServiceClient) GetBoard(ctx context.Context, in *GetBoardRequest, opts ...grpc.CallOption) (*Board, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Board)
+	err := c.cc.Invoke(ctx, OrderService_GetBoard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Make(ctx context.Context, in *MakeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, OrderService_Make_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Take(ctx context.Context, in *TakeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, OrderService_Take_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, OrderService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SubscribeBoard(ctx context.Context, in *SubscribeBoardRequest, opts ...grpc.CallOption) (OrderService_SubscribeBoardClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[0], OrderService_SubscribeBoard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceSubscribeBoardClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderService_SubscribeBoardClient interface {
+	Recv() (*Board, error)
+	grpc.ClientStream
+}
+
+type orderServiceSubscribeBoardClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceSubscribeBoardClient) Recv() (*Board, error) {
+	m := new(Board)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// OrderServiceServer is the server API for OrderService service.
+// All implementations must embed UnimplementedOrderServiceServer
+// for forward compatibility
+//
+// OrderService requires a JWT issued by the token API in the "authorization" metadata, as
+// "Bearer {{token}}" or the bare token. Prices and quantities are fixed-point decimals encoded
+// as strings, eg: "10.50".
+type OrderServiceServer interface {
+	GetBoard(context.Context, *GetBoardRequest) (*Board, error)
+	// Make, Take and Delete are served by the matcher leader if there is one
+	Make(context.Context, *MakeRequest) (*Empty, error)
+	Take(context.Context, *TakeRequest) (*Empty, error)
+	// Delete deletes an order of the caller along with its one-cancels-other group, NOT_FOUND if
+	// the caller has no such order
+	Delete(context.Context, *DeleteRequest) (*Empty, error)
+	// SubscribeBoard sends the board once subscribed and then every time it changes
+	SubscribeBoard(*SubscribeBoardRequest, OrderService_SubscribeBoardServer) error
+	mustEmbedUnimplementedOrderServiceServer()
+}
+
+// UnimplementedOrderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOrderServiceServer struct {
+}
+
+func (UnimplementedOrderServiceServer) GetBoard(context.Context, *GetBoardRequest) (*Board, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoard not implemented")
+}
+func (UnimplementedOrderServiceServer) Make(context.Context, *MakeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Make not implemented")
+}
+func (UnimplementedOrderServiceServer) Take(context.Context, *TakeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Take not implemented")
+}
+func (UnimplementedOrderServiceServer) Delete(context.Context, *DeleteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedOrderServiceServer) SubscribeBoard(*SubscribeBoardRequest, OrderService_SubscribeBoardServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBoard not implemented")
+}
+func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
+
+// UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderServiceServer will
+// result in compilation errors.
+type UnsafeOrderServiceServer interface {
+	mustEmbedUnimplementedOrderServiceServer()
+}
+
+func RegisterOrderServiceServer(s grpc.ServiceRegistrar, srv OrderServiceServer) {
+	s.RegisterService(&OrderService_ServiceDesc, srv)
+}
+
+func _OrderService_GetBoard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBoardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetBoard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetBoard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetBoard(ctx, req.(*GetBoardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Make_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Make(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_Make_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Make(ctx, req.(*MakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Take_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Take(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_Take_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Take(ctx, req.(*TakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SubscribeBoard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBoardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).SubscribeBoard(m, &orderServiceSubscribeBoardServer{ServerStream: stream})
+}
+
+type OrderService_SubscribeBoardServer interface {
+	Send(*Board) error
+	grpc.ServerStream
+}
+
+type orderServiceSubscribeBoardServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceSubscribeBoardServer) Send(m *Board) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kickstart.v1.OrderService",
+	HandlerType: (*OrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBoard",
+			Handler:    _OrderService_GetBoard_Handler,
+		},
+		{
+			MethodName: "Make",
+			Handler:    _OrderService_Make_Handler,
+		},
+		{
+			MethodName: "Take",
+			Handler:    _OrderService_Take_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _OrderService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBoard",
+			Handler:       _OrderService_SubscribeBoard_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/rpc/order.proto",
+}
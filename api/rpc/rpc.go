@@ -0,0 +1,58 @@
+// Package rpc defines the gRPC API of the order service, see order.proto
+package rpc
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+)
+
+// Server is a gRPC server serving OrderService
+type Server struct {
+	*grpc.Server
+	// ends the board subscriptions being served
+	stopStreams context.CancelFunc
+}
+
+// GracefulStop ends the board subscriptions, which never end by themselves, then stops the server
+// once the unary calls being served are done
+func (s *Server) GracefulStop() {
+	s.stopStreams()
+	s.Server.GracefulStop()
+}
+
+// NewServer returns a gRPC server serving OrderService with o. Calls are authorized with tokens
+// validated by a and commands are recorded to the audit trail by au, they are forwarded to the
+// matcher leader of l unless l is nil, i.e. every process matches orders.
+func NewServer(o service.Order, a service.Auth, au service.Audit, l service.Leader) *Server {
+	shutdown, stopStreams := context.WithCancel(context.Background())
+	server := grpc.NewServer(
+		// support open tracing
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// NOTE: the recovery interceptors should always be the first ones installed.
+		grpc.ChainUnaryInterceptor(
+			recoverUnary,
+			authUnary(a, au, models.Audience),
+			newForwarder(l, config.GetString("GRPC_LISTEN_PORT")).unary,
+			auditUnary(au),
+		),
+		grpc.ChainStreamInterceptor(
+			recoverStream,
+			authStream(a, au, models.Audience),
+		),
+	)
+	RegisterOrderServiceServer(server, &orderServer{
+		c:                 o,
+		subscribeInterval: config.GetMilliseconds("BOARD_SUBSCRIBE_INTERVAL_MS"),
+		shutdown:          shutdown,
+	})
+	return &Server{
+		Server:      server,
+		stopStreams: stopStreams,
+	}
+}
@@ -0,0 +1,167 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/A-pen-app/logging"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestNewBoard(t *testing.T) {
+	price := models.NewDecimal(10)
+	board := newBoard(&models.Board{
+		LatestPrice: models.NewDecimal(10),
+		MarketState: models.MarketAuction,
+		Auction:     &models.Auction{Price: &price, Volume: models.NewDecimal(5), Imbalance: models.NewDecimal(-1)},
+		BuyOrders: []*models.Order{
+			{ID: "b1", Action: models.Buy, Price: models.NewDecimal(9), Quantity: models.NewDecimal(1), Status: models.OrderOpen},
+			{ID: "b2", Action: models.Buy, Price: models.NewDecimal(8), Quantity: models.NewDecimal(2), Status: models.OrderOpen},
+		},
+		SellOrders: []*models.Order{
+			{ID: "s1", Action: models.Sell, Price: models.NewDecimal(11), Quantity: models.NewDecimal(3), Status: models.OrderOpen},
+		},
+	})
+	require.Equal(t, "10.00", board.GetLatestPrice())
+	require.Equal(t, "auction", board.GetMarketState())
+	require.Equal(t, "10.00", board.GetAuction().GetIndicativePrice())
+	require.Equal(t, "-1.00", board.GetAuction().GetImbalance())
+	require.Equal(t, []string{"b1", "b2"}, []string{board.GetBuyOrders()[0].GetId(), board.GetBuyOrders()[1].GetId()})
+	require.Equal(t, "3.00", board.GetSellOrders()[0].GetQuantity())
+
+	// a board which does not cross has no indicative price
+	board = newBoard(&models.Board{MarketState: models.MarketAuction, Auction: &models.Auction{}})
+	require.Equal(t, "", board.GetAuction().GetIndicativePrice())
+	require.Empty(t, board.GetBuyOrders())
+}
+
+func TestOrderService(t *testing.T) {
+	if err := logging.Initialize(&logging.Config{
+		Level:        logging.Level(config.GetUint("LOG_LEVEL")),
+		Development:  true,
+		KeyRequestID: "request_id",
+		KeyUserID:    "user_id",
+		KeyError:     "err",
+		KeyScope:     "scope",
+	}); err != nil {
+		panic(err)
+	}
+	t.Setenv("GRPC_LISTEN_PORT", "9000")
+	t.Setenv("BOARD_SUBSCRIBE_INTERVAL_MS", "10")
+
+	userID := "5f1e5bb6-54b4-4a5e-8f6e-3d0c2a1f0e11"
+	authSvc := new(service.MockAuth)
+	authSvc.On("ValidateToken", mock.Anything, "token").Return(&models.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: userID},
+		Scope:            models.Audience,
+	}, nil)
+	authSvc.On("ValidateToken", mock.Anything, mock.Anything).Return(nil, errors.New("invalid token"))
+	auditSvc := new(service.MockAudit)
+	auditSvc.On("Record", mock.Anything, userID, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	orderSvc := new(service.MockOrder)
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(orderSvc, authSvc, auditSvc, nil)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := NewOrderServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	authorized := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer token")
+
+	// calls without a valid token are rejected
+	_, err = client.GetBoard(ctx, &GetBoardRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.Make(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer other"), &MakeRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.Make(metadata.AppendToOutgoingContext(ctx, "authorization", "Basic token"), &MakeRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// the authenticated user makes orders, and the call is audited
+	orderSvc.On("Make", mock.Anything, userID, models.Buy, models.NewDecimal(10), models.NewDecimal(100)).Return(nil).Once()
+	_, err = client.Make(authorized, &MakeRequest{Action: "buy", Price: "10", Quantity: "100.00"})
+	require.NoError(t, err)
+	auditSvc.AssertCalled(t, "Record", mock.Anything, userID, models.AuditOrderMake, OrderService_Make_FullMethodName, mock.Anything)
+
+	// malformed requests never reach the order service
+	_, err = client.Make(authorized, &MakeRequest{Action: "hold", Price: "10", Quantity: "100"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Make(authorized, &MakeRequest{Action: "buy", Price: "-10", Quantity: "100"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Delete(authorized, &DeleteRequest{OrderId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	orderSvc.AssertNumberOfCalls(t, "Make", 1)
+
+	// errors of the order service are translated to status codes
+	orderSvc.On("Take", mock.Anything, userID, models.Sell, models.NewDecimal(1)).Return(models.ErrorMarketHalted).Once()
+	_, err = client.Take(authorized, &TakeRequest{Action: "sell", Quantity: "1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	// orders are deleted on behalf of the authenticated user, orders of others are not found
+	orderID := "0b8a4c7e-6f55-4a0e-9a57-6c1d2b3e4f50"
+	orderSvc.On("Delete", mock.Anything, userID, orderID).Return(models.ErrorNotFound).Once()
+	_, err = client.Delete(authorized, &DeleteRequest{OrderId: orderID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	ownOrderID := "9c3f1a2b-7d64-4e85-b0a1-2f3e4d5c6b7a"
+	orderSvc.On("Delete", mock.Anything, userID, ownOrderID).Return(nil).Once()
+	_, err = client.Delete(authorized, &DeleteRequest{OrderId: ownOrderID})
+	require.NoError(t, err)
+	auditSvc.AssertCalled(t, "Record", mock.Anything, userID, models.AuditOrderDelete, orderID, mock.Anything)
+
+	// subscriptions only receive boards that changed
+	first := &models.Board{LatestPrice: models.NewDecimal(10), MarketState: models.MarketOpen}
+	second := &models.Board{LatestPrice: models.NewDecimal(11), MarketState: models.MarketOpen}
+	orderSvc.On("GetBoard", mock.Anything, models.Live).Return(first, "", nil).Twice()
+	orderSvc.On("GetBoard", mock.Anything, models.Live).Return(second, "", nil)
+
+	board, err := client.GetBoard(authorized, &GetBoardRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "10.00", board.LatestPrice)
+
+	sub, err := client.SubscribeBoard(authorized, &SubscribeBoardRequest{BoardType: "live"})
+	require.NoError(t, err)
+	board, err = sub.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "10.00", board.LatestPrice)
+	board, err = sub.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "11.00", board.LatestPrice)
+	require.Equal(t, "open", board.MarketState)
+
+	// subscriptions end once the server shuts down, rather than holding it for the grace period
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		server.GracefulStop()
+	}()
+	_, err = sub.Recv()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expect graceful stop not to wait for subscriptions")
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/A-pen-app/kickstart/api/middleware"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/gin-gonic/gin"
+)
+
+type userHandler struct {
+	u service.User
+}
+
+func addUserRoutes(root *gin.RouterGroup, u service.User, auth service.Auth) {
+	h := &userHandler{
+		u: u,
+	}
+
+	g := root.Group("me")
+	g.Use(middleware.AuthUser(auth))
+	g.Use(middleware.NeedPermission(models.Audience))
+	g.GET("notification-preferences", h.getNotificationPreferences)
+	g.PUT("notification-preferences", h.updateNotificationPreferences)
+}
+
+//	@Summary		Get my notification preferences
+//	@Description	Get through which channels current user is notified about its orders
+//	@Tags			user
+//	@Produce		json
+//	@Success		200	{object}	models.NotificationPreferences
+//	@Failure		500	{object}	errorResp
+//	@Router			/me/notification-preferences [get]
+//	@Security		Bearer
+func (h *userHandler) getNotificationPreferences(ctx *gin.Context) {
+	preferences, err := h.u.GetNotificationPreferences(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, preferences)
+}
+
+type updateNotificationPreferencesBody struct {
+	Email *bool `json:"email" binding:"required" example:"true"`
+	SMS   *bool `json:"sms" binding:"required" example:"false"`
+	Push  *bool `json:"push" binding:"required" example:"true"`
+}
+
+//	@Summary		Update my notification preferences
+//	@Description	Enable or disable each channel current user is notified through
+//	@Tags			user
+//	@Param			jsonBody	body	updateNotificationPreferencesBody	true	"channels to enable"
+//	@Produce		json
+//	@Success		200	{object}	models.NotificationPreferences
+//	@Failure		400	{object}	errorResp
+//	@Failure		500	{object}	errorResp
+//	@Router			/me/notification-preferences [put]
+//	@Security		Bearer
+func (h *userHandler) updateNotificationPreferences(ctx *gin.Context) {
+	b := updateNotificationPreferencesBody{}
+	if err := ctx.BindJSON(&b); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	preferences, err := h.u.UpdateNotificationPreferences(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		*b.Email,
+		*b.SMS,
+		*b.Push,
+	)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, preferences)
+}
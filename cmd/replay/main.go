@@ -0,0 +1,277 @@
+// Command replay drives the order service with a JSONL journal of make, take and delete requests
+// against in-memory stores and a fake clock, and prints the final board, trades and positions as JSON.
+//
+//	$ go run ./cmd/replay -journal journal.jsonl
+//
+// Each line of the journal is a request like
+//
+//	{"op":"make","user_id":"...","action":"sell","price":"10.00","quantity":"5"}
+//	{"op":"take","user_id":"...","action":"buy","quantity":"2","at":"2026-01-02T09:00:00Z"}
+//	{"op":"oco","user_id":"...","action":"sell","quantity":"5","take_profit_price":"12","stop_price":"9","stop_limit_price":"8.5"}
+//	{"op":"trailing_stop","user_id":"...","action":"sell","quantity":"5","trail_amount":"1","limit_offset":"0.5"}
+//	{"op":"uncross","at":"2026-01-02T09:00:00Z"}
+//	{"op":"delete","order_id":"..."}
+//
+// The clock is set to "at" if present, otherwise it advances by -step from -start. Orders made
+// without "order_id" are assigned deterministic IDs, so replaying a journal always gives the same result.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/A-pen-app/cache"
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/A-pen-app/kickstart/store/memory"
+	"github.com/A-pen-app/logging"
+)
+
+type entry struct {
+	Op       string             `json:"op"` // make, oco, trailing_stop, take, uncross or delete
+	At       *time.Time         `json:"at"`
+	UserID   string             `json:"user_id"`
+	OrderID  string             `json:"order_id"` // ID of the order to make or to delete, deletes without UserID are made by an operator
+	Action   models.OrderAction `json:"action"`
+	Price    models.Decimal     `json:"price"`
+	Quantity models.Decimal     `json:"quantity"`
+	// makes an iceberg order
+	DisplayQuantity *models.Decimal `json:"display_quantity"`
+	PostOnly        bool            `json:"post_only"`
+	// one-cancels-other orders
+	TakeProfitPrice models.Decimal `json:"take_profit_price"`
+	StopPrice       models.Decimal `json:"stop_price"`
+	StopLimitPrice  models.Decimal `json:"stop_limit_price"`
+	// trailing stop orders
+	TrailAmount  *models.Decimal `json:"trail_amount"`
+	TrailPercent *models.Decimal `json:"trail_percent"`
+	LimitOffset  models.Decimal  `json:"limit_offset"`
+}
+
+type rejection struct {
+	Line  int    `json:"line"`
+	Op    string `json:"op"`
+	Error string `json:"error"`
+}
+
+type report struct {
+	*models.Board
+	Trades    []*models.Trade    `json:"trades"`
+	Positions []*models.Position `json:"positions"`
+	// requests rejected by the service, they are part of the result as much as the trades
+	Rejections []*rejection `json:"rejections"`
+}
+
+type options struct {
+	start      time.Time
+	step       time.Duration
+	instrument *models.Instrument
+}
+
+func main() {
+	journal := flag.String("journal", "-", "path of the JSONL journal, - for stdin")
+	start := flag.String("start", "2026-01-01T00:00:00Z", "initial time of the fake clock in RFC 3339")
+	step := flag.Duration("step", time.Second, "time the fake clock advances for entries without \"at\"")
+	instrument := flag.String("instrument", "", "path of a JSON instrument to trade, defaults to the seeded one")
+	flag.Parse()
+
+	if err := logging.Initialize(&logging.Config{
+		Level:        logging.Level(config.GetUint("LOG_LEVEL")),
+		Development:  true,
+		KeyRequestID: "request_id",
+		KeyUserID:    "user_id",
+		KeyError:     "err",
+		KeyScope:     "scope",
+	}); err != nil {
+		panic(err)
+	}
+	defer logging.Finalize()
+
+	cache.Initialize(&cache.Config{
+		Type:   cache.TypeLocal,
+		Prefix: "replay",
+	})
+	defer cache.Finalize()
+
+	if err := models.SetDecimalScale(config.GetInt("DECIMAL_SCALE")); err != nil {
+		fail(err)
+	}
+
+	opts := options{step: *step}
+	var err error
+	if opts.start, err = time.Parse(time.RFC3339, *start); err != nil {
+		fail(err)
+	}
+	if opts.instrument, err = loadInstrument(*instrument); err != nil {
+		fail(err)
+	}
+
+	r := io.Reader(os.Stdin)
+	if *journal != "-" {
+		f, err := os.Open(*journal)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	result, err := replay(context.Background(), r, opts)
+	if err != nil {
+		fail(err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "replay:", err)
+	os.Exit(1)
+}
+
+// loadInstrument reads the instrument at path, or returns the instrument seeded by the migrations
+func loadInstrument(path string) (*models.Instrument, error) {
+	if path == "" {
+		return &models.Instrument{
+			Symbol:           config.GetString("INSTRUMENT"),
+			TickSize:         models.NewDecimal(1),
+			LotSize:          models.NewDecimal(1),
+			MinNotional:      models.NewDecimal(1),
+			MaxNotional:      models.NewDecimal(1000000000),
+			PriceBandPercent: 50,
+		}, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	instrument := &models.Instrument{}
+	if err := json.Unmarshal(b, instrument); err != nil {
+		return nil, err
+	}
+	instrument.Symbol = config.GetString("INSTRUMENT")
+	return instrument, nil
+}
+
+// replay applies every entry of the journal in order and reports the final state
+func replay(ctx context.Context, journal io.Reader, opts options) (*report, error) {
+	now := opts.start
+	clock := func() time.Time { return now }
+
+	db := memory.NewDB(clock)
+	db.PutInstrument(opts.instrument)
+
+	seq := 0
+	nextID := ""
+	orderIDs := func() string {
+		if id := nextID; id != "" {
+			nextID = ""
+			return id
+		}
+		seq++
+		return fmt.Sprintf("00000000-0000-4000-9000-%012x", seq)
+	}
+	s := service.NewOrder(
+		memory.NewOrder(db),
+		memory.NewPosition(db),
+		memory.NewInstrument(db),
+		memory.NewUser(db),
+		service.NewTemplate(),
+		service.WithClock(clock),
+		service.WithOrderIDs(orderIDs),
+	)
+
+	result := &report{
+		Rejections: []*rejection{},
+	}
+	first := true
+	scanner := bufio.NewScanner(journal)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		e := entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if e.At != nil {
+			if e.At.Before(now) {
+				return nil, fmt.Errorf("line %d: time %s goes backwards", line, e.At.Format(time.RFC3339))
+			}
+			now = *e.At
+		} else if !first {
+			now = now.Add(opts.step)
+		}
+		first = false
+
+		var err error
+		switch e.Op {
+		case "make":
+			nextID = e.OrderID
+			options := []service.MakeOption{}
+			if e.DisplayQuantity != nil {
+				options = append(options, service.WithDisplayQuantity(*e.DisplayQuantity))
+			}
+			if e.PostOnly {
+				options = append(options, service.WithPostOnly())
+			}
+			err = s.Make(ctx, e.UserID, e.Action, e.Price, e.Quantity, options...)
+		case "oco":
+			_, err = s.MakeOCO(ctx, e.UserID, e.Action, e.Quantity, e.TakeProfitPrice, e.StopPrice, e.StopLimitPrice)
+		case "trailing_stop":
+			nextID = e.OrderID
+			_, err = s.MakeTrailingStop(ctx, e.UserID, e.Action, e.Quantity, e.TrailAmount, e.TrailPercent, e.LimitOffset)
+		case "take":
+			err = s.Take(ctx, e.UserID, e.Action, e.Quantity)
+		case "uncross":
+			_, err = s.Uncross(ctx)
+		case "delete":
+			if e.UserID == "" {
+				err = s.ForceDelete(ctx, e.OrderID, nil)
+			} else {
+				err = s.Delete(ctx, e.UserID, e.OrderID)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unknown op %q", line, e.Op)
+		}
+		if err != nil {
+			result.Rejections = append(result.Rejections, &rejection{
+				Line:  line,
+				Op:    e.Op,
+				Error: err.Error(),
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	board, _, err := s.GetBoard(ctx, models.Live)
+	if err != nil {
+		return nil, err
+	}
+	result.Board = board
+	result.Trades = db.Trades()
+	result.Positions = db.Positions()
+	for _, position := range result.Positions {
+		if err := position.Mark(board.LatestPrice); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(result.Positions, func(i, j int) bool {
+		return result.Positions[i].UserID < result.Positions[j].UserID
+	})
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/A-pen-app/cache"
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay(t *testing.T) {
+	if err := logging.Initialize(&logging.Config{
+		Level:        logging.Level(config.GetUint("LOG_LEVEL")),
+		Development:  true,
+		KeyRequestID: "request_id",
+		KeyUserID:    "user_id",
+		KeyError:     "err",
+		KeyScope:     "scope",
+	}); err != nil {
+		panic(err)
+	}
+	defer logging.Finalize()
+
+	cache.Initialize(&cache.Config{
+		Type:   cache.TypeLocal,
+		Prefix: "replay-test",
+	})
+	defer cache.Finalize()
+
+	journal := `
+{"op":"make","user_id":"maker-a","order_id":"sell-a","action":"sell","price":"11","quantity":"5"}
+{"op":"make","user_id":"maker-b","action":"sell","price":"12","quantity":"5"}
+{"op":"make","user_id":"maker-a","order_id":"buy-a","action":"buy","price":"9","quantity":"3"}
+{"op":"take","user_id":"taker","action":"buy","quantity":"7","at":"2026-01-01T09:30:00Z"}
+{"op":"delete","order_id":"buy-a"}
+{"op":"make","user_id":"maker-a","action":"buy","price":"12","quantity":"1"}`
+
+	instrument, err := loadInstrument("")
+	require.NoError(t, err)
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	result, err := replay(context.Background(), strings.NewReader(journal), options{
+		start:      start,
+		step:       time.Minute,
+		instrument: instrument,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, models.NewDecimal(12), result.LatestPrice)
+	require.Equal(t, 0, len(result.BuyOrders), "expect buy order to be deleted")
+	require.Equal(t, 1, len(result.SellOrders))
+	require.Equal(t, "00000000-0000-4000-9000-000000000001", result.SellOrders[0].ID, "expect generated order IDs to be deterministic")
+	require.Equal(t, models.NewDecimal(3), result.SellOrders[0].Quantity)
+	require.Equal(t, start.Add(time.Minute), result.SellOrders[0].CreatedAt, "expect clock to advance by step")
+
+	require.Equal(t, 2, len(result.Trades))
+	require.Equal(t, "sell-a", result.Trades[0].MakerOrderID)
+	require.Equal(t, models.NewDecimal(5), result.Trades[0].Quantity)
+	require.Equal(t, models.NewDecimal(12), result.Trades[1].Price)
+	require.Equal(t, time.Date(2026, 1, 1, 9, 30, 0, 0, time.UTC), result.Trades[1].CreatedAt)
+
+	require.Equal(t, 3, len(result.Positions))
+	require.Equal(t, "taker", result.Positions[2].UserID)
+	require.Equal(t, models.NewDecimal(7), result.Positions[2].Quantity)
+
+	require.Equal(t, 1, len(result.Rejections), "expect buy crossing the best sell price to be rejected")
+	require.Equal(t, 7, result.Rejections[0].Line)
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS public.position;
+
+DROP TABLE IF EXISTS public.trade;
+
+ALTER TABLE IF EXISTS public."order"
+    DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,28 @@
+ALTER TABLE IF EXISTS public."order"
+    ADD COLUMN IF NOT EXISTS user_id uuid;
+
+CREATE TABLE IF NOT EXISTS public.trade
+(
+    id uuid NOT NULL DEFAULT uuid_generate_v4(),
+    maker_order_id uuid NOT NULL,
+    maker_id uuid,
+    taker_id uuid NOT NULL,
+    action character varying(8) COLLATE pg_catalog."default" NOT NULL,
+    price integer NOT NULL,
+    quantity integer NOT NULL,
+    created_at timestamp without time zone NOT NULL DEFAULT now(),
+    CONSTRAINT trade_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS trade_maker_id_idx ON public.trade (maker_id, created_at);
+CREATE INDEX IF NOT EXISTS trade_taker_id_idx ON public.trade (taker_id, created_at);
+
+CREATE TABLE IF NOT EXISTS public.position
+(
+    user_id uuid NOT NULL,
+    quantity integer NOT NULL DEFAULT 0,
+    cost bigint NOT NULL DEFAULT 0,
+    realized_pnl bigint NOT NULL DEFAULT 0,
+    updated_at timestamp without time zone NOT NULL DEFAULT now(),
+    CONSTRAINT position_pkey PRIMARY KEY (user_id)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS public.instrument
+(
+    symbol character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    tick_size integer NOT NULL DEFAULT 1,
+    lot_size integer NOT NULL DEFAULT 1,
+    min_notional bigint NOT NULL DEFAULT 0,
+    max_notional bigint NOT NULL DEFAULT 0,
+    price_band_percent integer NOT NULL DEFAULT 0,
+    CONSTRAINT instrument_pkey PRIMARY KEY (symbol),
+    CONSTRAINT instrument_tick_size_check CHECK (tick_size > 0),
+    CONSTRAINT instrument_lot_size_check CHECK (lot_size > 0)
+);
+
+INSERT INTO public.instrument (symbol, tick_size, lot_size, min_notional, max_notional, price_band_percent)
+VALUES ('DEMO', 1, 1, 1, 1000000000, 50)
+ON CONFLICT (symbol) DO NOTHING;
@@ -0,0 +1,18 @@
+ALTER TABLE IF EXISTS public.instrument
+    ALTER COLUMN tick_size TYPE integer,
+    ALTER COLUMN lot_size TYPE integer,
+    ALTER COLUMN min_notional TYPE bigint,
+    ALTER COLUMN max_notional TYPE bigint;
+
+ALTER TABLE IF EXISTS public.position
+    ALTER COLUMN quantity TYPE integer,
+    ALTER COLUMN cost TYPE bigint,
+    ALTER COLUMN realized_pnl TYPE bigint;
+
+ALTER TABLE IF EXISTS public.trade
+    ALTER COLUMN price TYPE integer,
+    ALTER COLUMN quantity TYPE integer;
+
+ALTER TABLE IF EXISTS public."order"
+    ALTER COLUMN price TYPE integer,
+    ALTER COLUMN quantity TYPE integer;
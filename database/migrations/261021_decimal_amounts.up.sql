@@ -0,0 +1,18 @@
+ALTER TABLE IF EXISTS public."order"
+    ALTER COLUMN price TYPE numeric,
+    ALTER COLUMN quantity TYPE numeric;
+
+ALTER TABLE IF EXISTS public.trade
+    ALTER COLUMN price TYPE numeric,
+    ALTER COLUMN quantity TYPE numeric;
+
+ALTER TABLE IF EXISTS public.position
+    ALTER COLUMN quantity TYPE numeric,
+    ALTER COLUMN cost TYPE numeric,
+    ALTER COLUMN realized_pnl TYPE numeric;
+
+ALTER TABLE IF EXISTS public.instrument
+    ALTER COLUMN tick_size TYPE numeric,
+    ALTER COLUMN lot_size TYPE numeric,
+    ALTER COLUMN min_notional TYPE numeric,
+    ALTER COLUMN max_notional TYPE numeric;
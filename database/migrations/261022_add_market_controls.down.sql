@@ -0,0 +1,9 @@
+ALTER TABLE IF EXISTS public.instrument
+    DROP COLUMN IF EXISTS session_open,
+    DROP COLUMN IF EXISTS session_close,
+    DROP COLUMN IF EXISTS volatility_percent,
+    DROP COLUMN IF EXISTS volatility_window_minutes,
+    DROP COLUMN IF EXISTS halt_minutes,
+    DROP COLUMN IF EXISTS halted,
+    DROP COLUMN IF EXISTS halt_reason,
+    DROP COLUMN IF EXISTS halt_until;
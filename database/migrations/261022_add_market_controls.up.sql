@@ -0,0 +1,9 @@
+ALTER TABLE IF EXISTS public.instrument
+    ADD COLUMN IF NOT EXISTS session_open time without time zone,
+    ADD COLUMN IF NOT EXISTS session_close time without time zone,
+    ADD COLUMN IF NOT EXISTS volatility_percent integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS volatility_window_minutes integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS halt_minutes integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS halted boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS halt_reason character varying(200) COLLATE pg_catalog."default",
+    ADD COLUMN IF NOT EXISTS halt_until timestamp with time zone;
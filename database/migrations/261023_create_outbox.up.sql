@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS public.outbox
+(
+    id bigserial NOT NULL,
+    aggregate_id character varying(64) COLLATE pg_catalog."default" NOT NULL,
+    topic character varying(64) COLLATE pg_catalog."default" NOT NULL,
+    payload jsonb NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    last_error text COLLATE pg_catalog."default",
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    available_at timestamp with time zone NOT NULL DEFAULT now(),
+    published_at timestamp with time zone,
+    CONSTRAINT outbox_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON public.outbox (aggregate_id, id) WHERE published_at IS NULL;
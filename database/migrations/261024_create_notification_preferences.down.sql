@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.notification_preference;
+
+ALTER TABLE IF EXISTS public."user"
+    DROP COLUMN IF EXISTS phone;
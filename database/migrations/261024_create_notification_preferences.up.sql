@@ -0,0 +1,12 @@
+ALTER TABLE IF EXISTS public."user"
+    ADD COLUMN IF NOT EXISTS phone character varying(20) COLLATE pg_catalog."default";
+
+CREATE TABLE IF NOT EXISTS public.notification_preference
+(
+    user_id uuid NOT NULL,
+    email boolean NOT NULL DEFAULT true,
+    sms boolean NOT NULL DEFAULT true,
+    push boolean NOT NULL DEFAULT true,
+    updated_at timestamp without time zone NOT NULL DEFAULT now(),
+    CONSTRAINT notification_preference_pkey PRIMARY KEY (user_id)
+);
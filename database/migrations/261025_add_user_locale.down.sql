@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS public."user"
+    DROP COLUMN IF EXISTS locale;
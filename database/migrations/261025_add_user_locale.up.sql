@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS public."user"
+    ADD COLUMN IF NOT EXISTS locale character varying(16) COLLATE pg_catalog."default";
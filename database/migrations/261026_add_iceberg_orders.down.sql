@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS public."order"
+    DROP COLUMN IF EXISTS display_quantity,
+    DROP COLUMN IF EXISTS hidden_quantity;
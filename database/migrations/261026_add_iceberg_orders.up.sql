@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS public."order"
+    ADD COLUMN IF NOT EXISTS display_quantity numeric,
+    ADD COLUMN IF NOT EXISTS hidden_quantity numeric NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS public.order_pending_stop_price_idx;
+
+DROP INDEX IF EXISTS public.order_oco_group_id_idx;
+
+ALTER TABLE IF EXISTS public."order"
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS stop_price,
+    DROP COLUMN IF EXISTS oco_group_id;
@@ -0,0 +1,14 @@
+ALTER TABLE IF EXISTS public."order"
+    ADD COLUMN IF NOT EXISTS status character varying(16) COLLATE pg_catalog."default" NOT NULL DEFAULT 'open',
+    ADD COLUMN IF NOT EXISTS stop_price numeric,
+    ADD COLUMN IF NOT EXISTS oco_group_id uuid;
+
+CREATE INDEX IF NOT EXISTS order_oco_group_id_idx
+    ON public."order" USING btree
+    (oco_group_id ASC NULLS LAST)
+    WHERE oco_group_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS order_pending_stop_price_idx
+    ON public."order" USING btree
+    (action, stop_price)
+    WHERE status = 'pending';
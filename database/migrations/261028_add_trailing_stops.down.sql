@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS public."order"
+    DROP COLUMN IF EXISTS trail_amount,
+    DROP COLUMN IF EXISTS trail_percent,
+    DROP COLUMN IF EXISTS limit_offset;
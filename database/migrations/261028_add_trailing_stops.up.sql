@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS public."order"
+    ADD COLUMN IF NOT EXISTS trail_amount numeric,
+    ADD COLUMN IF NOT EXISTS trail_percent numeric,
+    ADD COLUMN IF NOT EXISTS limit_offset numeric NOT NULL DEFAULT 0;
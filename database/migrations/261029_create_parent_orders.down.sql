@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.parent_order_due_idx;
+
+DROP TABLE IF EXISTS public.parent_order;
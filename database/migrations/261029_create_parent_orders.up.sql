@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS public.parent_order
+(
+    id uuid NOT NULL DEFAULT uuid_generate_v4(),
+    user_id uuid NOT NULL,
+    action character varying(8) COLLATE pg_catalog."default" NOT NULL,
+    quantity numeric NOT NULL,
+    limit_price numeric,
+    slices integer NOT NULL,
+    start_at timestamp with time zone NOT NULL,
+    end_at timestamp with time zone NOT NULL,
+    status character varying(16) COLLATE pg_catalog."default" NOT NULL DEFAULT 'active',
+    slices_sent integer NOT NULL DEFAULT 0,
+    sent_quantity numeric NOT NULL DEFAULT 0,
+    next_slice_at timestamp with time zone,
+    last_error text COLLATE pg_catalog."default",
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT parent_order_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS parent_order_due_idx
+    ON public.parent_order USING btree
+    (next_slice_at ASC)
+    WHERE status = 'active';
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS public.instrument
+    ADD COLUMN IF NOT EXISTS auction_minutes integer NOT NULL DEFAULT 0;
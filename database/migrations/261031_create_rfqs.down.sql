@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS public.trade
+    DROP COLUMN IF EXISTS off_book;
+
+DROP INDEX IF EXISTS public.quote_rfq_id_idx;
+
+DROP TABLE IF EXISTS public.quote;
+
+DROP TABLE IF EXISTS public.rfq;
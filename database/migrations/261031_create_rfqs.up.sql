@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS public.rfq
+(
+    id uuid NOT NULL DEFAULT uuid_generate_v4(),
+    requester_id uuid NOT NULL,
+    action character varying(8) COLLATE pg_catalog."default" NOT NULL,
+    quantity numeric NOT NULL,
+    status character varying(16) COLLATE pg_catalog."default" NOT NULL DEFAULT 'open',
+    expires_at timestamp with time zone NOT NULL,
+    trade_id uuid,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT rfq_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS public.quote
+(
+    id uuid NOT NULL DEFAULT uuid_generate_v4(),
+    rfq_id uuid NOT NULL,
+    market_maker_id uuid NOT NULL,
+    price numeric NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT quote_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS quote_rfq_id_idx ON public.quote (rfq_id, created_at);
+
+ALTER TABLE IF EXISTS public.trade
+    ADD COLUMN IF NOT EXISTS off_book boolean NOT NULL DEFAULT false;
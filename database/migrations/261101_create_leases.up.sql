@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS public.lease
+(
+    name character varying(64) COLLATE pg_catalog."default" NOT NULL,
+    holder character varying(128) COLLATE pg_catalog."default" NOT NULL,
+    address text COLLATE pg_catalog."default" NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    CONSTRAINT lease_pkey PRIMARY KEY (name)
+);
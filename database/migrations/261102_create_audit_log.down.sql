@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.audit_log_actor_id_idx;
+
+DROP TABLE IF EXISTS public.audit_log;
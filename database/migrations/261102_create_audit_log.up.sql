@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.audit_log
+(
+    id bigserial NOT NULL,
+    actor_id uuid NOT NULL,
+    action character varying(64) COLLATE pg_catalog."default" NOT NULL,
+    target text COLLATE pg_catalog."default" NOT NULL,
+    detail jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT audit_log_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_idx
+    ON public.audit_log USING btree
+    (actor_id ASC, created_at DESC);
@@ -0,0 +1,17 @@
+DROP TRIGGER IF EXISTS audit_log_append_only ON public.audit_log;
+
+DROP FUNCTION IF EXISTS public.audit_log_append_only();
+
+DROP INDEX IF EXISTS public.audit_log_target_idx;
+
+DROP INDEX IF EXISTS public.audit_log_action_idx;
+
+DELETE FROM public.audit_log WHERE actor_id IS NULL;
+
+ALTER TABLE public.audit_log
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS payload_hash,
+    DROP COLUMN IF EXISTS ip,
+    DROP COLUMN IF EXISTS request_id,
+    ALTER COLUMN actor_id SET NOT NULL;
@@ -0,0 +1,29 @@
+ALTER TABLE public.audit_log
+    ALTER COLUMN actor_id DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS request_id text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS ip text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS payload_hash text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS prev_hash text COLLATE pg_catalog."default" NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS hash text COLLATE pg_catalog."default" NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS audit_log_action_idx
+    ON public.audit_log USING btree
+    (action ASC, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS audit_log_target_idx
+    ON public.audit_log USING btree
+    (target ASC, created_at DESC);
+
+-- the audit trail is append-only, changes are rejected even if the hash chain would catch them
+CREATE OR REPLACE FUNCTION public.audit_log_append_only()
+    RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON public.audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION public.audit_log_append_only();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.trade_bucket_minute_idx;
+
+DROP TABLE IF EXISTS public.trade_bucket;
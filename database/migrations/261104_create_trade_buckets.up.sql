@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS public.trade_bucket
+(
+    symbol character varying(16) COLLATE pg_catalog."default" NOT NULL,
+    minute timestamp with time zone NOT NULL,
+    open numeric NOT NULL,
+    high numeric NOT NULL,
+    low numeric NOT NULL,
+    close numeric NOT NULL,
+    volume numeric NOT NULL,
+    notional numeric NOT NULL,
+    CONSTRAINT trade_bucket_pkey PRIMARY KEY (symbol, minute)
+);
+
+CREATE INDEX IF NOT EXISTS trade_bucket_minute_idx
+    ON public.trade_bucket USING btree
+    (minute DESC);
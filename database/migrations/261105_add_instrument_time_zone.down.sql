@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS public.instrument
+    DROP CONSTRAINT IF EXISTS instrument_time_zone_check;
+
+ALTER TABLE IF EXISTS public.instrument
+    DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,8 @@
+-- sessions used to be compared in the time zone of the process, operators set the time zone of
+-- each instrument through the admin API
+ALTER TABLE IF EXISTS public.instrument
+    ADD COLUMN IF NOT EXISTS time_zone character varying(64) COLLATE pg_catalog."default" NOT NULL DEFAULT 'UTC';
+
+-- AT TIME ZONE raises on unknown time zone names
+ALTER TABLE IF EXISTS public.instrument
+    ADD CONSTRAINT instrument_time_zone_check CHECK ((now() AT TIME ZONE time_zone) IS NOT NULL);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS public.trade_board_created_at_idx;
+
+DROP INDEX IF EXISTS public.trade_board_seq_idx;
+
+ALTER TABLE IF EXISTS public.trade
+    DROP COLUMN IF EXISTS seq;
@@ -0,0 +1,13 @@
+-- trades executed within the same transaction share created_at, seq keeps the order they are executed in
+ALTER TABLE IF EXISTS public.trade
+    ADD COLUMN IF NOT EXISTS seq bigserial;
+
+CREATE INDEX IF NOT EXISTS trade_board_seq_idx
+    ON public.trade USING btree
+    (seq DESC)
+    WHERE NOT off_book;
+
+CREATE INDEX IF NOT EXISTS trade_board_created_at_idx
+    ON public.trade USING btree
+    (created_at DESC)
+    WHERE NOT off_book;
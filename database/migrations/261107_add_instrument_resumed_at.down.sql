@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS public.instrument
+    DROP COLUMN IF EXISTS resumed_at;
@@ -0,0 +1,3 @@
+-- the volatility window of the circuit breaker starts over when trading is resumed
+ALTER TABLE IF EXISTS public.instrument
+    ADD COLUMN IF NOT EXISTS resumed_at timestamp with time zone;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS public.order_open_price_idx;
+
+DELETE FROM public."order"
+    WHERE closed_at IS NOT NULL;
+
+ALTER TABLE IF EXISTS public."order"
+    DROP COLUMN IF EXISTS closed_at;
@@ -0,0 +1,8 @@
+-- filled and cancelled orders are kept off the board instead of being deleted
+ALTER TABLE IF EXISTS public."order"
+    ADD COLUMN IF NOT EXISTS closed_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS order_open_price_idx
+    ON public."order" USING btree
+    (action, price, created_at)
+    WHERE status = 'open';
@@ -15,31 +15,108 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/board": {
+        "/admin/audit": {
             "get": {
-                "description": "Get a order board",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List entries of the audit trail, newest first, optionally filtered by actor, action, target and time, the access is recorded as well",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "admin"
                 ],
-                "summary": "Get a order board",
+                "summary": "List the audit trail",
                 "parameters": [
                     {
                         "enum": [
-                            "live",
-                            "history",
-                            "removed"
+                            "admin.list_orders",
+                            "admin.cancel_order",
+                            "admin.cancel_user_orders",
+                            "admin.view_user_activity",
+                            "admin.halt_instrument",
+                            "admin.resume_instrument",
+                            "admin.set_instrument_time_zone",
+                            "admin.list_audit",
+                            "admin.verify_audit",
+                            "auth.issue_token",
+                            "auth.permission_denied",
+                            "order.make",
+                            "order.make_oco",
+                            "order.make_trailing_stop",
+                            "order.take",
+                            "order.delete",
+                            "order.batch",
+                            "parent_order.make_twap",
+                            "parent_order.pause",
+                            "parent_order.resume",
+                            "parent_order.cancel",
+                            "rfq.accept"
                         ],
                         "type": "string",
                         "x-enum-varnames": [
-                            "Live",
-                            "History",
-                            "Removed"
+                            "AuditAdminListOrders",
+                            "AuditAdminCancelOrder",
+                            "AuditAdminCancelUser",
+                            "AuditAdminViewActivity",
+                            "AuditAdminHalt",
+                            "AuditAdminResume",
+                            "AuditAdminTimeZone",
+                            "AuditAdminListAudit",
+                            "AuditAdminVerifyAudit",
+                            "AuditTokenIssued",
+                            "AuditPermissionDenied",
+                            "AuditOrderMake",
+                            "AuditOrderMakeOCO",
+                            "AuditOrderTrailingStop",
+                            "AuditOrderTake",
+                            "AuditOrderDelete",
+                            "AuditOrderBatch",
+                            "AuditParentMakeTWAP",
+                            "AuditParentPause",
+                            "AuditParentResume",
+                            "AuditParentCancel",
+                            "AuditRFQAccept"
                         ],
-                        "description": "FIXME: add pagination with var below\npageReq\nif true, return orders created by the user",
-                        "name": "board_type",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "number of elements requested",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, entries created at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next cursor value, use it when requesting next page",
+                        "name": "next",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, entries created before it",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -57,7 +134,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Order"
+                                                "$ref": "#/definitions/models.AuditEntry"
                                             }
                                         }
                                     }
@@ -80,28 +157,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
-            "delete": {
+        "/admin/audit/verify": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Delete a order",
+                "description": "Check the hash chain of the whole audit trail, the first entry whose hash does not match is reported if it is broken",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "admin"
                 ],
-                "summary": "Delete a order",
+                "summary": "Verify the audit trail",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuditVerification"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/instruments/{symbol}/halt": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Halt trading of an instrument until it is resumed, orders cannot be made or taken while halted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "market"
+                ],
+                "summary": "Halt trading of an instrument",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID of order",
-                        "name": "order_id",
+                        "description": "symbol of instrument",
+                        "name": "symbol",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "reason of the halt",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.haltBody"
+                        }
                     }
                 ],
                 "responses": {
@@ -114,6 +231,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.errorResp"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -123,29 +246,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/make": {
+        "/admin/instruments/{symbol}/resume": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Make a order",
+                "description": "Resume trading of an instrument halted by an operator or a circuit breaker",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "market"
                 ],
-                "summary": "Make a order",
+                "summary": "Resume trading of an instrument",
                 "parameters": [
                     {
-                        "description": "order id to attend and user's email",
+                        "type": "string",
+                        "description": "symbol of instrument",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/instruments/{symbol}/time-zone": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Set the IANA time zone the trading session of an instrument is in, sessions are in UTC until it is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "market"
+                ],
+                "summary": "Set the time zone of an instrument",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "symbol of instrument",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "time zone of the session",
                         "name": "jsonBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.makeOrderBody"
+                            "$ref": "#/definitions/api.timeZoneBody"
                         }
                     }
                 ],
@@ -159,6 +332,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.errorResp"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -168,35 +347,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/take": {
-            "patch": {
+        "/admin/notifications/preview": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Take a order",
+                "description": "Render the notification of an event for every channel in given locale, falling back to the default locale if it has no templates.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "notification"
                 ],
-                "summary": "Take a order",
+                "summary": "Preview a notification",
                 "parameters": [
                     {
-                        "description": "order id to attend and user's email",
+                        "description": "event, locale and template variables",
                         "name": "jsonBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.takeOrderBody"
+                            "$ref": "#/definitions/api.previewNotificationBody"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Notification"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -213,34 +395,101 @@ const docTemplate = `{
                 }
             }
         },
-        "/token": {
+        "/admin/orders": {
             "get": {
-                "description": "temporary token generator for testing which return a JWT once verified.",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List orders of all users, including filled and cancelled ones, newest first, optionally filtered by user, action and status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "admin"
                 ],
-                "summary": "temporary token generator for testing",
+                "summary": "List orders of all users",
                 "parameters": [
+                    {
+                        "enum": [
+                            "buy",
+                            "sell"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "Buy",
+                            "Sell"
+                        ],
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "number of elements requested",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next cursor value, use it when requesting next page",
+                        "name": "next",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "pending",
+                            "filled",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "x-enum-comments": {
+                            "OrderCancelled": "off the board, cancelled by its owner, an operator or its one-cancels-other group",
+                            "OrderFullyFilled": "off the board, nothing is left to fill",
+                            "OrderOpen": "on the board",
+                            "OrderPending": "stop orders waiting for the latest price to reach their stop price"
+                        },
+                        "x-enum-varnames": [
+                            "OrderOpen",
+                            "OrderPending",
+                            "OrderFullyFilled",
+                            "OrderCancelled"
+                        ],
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "ID of user to get token of",
                         "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.GetTokenResp"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.pageResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/api.adminOrder"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "cannot generate token",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/api.errorResp"
                         }
@@ -253,35 +502,2583 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.GetTokenResp": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
+        },
+        "/admin/orders/{order_id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Force-cancel an order along with its one-cancels-other group, the action is recorded in the audit trail",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Cancel an order of any user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of order",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reason of cancelling",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.cancelBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
                 }
             }
         },
-        "api.errorResp": {
+        "/admin/users/{user_id}/activity": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get the position, recent orders and recent trades of a user, the access is recorded in the audit trail",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get activity of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of user",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserActivity"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{user_id}/orders/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Force-cancel every open and pending order of a user, the action is recorded in the audit trail",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Cancel all orders of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of user",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "reason of cancelling",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.cancelBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.cancelUserOrdersResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/board": {
+            "get": {
+                "description": "Get a order board",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get a order board",
+                "parameters": [
+                    {
+                        "enum": [
+                            "live",
+                            "history",
+                            "removed"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "Live",
+                            "History",
+                            "Removed"
+                        ],
+                        "description": "FIXME: add pagination with var below\npageReq\nif true, return orders created by the user",
+                        "name": "board_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.pageResp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Order"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/instruments/{symbol}": {
+            "get": {
+                "description": "Get trading rules, trading session and current market state of an instrument",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "market"
+                ],
+                "summary": "Get an instrument",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "symbol of instrument",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.getInstrumentResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notification-preferences": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get through which channels current user is notified about its orders",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get my notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationPreferences"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Enable or disable each channel current user is notified through",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update my notification preferences",
+                "parameters": [
+                    {
+                        "description": "channels to enable",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.updateNotificationPreferencesBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a order of the user along with the other orders of its one-cancels-other group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Delete a order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of order",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "the user has no such order",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/batch": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Make and cancel orders in the order of operations, the result of each operation is returned whether it succeeds or not.\nOperations are executed one by one unless atomic is set, in which case either all of them are executed in a single transaction or none is.\nOnly orders of current user can be cancelled, the number of operations is limited by ORDER_BATCH_MAX_SIZE.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Make and cancel orders in a batch",
+                "parameters": [
+                    {
+                        "description": "operations to execute",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.batchBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.batchResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/make": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Make a order, orders crossing the other side of the board are rejected unless they are post-only and repricing is enabled.\nIceberg orders only show their display quantity on the board, and take new time priority each time they are replenished.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Make a order",
+                "parameters": [
+                    {
+                        "description": "order id to attend and user's email",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.makeOrderBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "market halted or closed",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/mine/export": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Stream all orders and then all fills of current user as CSV or JSON Lines, each most recent first.\nFilled and cancelled orders are exported along with open and pending ones, with the time they were closed.\nThe response is chunked, the connection is closed before the end of the response if the export fails midway.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Export my order history",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl"
+                        ],
+                        "type": "string",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, orders and fills created at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, orders and fills created before it",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.HistoryRecord"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/oco": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Make a take-profit limit order and a stop-loss stop-limit order linked together, filling either of them, even partially, reduces the other by the filled quantity.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Make one-cancels-other orders",
+                "parameters": [
+                    {
+                        "description": "prices and quantity of the linked orders",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.makeOCOBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Order"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "market halted or closed",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/take": {
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Take a order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Take a order",
+                "parameters": [
+                    {
+                        "description": "order id to attend and user's email",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.takeOrderBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "market halted, closed or in call auction",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/trailing-stop": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Make a stop-limit order whose stop price follows the latest price, it only moves up for sell orders and down for buy orders, and is put on the board once the latest price reverses to it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Make a trailing stop order",
+                "parameters": [
+                    {
+                        "description": "trail and quantity of the order",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.makeTrailingStopBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "market halted or closed",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{order_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get an open or pending order of current user, along with the other orders of its one-cancels-other group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get my order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of order",
+                        "name": "order_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.getOrderResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/parent-orders/twap": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Split a large quantity into child orders of even quantity sent evenly from start_at to end_at, the schedule survives restarts. A child order failing to be sent is skipped and its quantity is spread over the remaining ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parent order"
+                ],
+                "summary": "Make a TWAP parent order",
+                "parameters": [
+                    {
+                        "description": "quantity and schedule of the parent order",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.makeTWAPBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ParentOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/parent-orders/{parent_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get a parent order of current user along with its progress",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parent order"
+                ],
+                "summary": "Get my parent order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of parent order",
+                        "name": "parent_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ParentOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/parent-orders/{parent_id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Stop sending child orders of an active or paused parent order, the child orders already sent are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parent order"
+                ],
+                "summary": "Cancel my parent order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of parent order",
+                        "name": "parent_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ParentOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "parent order is completed or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/parent-orders/{parent_id}/pause": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Stop sending child orders of an active parent order until it is resumed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parent order"
+                ],
+                "summary": "Pause my parent order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of parent order",
+                        "name": "parent_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ParentOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "parent order is not active",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/parent-orders/{parent_id}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Resume sending child orders of a paused parent order, slices due while paused are sent one after another",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "parent order"
+                ],
+                "summary": "Resume my parent order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of parent order",
+                        "name": "parent_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ParentOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "parent order is not paused",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/positions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get net quantity, average entry price, realized PnL and unrealized PnL marked at the latest price of current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get my position",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Position"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/rfq": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Broadcast a request for quote of a block trade to the market makers, it collects quotes for a limited time and never hits the board",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rfq"
+                ],
+                "summary": "Request for quote",
+                "parameters": [
+                    {
+                        "description": "side and quantity of the block trade",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.requestQuoteBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.RFQ"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "market halted or closed",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/rfq/{rfq_id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Get an RFQ along with all its quotes for the requester, or with their own quotes for market makers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rfq"
+                ],
+                "summary": "Get an RFQ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of RFQ",
+                        "name": "rfq_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RFQ"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/rfq/{rfq_id}/quotes": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Offer a price for the quantity of an open RFQ as a market maker, the quote is valid for a limited time within the RFQ",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rfq"
+                ],
+                "summary": "Quote an RFQ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of RFQ",
+                        "name": "rfq_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "price to trade at",
+                        "name": "jsonBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.quoteBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Quote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "RFQ is no longer open, or is requested by yourself",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/rfq/{rfq_id}/quotes/{quote_id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Accept a quote of my RFQ before either expires, it is executed as an off-book trade at the quoted price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rfq"
+                ],
+                "summary": "Accept a quote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of RFQ",
+                        "name": "rfq_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of quote",
+                        "name": "quote_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Trade"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "403": {
+                        "description": "RFQ or quote is no longer open",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/ticker": {
+            "get": {
+                "description": "Get open, high, low, last, volume, VWAP and change percentage of trades on the board over the last 24 hours per instrument.\nStatistics are kept per minute, so the window starts at the beginning of the minute 24 hours ago. Off-book trades are not counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "market"
+                ],
+                "summary": "Get 24h tickers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Ticker"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/token": {
+            "get": {
+                "description": "temporary token generator for testing which return a JWT once verified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "temporary token generator for testing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of user to get token of",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetTokenResp"
+                        }
+                    },
+                    "400": {
+                        "description": "cannot generate token",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.errorResp"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.GetTokenResp": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.adminOrder": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "closed_at": {
+                    "description": "when the order was filled or cancelled, nil while it is open or pending",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "limit_offset": {
+                    "type": "string",
+                    "example": "0.50"
+                },
+                "oco_group_id": {
+                    "description": "orders of the same one-cancels-other group reduce each other when any of them is filled",
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "price": {
+                    "description": "using fixed-point Decimal instead of float64 to avoid floating point precision issue",
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "quantity": {
+                    "description": "the visible quantity of iceberg orders",
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderStatus"
+                        }
+                    ],
+                    "example": "open"
+                },
+                "stop_price": {
+                    "description": "stop orders are pending until the latest price reaches StopPrice, when they are put on the board at Price",
+                    "type": "string",
+                    "example": "9.00"
+                },
+                "trail_amount": {
+                    "description": "the stop price of trailing stop orders follows the latest price by TrailAmount or TrailPercent,\nand their limit price stays LimitOffset away from the stop price",
+                    "type": "string",
+                    "example": "1.00"
+                },
+                "trail_percent": {
+                    "type": "string",
+                    "example": "5.00"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "uuid"
+                }
+            }
+        },
+        "api.batchBody": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "atomic": {
+                    "description": "executes all operations in a single transaction, none of them is executed if any fails",
+                    "type": "boolean",
+                    "example": false
+                },
+                "operations": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/api.batchOperationBody"
+                    }
+                }
+            }
+        },
+        "api.batchOperationBody": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "action": {
+                    "description": "fields of the order to make, the same as making a single order",
+                    "enum": [
+                        "buy",
+                        "sell"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "display_quantity": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "order_id": {
+                    "description": "ID of the order to cancel, along with its one-cancels-other group",
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "post_only": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "price": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "type": {
+                    "enum": [
+                        "make",
+                        "cancel"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.BatchOperationType"
+                        }
+                    ],
+                    "example": "make"
+                }
+            }
+        },
+        "api.batchResp": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "description": "in the order of operations",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchResult"
+                    }
+                }
+            }
+        },
+        "api.cancelBody": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "why the operator cancels, kept in the audit trail",
+                    "type": "string",
+                    "example": "erroneous order reported by the user"
+                }
+            }
+        },
+        "api.cancelUserOrdersResp": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "api.errorResp": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "field": {
+                    "description": "the offending request field, if the error is caused by a specific one",
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.getInstrumentResp": {
+            "type": "object",
+            "properties": {
+                "auction_minutes": {
+                    "description": "the last AuctionMinutes before SessionOpen and before SessionClose are the call phases\nof the opening and closing auctions, 0 to trade continuously through the session",
+                    "type": "integer",
+                    "example": 5
+                },
+                "halt_minutes": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "halt_reason": {
+                    "type": "string",
+                    "example": "maintenance"
+                },
+                "halt_until": {
+                    "description": "nil if halted until resumed",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "halted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "lot_size": {
+                    "description": "quantity of an order must be a multiple of lot size",
+                    "type": "string",
+                    "example": "1.00"
+                },
+                "market_state": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MarketState"
+                        }
+                    ],
+                    "example": "open"
+                },
+                "max_notional": {
+                    "type": "string",
+                    "example": "1000000.00"
+                },
+                "min_notional": {
+                    "description": "price * quantity of an order must be within [MinNotional, MaxNotional], 0 for no limit",
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "price_band_percent": {
+                    "description": "price of an order must be within this percentage around the latest price, 0 for no limit",
+                    "type": "integer",
+                    "example": 50
+                },
+                "resumed_at": {
+                    "description": "when trading was last resumed by an operator, nil if never",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "session_close": {
+                    "type": "string",
+                    "example": "13:30:00"
+                },
+                "session_open": {
+                    "description": "daily trading session in TimeZone, trading is allowed all day if either is nil",
+                    "type": "string",
+                    "example": "09:00:00"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "DEMO"
+                },
+                "tick_size": {
+                    "description": "price of an order must be a multiple of tick size",
+                    "type": "string",
+                    "example": "1.00"
+                },
+                "time_zone": {
+                    "description": "IANA name of the time zone of the session, UTC if empty",
+                    "type": "string",
+                    "example": "Asia/Taipei"
+                },
+                "volatility_percent": {
+                    "description": "trading is halted for HaltMinutes once the latest price moves more than\nVolatilityPercent within VolatilityWindowMinutes, 0 to disable the circuit breaker",
+                    "type": "integer",
+                    "example": 10
+                },
+                "volatility_window_minutes": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "api.getOrderResp": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "closed_at": {
+                    "description": "when the order was filled or cancelled, nil while it is open or pending",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "limit_offset": {
+                    "type": "string",
+                    "example": "0.50"
+                },
+                "linked": {
+                    "description": "the other orders of its one-cancels-other group",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Order"
+                    }
+                },
+                "oco_group_id": {
+                    "description": "orders of the same one-cancels-other group reduce each other when any of them is filled",
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "price": {
+                    "description": "using fixed-point Decimal instead of float64 to avoid floating point precision issue",
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "quantity": {
+                    "description": "the visible quantity of iceberg orders",
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderStatus"
+                        }
+                    ],
+                    "example": "open"
+                },
+                "stop_price": {
+                    "description": "stop orders are pending until the latest price reaches StopPrice, when they are put on the board at Price",
+                    "type": "string",
+                    "example": "9.00"
+                },
+                "trail_amount": {
+                    "description": "the stop price of trailing stop orders follows the latest price by TrailAmount or TrailPercent,\nand their limit price stays LimitOffset away from the stop price",
+                    "type": "string",
+                    "example": "1.00"
+                },
+                "trail_percent": {
+                    "type": "string",
+                    "example": "5.00"
+                }
+            }
+        },
+        "api.haltBody": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "system maintenance"
+                }
+            }
+        },
+        "api.makeOCOBody": {
+            "type": "object",
+            "required": [
+                "action",
+                "quantity",
+                "stop_limit_price",
+                "stop_price",
+                "take_profit_price"
+            ],
+            "properties": {
+                "action": {
+                    "enum": [
+                        "buy",
+                        "sell"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "sell"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "stop_limit_price": {
+                    "type": "string",
+                    "example": "8.50"
+                },
+                "stop_price": {
+                    "description": "the stop-loss order is put on the board once the latest price reaches stop price",
+                    "type": "string",
+                    "example": "9.00"
+                },
+                "take_profit_price": {
+                    "description": "price of the take-profit limit order",
+                    "type": "string",
+                    "example": "12.00"
+                }
+            }
+        },
+        "api.makeOrderBody": {
+            "type": "object",
+            "required": [
+                "action",
+                "price",
+                "quantity"
+            ],
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "display_quantity": {
+                    "description": "makes an iceberg order only showing this quantity on the board at a time, the remainder\nreplenishes it once it is filled",
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "post_only": {
+                    "description": "post-only orders never match immediately, they are rejected or repriced one tick away from\nthe other side of the board if they would",
+                    "type": "boolean",
+                    "example": false
+                },
+                "price": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "100.00"
+                }
+            }
+        },
+        "api.makeTWAPBody": {
+            "type": "object",
+            "required": [
+                "action",
+                "end_at",
+                "quantity",
+                "slices"
+            ],
+            "properties": {
+                "action": {
+                    "enum": [
+                        "buy",
+                        "sell"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "end_at": {
+                    "type": "string",
+                    "example": "2021-01-01T10:00:00Z"
+                },
+                "limit_price": {
+                    "description": "child orders are limit orders at this price if given, otherwise they take from the board",
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "slices": {
+                    "description": "number of child orders",
+                    "type": "integer",
+                    "example": 10
+                },
+                "start_at": {
+                    "description": "starts now if absent",
+                    "type": "string",
+                    "example": "2021-01-01T09:00:00Z"
+                }
+            }
+        },
+        "api.makeTrailingStopBody": {
+            "type": "object",
+            "required": [
+                "action",
+                "quantity"
+            ],
+            "properties": {
+                "action": {
+                    "enum": [
+                        "buy",
+                        "sell"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "sell"
+                },
+                "limit_offset": {
+                    "description": "distance between the limit price and the stop price, the limit price is below the stop price for sell orders",
+                    "type": "string",
+                    "minLength": 0,
+                    "example": "0.10"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "trail_amount": {
+                    "description": "distance between the stop price and the latest price, exclusive with trail_percent",
+                    "type": "string",
+                    "example": "0.50"
+                },
+                "trail_percent": {
+                    "description": "distance between the stop price and the latest price in percent of the latest price, exclusive with trail_amount",
+                    "type": "string",
+                    "example": "5.00"
+                }
+            }
+        },
+        "api.pageResp": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "next": {
+                    "type": "string",
+                    "example": "next cursor value"
+                }
+            }
+        },
+        "api.previewNotificationBody": {
+            "type": "object",
+            "required": [
+                "event"
+            ],
+            "properties": {
+                "data": {
+                    "description": "sample data is used if absent",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationData"
+                        }
+                    ]
+                },
+                "event": {
+                    "enum": [
+                        "order_created",
+                        "order_filled",
+                        "rfq_requested"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationEvent"
+                        }
+                    ],
+                    "example": "order_created"
+                },
+                "locale": {
+                    "type": "string",
+                    "maxLength": 16,
+                    "example": "zh-TW"
+                }
+            }
+        },
+        "api.quoteBody": {
+            "type": "object",
+            "required": [
+                "price"
+            ],
+            "properties": {
+                "price": {
+                    "type": "string",
+                    "example": "10.00"
+                }
+            }
+        },
+        "api.requestQuoteBody": {
+            "type": "object",
+            "required": [
+                "action",
+                "quantity"
+            ],
+            "properties": {
+                "action": {
+                    "enum": [
+                        "buy",
+                        "sell"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "10000.00"
+                }
+            }
+        },
+        "api.takeOrderBody": {
+            "type": "object",
+            "required": [
+                "action",
+                "quantity"
+            ],
+            "properties": {
+                "action": {
+                    "description": "FIXME:user_id should be retrieved from the user's jwt token",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "100.00"
+                }
+            }
+        },
+        "api.timeZoneBody": {
+            "type": "object",
+            "required": [
+                "time_zone"
+            ],
+            "properties": {
+                "time_zone": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Asia/Taipei"
+                }
+            }
+        },
+        "api.updateNotificationPreferencesBody": {
             "type": "object",
+            "required": [
+                "email",
+                "push",
+                "sms"
+            ],
             "properties": {
-                "error": {
+                "email": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "push": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "sms": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.AuditAction": {
+            "type": "string",
+            "enum": [
+                "admin.list_orders",
+                "admin.cancel_order",
+                "admin.cancel_user_orders",
+                "admin.view_user_activity",
+                "admin.halt_instrument",
+                "admin.resume_instrument",
+                "admin.set_instrument_time_zone",
+                "admin.list_audit",
+                "admin.verify_audit",
+                "auth.issue_token",
+                "auth.permission_denied",
+                "order.make",
+                "order.make_oco",
+                "order.make_trailing_stop",
+                "order.take",
+                "order.delete",
+                "order.batch",
+                "parent_order.make_twap",
+                "parent_order.pause",
+                "parent_order.resume",
+                "parent_order.cancel",
+                "rfq.accept"
+            ],
+            "x-enum-varnames": [
+                "AuditAdminListOrders",
+                "AuditAdminCancelOrder",
+                "AuditAdminCancelUser",
+                "AuditAdminViewActivity",
+                "AuditAdminHalt",
+                "AuditAdminResume",
+                "AuditAdminTimeZone",
+                "AuditAdminListAudit",
+                "AuditAdminVerifyAudit",
+                "AuditTokenIssued",
+                "AuditPermissionDenied",
+                "AuditOrderMake",
+                "AuditOrderMakeOCO",
+                "AuditOrderTrailingStop",
+                "AuditOrderTake",
+                "AuditOrderDelete",
+                "AuditOrderBatch",
+                "AuditParentMakeTWAP",
+                "AuditParentPause",
+                "AuditParentResume",
+                "AuditParentCancel",
+                "AuditRFQAccept"
+            ]
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AuditAction"
+                        }
+                    ],
+                    "example": "admin.cancel_order"
+                },
+                "actor_id": {
+                    "description": "empty if the actor is not authenticated, e.g. when a token is issued",
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "detail": {
+                    "type": "object"
+                },
+                "hash": {
+                    "description": "hash of this entry, see Digest; empty for entries recorded before the trail was chained",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "payload_hash": {
+                    "description": "hex encoded SHA-256 of the request body, or of the query string if there is no body",
+                    "type": "string"
+                },
+                "prev_hash": {
+                    "description": "hash of the entry before this one, empty for the first entry",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "trace ID of the request taking the action, the same as the request ID of error responses",
+                    "type": "string",
+                    "example": "4bf92f3577b34da6a3ce929d0e0e4736"
+                },
+                "target": {
+                    "description": "what the action is taken on, e.g. the ID of the cancelled order",
+                    "type": "string",
+                    "example": "uuid"
+                }
+            }
+        },
+        "models.AuditVerification": {
+            "type": "object",
+            "properties": {
+                "broken_id": {
+                    "description": "ID of the first entry whose hash does not match, if the chain is broken",
+                    "type": "integer",
+                    "example": 42
+                },
+                "checked": {
+                    "description": "number of entries checked",
+                    "type": "integer",
+                    "example": 1024
+                },
+                "head": {
+                    "description": "hash of the last entry, keep it elsewhere to detect removal of the latest entries",
                     "type": "string"
                 },
-                "request_id": {
-                    "type": "string"
+                "unchained": {
+                    "description": "number of entries recorded before the trail was chained, they cannot be verified",
+                    "type": "integer",
+                    "example": 0
+                },
+                "valid": {
+                    "description": "whether every chained entry is intact",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.BatchOperationType": {
+            "type": "string",
+            "enum": [
+                "make",
+                "cancel"
+            ],
+            "x-enum-varnames": [
+                "BatchMake",
+                "BatchCancel"
+            ]
+        },
+        "models.BatchResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "reason of failure",
+                    "type": "string",
+                    "example": "not found"
+                },
+                "field": {
+                    "description": "the offending field of the operation, if the failure is caused by a specific one",
+                    "type": "string",
+                    "example": "price"
+                },
+                "order_id": {
+                    "description": "ID of the order made or cancelled",
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.BatchStatus"
+                        }
+                    ],
+                    "example": "done"
+                }
+            }
+        },
+        "models.BatchStatus": {
+            "type": "string",
+            "enum": [
+                "done",
+                "failed",
+                "aborted"
+            ],
+            "x-enum-varnames": [
+                "BatchDone",
+                "BatchFailed",
+                "BatchAborted"
+            ]
+        },
+        "models.FillSummary": {
+            "type": "object",
+            "properties": {
+                "average_price": {
+                    "type": "string",
+                    "example": "10.50"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "trades": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.HistoryKind": {
+            "type": "string",
+            "enum": [
+                "order",
+                "fill"
+            ],
+            "x-enum-varnames": [
+                "HistoryOrder",
+                "HistoryFill"
+            ]
+        },
+        "models.HistoryRecord": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "action of the user, i.e. the opposite of the taker for fills made by the user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "closed_at": {
+                    "description": "when orders were filled or cancelled, nil for open and pending orders and for fills",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "kind": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HistoryKind"
+                        }
+                    ],
+                    "example": "fill"
+                },
+                "maker_order_id": {
+                    "description": "ID of the resting order or RFQ quote filled, empty for orders",
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "off_book": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "price": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "role": {
+                    "description": "\"maker\" or \"taker\" for fills, empty for orders",
+                    "type": "string",
+                    "example": "taker"
+                },
+                "status": {
+                    "description": "status of orders, empty for fills",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderStatus"
+                        }
+                    ],
+                    "example": "open"
+                }
+            }
+        },
+        "models.MarketState": {
+            "type": "string",
+            "enum": [
+                "open",
+                "auction",
+                "closed",
+                "halted"
+            ],
+            "x-enum-varnames": [
+                "MarketOpen",
+                "MarketAuction",
+                "MarketClosed",
+                "MarketHalted"
+            ]
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string",
+                    "example": "\u003cp\u003eYour buy order of 100.00 at 10.00 has been created.\u003c/p\u003e"
+                },
+                "push": {
+                    "$ref": "#/definitions/models.PushPayload"
+                },
+                "sms": {
+                    "type": "string",
+                    "example": "Buy order 100.00@10.00 created"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Your order has been created"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Your buy order of 100.00 at 10.00 has been created."
+                }
+            }
+        },
+        "models.NotificationData": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:01:00Z"
+                },
+                "fill": {
+                    "description": "only available to OrderFilled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FillSummary"
+                        }
+                    ]
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "rfq_id": {
+                    "description": "only available to RFQRequested, market makers quote the RFQ until ExpiresAt",
+                    "type": "string",
+                    "example": "uuid"
+                }
+            }
+        },
+        "models.NotificationEvent": {
+            "type": "string",
+            "enum": [
+                "order_created",
+                "order_filled",
+                "rfq_requested"
+            ],
+            "x-enum-varnames": [
+                "OrderCreated",
+                "OrderFilled",
+                "RFQRequested"
+            ]
+        },
+        "models.NotificationPreferences": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "push": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "sms": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.Order": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderAction"
+                        }
+                    ],
+                    "example": "buy"
+                },
+                "closed_at": {
+                    "description": "when the order was filled or cancelled, nil while it is open or pending",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "limit_offset": {
+                    "type": "string",
+                    "example": "0.50"
+                },
+                "oco_group_id": {
+                    "description": "orders of the same one-cancels-other group reduce each other when any of them is filled",
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "price": {
+                    "description": "using fixed-point Decimal instead of float64 to avoid floating point precision issue",
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "quantity": {
+                    "description": "the visible quantity of iceberg orders",
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderStatus"
+                        }
+                    ],
+                    "example": "open"
+                },
+                "stop_price": {
+                    "description": "stop orders are pending until the latest price reaches StopPrice, when they are put on the board at Price",
+                    "type": "string",
+                    "example": "9.00"
+                },
+                "trail_amount": {
+                    "description": "the stop price of trailing stop orders follows the latest price by TrailAmount or TrailPercent,\nand their limit price stays LimitOffset away from the stop price",
+                    "type": "string",
+                    "example": "1.00"
+                },
+                "trail_percent": {
+                    "type": "string",
+                    "example": "5.00"
                 }
             }
         },
-        "api.makeOrderBody": {
-            "type": "object",
-            "required": [
-                "action",
-                "price",
-                "quantity"
+        "models.OrderAction": {
+            "type": "string",
+            "enum": [
+                "buy",
+                "sell"
+            ],
+            "x-enum-varnames": [
+                "Buy",
+                "Sell"
+            ]
+        },
+        "models.OrderBoardType": {
+            "type": "string",
+            "enum": [
+                "live",
+                "history",
+                "removed"
+            ],
+            "x-enum-varnames": [
+                "Live",
+                "History",
+                "Removed"
+            ]
+        },
+        "models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "pending",
+                "filled",
+                "cancelled"
             ],
+            "x-enum-comments": {
+                "OrderCancelled": "off the board, cancelled by its owner, an operator or its one-cancels-other group",
+                "OrderFullyFilled": "off the board, nothing is left to fill",
+                "OrderOpen": "on the board",
+                "OrderPending": "stop orders waiting for the latest price to reach their stop price"
+            },
+            "x-enum-varnames": [
+                "OrderOpen",
+                "OrderPending",
+                "OrderFullyFilled",
+                "OrderCancelled"
+            ]
+        },
+        "models.ParentOrder": {
+            "type": "object",
             "properties": {
                 "action": {
                     "allOf": [
@@ -291,37 +3088,170 @@ const docTemplate = `{
                     ],
                     "example": "buy"
                 },
-                "price": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "end_at": {
+                    "type": "string",
+                    "example": "2021-01-01T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "market closed"
+                },
+                "limit_price": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "next_slice_at": {
+                    "description": "nil once completed or cancelled",
+                    "type": "string",
+                    "example": "2021-01-01T09:18:00Z"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "sent_quantity": {
+                    "type": "string",
+                    "example": "300.00"
+                },
+                "slices": {
                     "type": "integer",
-                    "minimum": 1,
                     "example": 10
                 },
-                "quantity": {
+                "slices_sent": {
+                    "description": "progress, the quantity of a failed slice is spread over the remaining ones",
                     "type": "integer",
-                    "minimum": 1,
-                    "example": 100
+                    "example": 3
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "2021-01-01T09:00:00Z"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ParentOrderStatus"
+                        }
+                    ],
+                    "example": "active"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
                 }
             }
         },
-        "api.pageResp": {
+        "models.ParentOrderStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "paused",
+                "cancelled",
+                "completed"
+            ],
+            "x-enum-comments": {
+                "ParentActive": "sending child orders on schedule",
+                "ParentCancelled": "no more child orders, the sent ones are kept",
+                "ParentCompleted": "every slice is sent",
+                "ParentPaused": "no child order is sent until resumed"
+            },
+            "x-enum-varnames": [
+                "ParentActive",
+                "ParentPaused",
+                "ParentCancelled",
+                "ParentCompleted"
+            ]
+        },
+        "models.Position": {
             "type": "object",
             "properties": {
-                "data": {},
-                "next": {
+                "average_price": {
                     "type": "string",
-                    "example": "next cursor value"
+                    "example": "10.50"
+                },
+                "quantity": {
+                    "description": "positive for a long position, negative for a short one",
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "realized_pnl": {
+                    "type": "string",
+                    "example": "50.00"
+                },
+                "unrealized_pnl": {
+                    "type": "string",
+                    "example": "-20.00"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "uuid"
                 }
             }
         },
-        "api.takeOrderBody": {
+        "models.PushPayload": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Buy 100.00 at 10.00"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Order created"
+                }
+            }
+        },
+        "models.Quote": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:10Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "market_maker_id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "rfq_id": {
+                    "type": "string",
+                    "example": "uuid"
+                }
+            }
+        },
+        "models.RFQ": {
             "type": "object",
-            "required": [
-                "action",
-                "quantity"
-            ],
             "properties": {
                 "action": {
-                    "description": "FIXME:user_id should be retrieved from the user's jwt token",
+                    "description": "action of the requester",
                     "allOf": [
                         {
                             "$ref": "#/definitions/models.OrderAction"
@@ -329,17 +3259,110 @@ const docTemplate = `{
                     ],
                     "example": "buy"
                 },
+                "created_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2021-01-01T00:01:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
                 "quantity": {
-                    "type": "integer",
-                    "minimum": 1,
-                    "example": 100
+                    "type": "string",
+                    "example": "10000.00"
+                },
+                "quotes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Quote"
+                    }
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RFQStatus"
+                        }
+                    ],
+                    "example": "open"
+                },
+                "trade_id": {
+                    "description": "the off-book trade once accepted",
+                    "type": "string",
+                    "example": "uuid"
                 }
             }
         },
-        "models.Order": {
+        "models.RFQStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "accepted",
+                "expired"
+            ],
+            "x-enum-comments": {
+                "RFQAccepted": "a quote is accepted and executed as an off-book trade",
+                "RFQExpired": "no quote was accepted in time",
+                "RFQOpen": "collecting quotes until it expires"
+            },
+            "x-enum-varnames": [
+                "RFQOpen",
+                "RFQAccepted",
+                "RFQExpired"
+            ]
+        },
+        "models.Ticker": {
+            "type": "object",
+            "properties": {
+                "change_percent": {
+                    "description": "change of Last from Open in percent",
+                    "type": "string",
+                    "example": "5.00"
+                },
+                "high": {
+                    "type": "string",
+                    "example": "10.80"
+                },
+                "last": {
+                    "type": "string",
+                    "example": "10.50"
+                },
+                "low": {
+                    "type": "string",
+                    "example": "9.90"
+                },
+                "open": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "since": {
+                    "description": "start of the window, statistics are kept per minute",
+                    "type": "string",
+                    "example": "2021-01-01T00:00:00Z"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "AAPL"
+                },
+                "volume": {
+                    "type": "string",
+                    "example": "12000.00"
+                },
+                "vwap": {
+                    "description": "volume weighted average price",
+                    "type": "string",
+                    "example": "10.32"
+                }
+            }
+        },
+        "models.Trade": {
             "type": "object",
             "properties": {
                 "action": {
+                    "description": "action of the taker",
                     "allOf": [
                         {
                             "$ref": "#/definitions/models.OrderAction"
@@ -355,40 +3378,54 @@ const docTemplate = `{
                     "type": "string",
                     "example": "uuid"
                 },
+                "maker_id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "maker_order_id": {
+                    "type": "string",
+                    "example": "uuid"
+                },
+                "off_book": {
+                    "description": "executed by accepting a quote of an RFQ instead of on the board, MakerOrderID is then the ID of the quote",
+                    "type": "boolean",
+                    "example": false
+                },
                 "price": {
-                    "description": "using int instead of float64 to avoid floating point precision issue",
-                    "type": "integer",
-                    "example": 10
+                    "type": "string",
+                    "example": "10.00"
                 },
                 "quantity": {
-                    "type": "integer",
-                    "example": 100
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "taker_id": {
+                    "type": "string",
+                    "example": "uuid"
                 }
             }
         },
-        "models.OrderAction": {
-            "type": "string",
-            "enum": [
-                "buy",
-                "sell"
-            ],
-            "x-enum-varnames": [
-                "Buy",
-                "Sell"
-            ]
-        },
-        "models.OrderBoardType": {
-            "type": "string",
-            "enum": [
-                "live",
-                "history",
-                "removed"
-            ],
-            "x-enum-varnames": [
-                "Live",
-                "History",
-                "Removed"
-            ]
+        "models.UserActivity": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "description": "most recent first, including filled and cancelled orders",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Order"
+                    }
+                },
+                "position": {
+                    "$ref": "#/definitions/models.Position"
+                },
+                "trades": {
+                    "description": "most recent first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Trade"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -4,6 +4,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 
 	_ "go.uber.org/automaxprocs"
 
@@ -11,7 +12,10 @@ import (
 	"github.com/A-pen-app/kickstart/config"
 	"github.com/A-pen-app/kickstart/database"
 	"github.com/A-pen-app/kickstart/global"
+	"github.com/A-pen-app/kickstart/models"
 	"github.com/A-pen-app/kickstart/server/app"
+	"github.com/A-pen-app/kickstart/service"
+	"github.com/A-pen-app/kickstart/store"
 	"github.com/A-pen-app/logging"
 	"github.com/A-pen-app/mq"
 	"github.com/A-pen-app/mq/pubsubLite"
@@ -78,6 +82,11 @@ func main() {
 	})
 	defer cache.Finalize()
 
+	// Setup the scale of prices and quantities before any of them is parsed.
+	if err := models.SetDecimalScale(config.GetInt("DECIMAL_SCALE")); err != nil {
+		panic(err)
+	}
+
 	// Setup database module.
 	database.Initialize(ctx)
 	defer database.Finalize()
@@ -90,6 +99,7 @@ func main() {
 			Topics: map[string]string{
 				"mail": "mail",
 				"sms":  "sms",
+				"push": "push",
 			},
 		},
 		Rabbitmq: &rabbitmq.Config{
@@ -99,18 +109,36 @@ func main() {
 	})
 	defer mq.Finalize()
 
+	// Start relaying messages written to the outbox to the mq module.
+	relay := service.NewOutboxRelay(store.NewOutbox(database.GetPostgres()), mq.GetPubsub())
+	go relay.Run(ctx)
+
 	// Create HTTP server instance to listen on all interfaces.
 	address := fmt.Sprintf("%s:%s",
 		config.GetString("SERVER_LISTEN_ADDRESS"),
 		config.GetString("SERVER_LISTEN_PORT"))
-	server := app.CreateServer(ctx, address)
+	server, rpcServer := app.CreateServer(ctx, address)
+
+	// Create gRPC listener next to the HTTP server.
+	rpcAddress := fmt.Sprintf("%s:%s",
+		config.GetString("SERVER_LISTEN_ADDRESS"),
+		config.GetString("GRPC_LISTEN_PORT"))
+	listener, err := net.Listen("tcp", rpcAddress)
+	if err != nil {
+		panic(err)
+	}
 
 	// Now that we finished initializing all necessary modules,
 	// let's turn on the readiness indication flag.
 	global.Ready = true
 
 	// Start servicing requests.
-	logging.Info(ctx, "Initialization complete, listening on %s...", address)
+	logging.Info(ctx, "Initialization complete, listening on %s and %s...", address, rpcAddress)
+	go func() {
+		if err := rpcServer.Serve(listener); err != nil {
+			logging.Info(ctx, err.Error())
+		}
+	}()
 	if err := server.ListenAndServe(); err != nil {
 		logging.Info(ctx, err.Error())
 	}
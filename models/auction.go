@@ -0,0 +1,64 @@
+package models
+
+import (
+	"sort"
+)
+
+// Auction is the outcome of uncrossing the board at a single clearing price, during the call phase
+// it is indicative, showing where the board would uncross if the call phase ended now.
+type Auction struct {
+	// clearing price maximizing the executed volume, nil if the board does not cross
+	Price  *Decimal `json:"indicative_price" swaggertype:"string" example:"10.00"`
+	Volume Decimal  `json:"indicative_volume" swaggertype:"string" example:"100.00"`
+	// quantity left unmatched at Price, positive on the buy side and negative on the sell side
+	Imbalance Decimal `json:"imbalance" swaggertype:"string" example:"-20.00"`
+}
+
+// Uncross finds the clearing price of buy and sell orders, the price executing the highest volume,
+// then leaving the lowest imbalance, then closest to referencePrice, then the lower one. The hidden
+// quantity of iceberg orders takes part in full, as it replenishes them while they are matched.
+func Uncross(buys, sells []*Order, referencePrice Decimal) *Auction {
+	prices := []Decimal{}
+	seen := map[Decimal]bool{}
+	for _, orders := range [][]*Order{buys, sells} {
+		for _, o := range orders {
+			if !seen[o.Price] {
+				seen[o.Price] = true
+				prices = append(prices, o.Price)
+			}
+		}
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+
+	auction := &Auction{}
+	for _, price := range prices {
+		var demand, supply Decimal
+		for _, o := range buys {
+			if o.Price >= price {
+				demand += o.Quantity + o.HiddenQuantity
+			}
+		}
+		for _, o := range sells {
+			if o.Price <= price {
+				supply += o.Quantity + o.HiddenQuantity
+			}
+		}
+		volume, imbalance := min(demand, supply), demand-supply
+		if volume <= 0 {
+			continue
+		}
+		better := auction.Price == nil || volume > auction.Volume
+		if !better && volume == auction.Volume {
+			if imbalance.Abs() != auction.Imbalance.Abs() {
+				better = imbalance.Abs() < auction.Imbalance.Abs()
+			} else {
+				better = (price - referencePrice).Abs() < (*auction.Price - referencePrice).Abs()
+			}
+		}
+		if better {
+			p := price
+			auction.Price, auction.Volume, auction.Imbalance = &p, volume, imbalance
+		}
+	}
+	return auction
+}
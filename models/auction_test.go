@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUncross(t *testing.T) {
+	order := func(price, quantity int64) *Order {
+		return &Order{Price: NewDecimal(price), Quantity: NewDecimal(quantity)}
+	}
+	buys := []*Order{order(12, 5), order(11, 5), order(10, 10)}
+	sells := []*Order{order(9, 4), order(10, 6), order(11, 8)}
+
+	// 10 and 11 both execute 10, 11 leaves less imbalance
+	auction := Uncross(buys, sells, NewDecimal(10))
+	require.Equal(t, NewDecimal(11), *auction.Price)
+	require.Equal(t, NewDecimal(10), auction.Volume)
+	require.Equal(t, NewDecimal(-8), auction.Imbalance, "expect 8 left on the sell side")
+
+	// same volume and imbalance, the price closest to the reference price wins
+	auction = Uncross([]*Order{order(11, 5)}, []*Order{order(10, 5)}, NewDecimal(13))
+	require.Equal(t, NewDecimal(11), *auction.Price)
+	auction = Uncross([]*Order{order(11, 5)}, []*Order{order(10, 5)}, NewDecimal(10))
+	require.Equal(t, NewDecimal(10), *auction.Price)
+
+	// the hidden quantity of iceberg orders is matched as they replenish
+	iceberg := order(10, 2)
+	iceberg.HiddenQuantity = NewDecimal(3)
+	auction = Uncross([]*Order{order(11, 5)}, []*Order{iceberg}, NewDecimal(10))
+	require.Equal(t, NewDecimal(5), auction.Volume)
+	require.Equal(t, NewDecimal(0), auction.Imbalance)
+
+	auction = Uncross([]*Order{order(9, 5)}, []*Order{order(10, 5)}, NewDecimal(10))
+	require.Nil(t, auction.Price, "expect no clearing price if the board does not cross")
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// AuditAction names an action recorded in the audit trail
+type AuditAction string
+
+const (
+	AuditAdminListOrders   AuditAction = "admin.list_orders"
+	AuditAdminCancelOrder  AuditAction = "admin.cancel_order"
+	AuditAdminCancelUser   AuditAction = "admin.cancel_user_orders"
+	AuditAdminViewActivity AuditAction = "admin.view_user_activity"
+	AuditAdminHalt         AuditAction = "admin.halt_instrument"
+	AuditAdminResume       AuditAction = "admin.resume_instrument"
+	AuditAdminTimeZone     AuditAction = "admin.set_instrument_time_zone"
+	AuditAdminListAudit    AuditAction = "admin.list_audit"
+	AuditAdminVerifyAudit  AuditAction = "admin.verify_audit"
+
+	AuditTokenIssued      AuditAction = "auth.issue_token"
+	AuditPermissionDenied AuditAction = "auth.permission_denied"
+
+	AuditOrderMake         AuditAction = "order.make"
+	AuditOrderMakeOCO      AuditAction = "order.make_oco"
+	AuditOrderTrailingStop AuditAction = "order.make_trailing_stop"
+	AuditOrderTake         AuditAction = "order.take"
+	AuditOrderDelete       AuditAction = "order.delete"
+	AuditOrderBatch        AuditAction = "order.batch"
+
+	AuditParentMakeTWAP AuditAction = "parent_order.make_twap"
+	AuditParentPause    AuditAction = "parent_order.pause"
+	AuditParentResume   AuditAction = "parent_order.resume"
+	AuditParentCancel   AuditAction = "parent_order.cancel"
+
+	AuditRFQAccept AuditAction = "rfq.accept"
+)
+
+// AuditEntry records who did what to which target, it is never updated nor deleted. Entries are
+// chained by hash, so changing or removing one breaks the chain from there on.
+type AuditEntry struct {
+	ID int64 `json:"id" db:"id"`
+	// empty if the actor is not authenticated, e.g. when a token is issued
+	ActorID string      `json:"actor_id" db:"actor_id" example:"uuid"`
+	Action  AuditAction `json:"action" db:"action" example:"admin.cancel_order"`
+	// what the action is taken on, e.g. the ID of the cancelled order
+	Target string          `json:"target" db:"target" example:"uuid"`
+	Detail json.RawMessage `json:"detail,omitempty" db:"detail" swaggertype:"object"`
+	// trace ID of the request taking the action, the same as the request ID of error responses
+	RequestID string `json:"request_id" db:"request_id" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	IP        string `json:"ip" db:"ip" example:"203.0.113.7"`
+	// hex encoded SHA-256 of the request body, or of the query string if there is no body
+	PayloadHash string    `json:"payload_hash" db:"payload_hash"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at" example:"2021-01-01T00:00:00Z"`
+	// hash of the entry before this one, empty for the first entry
+	PrevHash string `json:"prev_hash" db:"prev_hash"`
+	// hash of this entry, see Digest; empty for entries recorded before the trail was chained
+	Hash string `json:"hash" db:"hash"`
+}
+
+// Digest returns the hex encoded SHA-256 of every field of entry but ID and Hash. Detail is
+// re-encoded first, so the digest does not depend on how the database formats JSON.
+func (e *AuditEntry) Digest() (string, error) {
+	detail := ""
+	if len(e.Detail) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(e.Detail, &v); err != nil {
+			return "", err
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		detail = string(b)
+	}
+	b, err := json.Marshal([]string{
+		e.PrevHash,
+		e.ActorID,
+		string(e.Action),
+		e.Target,
+		detail,
+		e.RequestID,
+		e.IP,
+		e.PayloadHash,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Chain links entry to the entry hashed prevHash, CreatedAt should be set beforehand
+func (e *AuditEntry) Chain(prevHash string) error {
+	e.PrevHash = prevHash
+	hash, err := e.Digest()
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+	return nil
+}
+
+// AuditFilter narrows down the audit trail, nil fields match every entry
+type AuditFilter struct {
+	ActorID *string
+	Action  *AuditAction
+	Target  *string
+	// entries created at or after From and before To
+	From *time.Time
+	To   *time.Time
+}
+
+// AuditVerification is the result of checking the hash chain of the audit trail
+type AuditVerification struct {
+	// whether every chained entry is intact
+	Valid bool `json:"valid" example:"true"`
+	// number of entries checked
+	Checked int `json:"checked" example:"1024"`
+	// number of entries recorded before the trail was chained, they cannot be verified
+	Unchained int `json:"unchained" example:"0"`
+	// hash of the last entry, keep it elsewhere to detect removal of the latest entries
+	Head string `json:"head"`
+	// ID of the first entry whose hash does not match, if the chain is broken
+	BrokenID *int64 `json:"broken_id,omitempty" example:"42"`
+}
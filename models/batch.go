@@ -0,0 +1,67 @@
+package models
+
+import "fmt"
+
+// BatchOperationType tells what an operation of an order batch does
+type BatchOperationType string
+
+const (
+	BatchMake   BatchOperationType = "make"
+	BatchCancel BatchOperationType = "cancel"
+)
+
+// BatchOperation makes or cancels an order of a batch
+type BatchOperation struct {
+	Type BatchOperationType
+	// the order to make, the same as a single order made
+	Action          OrderAction
+	Price           Decimal
+	Quantity        Decimal
+	DisplayQuantity *Decimal
+	PostOnly        bool
+	// the order to cancel along with its one-cancels-other group, it must belong to the user
+	OrderID string
+}
+
+// BatchItem is an operation of a batch ready to be executed, Order is made if it is set,
+// otherwise the order OrderID is cancelled
+type BatchItem struct {
+	Order    *Order
+	Messages []*OutboxMessage
+	OrderID  string
+}
+
+type BatchStatus string
+
+const (
+	BatchDone   BatchStatus = "done"
+	BatchFailed BatchStatus = "failed"
+	// not executed since another operation of an all-or-nothing batch failed
+	BatchAborted BatchStatus = "aborted"
+)
+
+// BatchResult is the result of an operation of a batch
+type BatchResult struct {
+	Status BatchStatus `json:"status" example:"done"`
+	// ID of the order made or cancelled
+	OrderID string `json:"order_id,omitempty" example:"uuid"`
+	// reason of failure
+	Error string `json:"error,omitempty" example:"not found"`
+	// the offending field of the operation, if the failure is caused by a specific one
+	Field string `json:"field,omitempty" example:"price"`
+}
+
+// BatchError is the failure of an item of a batch executed all at once
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d of batch: %s", e.Index, e.Err)
+}
+
+// Unwrap makes errors.Is hold for the error of the failed item
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+// Cursor is where the next page of a list ordered by creation time and then ID, newest first, starts.
+// It carries the keys of the last item of the previous page rather than referring to the item, so
+// pages carry on even if the item is removed or recreated in the meantime.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NewCursor returns the opaque cursor of the page after the item created at createdAt with given ID
+func NewCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.Format(time.RFC3339Nano) + " " + id))
+}
+
+// ParseCursor decodes a cursor returned by NewCursor, it returns ErrorWrongParams if next is malformed
+func ParseCursor(next string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(next)
+	if err != nil {
+		return nil, ErrorWrongParams
+	}
+	createdAt, id, ok := strings.Cut(string(b), " ")
+	if !ok || id == "" {
+		return nil, ErrorWrongParams
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrorWrongParams
+	}
+	return &Cursor{CreatedAt: t, ID: id}, nil
+}
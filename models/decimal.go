@@ -0,0 +1,184 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is a fixed-point number stored as an integer count of 10^-DecimalScale units,
+// it is used for prices and quantities to avoid floating point precision and integer overflow issues.
+type Decimal int64
+
+// DecimalScale is the number of fractional digits of a Decimal, it is set by SetDecimalScale at startup
+var DecimalScale = 2
+
+// decimalOne is the number of units of Decimal 1
+var decimalOne int64 = 100
+
+// SetDecimalScale sets the number of fractional digits of a Decimal, scale must be within [0, 18].
+// It must be called before any Decimal is used, as existing values are not rescaled.
+func SetDecimalScale(scale int) error {
+	if scale < 0 || scale > 18 {
+		return fmt.Errorf("invalid decimal scale %d", scale)
+	}
+	one := int64(1)
+	for i := 0; i < scale; i++ {
+		one *= 10
+	}
+	DecimalScale, decimalOne = scale, one
+	return nil
+}
+
+// NewDecimal returns the Decimal of given integer value
+func NewDecimal(value int64) Decimal {
+	return Decimal(value * decimalOne)
+}
+
+// ParseDecimal parses a decimal string like "-12.345", it returns ErrorWrongParams
+// if the string is malformed, has more fractional digits than DecimalScale or overflows.
+func ParseDecimal(s string) (Decimal, error) {
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, ErrorWrongParams
+	}
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return 0, ErrorWrongParams
+		}
+	}
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > DecimalScale {
+		return 0, ErrorWrongParams
+	}
+	fracPart += strings.Repeat("0", DecimalScale-len(fracPart))
+
+	units, err := strconv.ParseInt("0"+intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, ErrorWrongParams
+	}
+	if neg {
+		units = -units
+	}
+	return Decimal(units), nil
+}
+
+func (d Decimal) String() string {
+	sign, units := "", uint64(d)
+	if d < 0 {
+		sign, units = "-", uint64(-d)
+	}
+	s := strconv.FormatUint(units, 10)
+	if DecimalScale == 0 {
+		return sign + s
+	}
+	if len(s) <= DecimalScale {
+		s = strings.Repeat("0", DecimalScale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-DecimalScale] + "." + s[len(s)-DecimalScale:]
+}
+
+// Abs returns the absolute value of d
+func (d Decimal) Abs() Decimal {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Add returns d + o, or ErrorWrongParams if the result overflows
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	r := d + o
+	if (o > 0 && r < d) || (o < 0 && r > d) {
+		return 0, ErrorWrongParams
+	}
+	return r, nil
+}
+
+// Mul returns d * o, or ErrorWrongParams if the result overflows,
+// digits beyond DecimalScale are truncated.
+func (d Decimal) Mul(o Decimal) (Decimal, error) {
+	return d.MulDiv(o, Decimal(decimalOne))
+}
+
+// Div returns d / o, or ErrorWrongParams if o is zero or the result overflows,
+// digits beyond DecimalScale are truncated.
+func (d Decimal) Div(o Decimal) (Decimal, error) {
+	return d.MulDiv(Decimal(decimalOne), o)
+}
+
+// MulDiv returns d * m / n without overflowing the intermediate product,
+// or ErrorWrongParams if n is zero or the result overflows.
+func (d Decimal) MulDiv(m, n Decimal) (Decimal, error) {
+	if n == 0 {
+		return 0, ErrorWrongParams
+	}
+	r := new(big.Int).Mul(big.NewInt(int64(d)), big.NewInt(int64(m)))
+	r.Quo(r, big.NewInt(int64(n)))
+	if !r.IsInt64() {
+		return 0, ErrorWrongParams
+	}
+	return Decimal(r.Int64()), nil
+}
+
+// MarshalJSON encodes d as a JSON string to keep its precision on all clients
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts both JSON strings and numbers
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Value implements driver.Valuer, d is stored as a numeric column
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner for numeric and bigint columns
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return d.scanString(string(v))
+	case string:
+		return d.scanString(v)
+	case int64:
+		if r, err := Decimal(v).MulDiv(Decimal(decimalOne), 1); err == nil {
+			*d = r
+			return nil
+		}
+		return fmt.Errorf("decimal overflow scanning %d", v)
+	}
+	return fmt.Errorf("unsupported type %T to scan into decimal", src)
+}
+
+func (d *Decimal) scanString(s string) error {
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return fmt.Errorf("invalid decimal %q: %w", s, err)
+	}
+	*d = v
+	return nil
+}
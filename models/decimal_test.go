@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	d, err := ParseDecimal("12.5")
+	require.NoError(t, err)
+	require.Equal(t, "12.50", d.String())
+
+	_, err = ParseDecimal("0.001")
+	require.ErrorIs(t, err, ErrorWrongParams, "expect digits beyond scale to be rejected")
+
+	_, err = ParseDecimal("1.2.3")
+	require.ErrorIs(t, err, ErrorWrongParams, "expect malformed decimal to be rejected")
+
+	_, err = Decimal(1 << 62).Mul(NewDecimal(4))
+	require.ErrorIs(t, err, ErrorWrongParams, "expect overflow to be rejected")
+
+	notional, err := d.Mul(NewDecimal(3))
+	require.NoError(t, err)
+	require.Equal(t, "37.50", notional.String())
+
+	data, err := notional.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `"37.50"`, string(data))
+}
+
+func TestSetDecimalScale(t *testing.T) {
+	defer SetDecimalScale(DecimalScale)
+
+	require.Error(t, SetDecimalScale(19), "expect scale beyond int64 precision to be rejected")
+	require.NoError(t, SetDecimalScale(3))
+	d, err := ParseDecimal("0.001")
+	require.NoError(t, err)
+	require.Equal(t, "0.001", d.String())
+	require.Equal(t, "1.000", NewDecimal(1).String())
+}
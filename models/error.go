@@ -1,6 +1,9 @@
 package models
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrorNotFound       = errors.New("not found")
@@ -8,8 +11,28 @@ var (
 	ErrorWrongParams    = errors.New("wrong parameters")
 	ErrorUnsupported    = errors.New("unsupported")
 	ErrorNotAllowed     = errors.New("action not allowed")
+	ErrorMarketHalted   = errors.New("market halted")
+	ErrorMarketClosed   = errors.New("market closed")
+	ErrorMarketAuction  = errors.New("market in call auction")
+	ErrorBookBusy       = errors.New("order book busy, try again later")
+	ErrorNoLeader       = errors.New("no matcher leader, try again later")
 )
 
+// FieldError is a parameter error caused by a specific field of the request
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Reason)
+}
+
+// Unwrap makes errors.Is(err, ErrorWrongParams) hold for field errors
+func (e *FieldError) Unwrap() error {
+	return ErrorWrongParams
+}
+
 type VerifyCodeError error
 
 var (
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// HistoryKind tells what a record of the order history of a user is
+type HistoryKind string
+
+const (
+	// an order, whether it is still open or pending or it is filled or cancelled already
+	HistoryOrder HistoryKind = "order"
+	// a trade taken or made by the user
+	HistoryFill HistoryKind = "fill"
+)
+
+// HistoryRecord is an order or a fill of a user, flattened to be exported for accounting
+type HistoryRecord struct {
+	Kind      HistoryKind `json:"kind" example:"fill"`
+	ID        string      `json:"id" example:"uuid"`
+	CreatedAt time.Time   `json:"created_at" example:"2021-01-01T00:00:00Z"`
+	// action of the user, i.e. the opposite of the taker for fills made by the user
+	Action   OrderAction `json:"action" example:"buy"`
+	Price    Decimal     `json:"price" swaggertype:"string" example:"10.00"`
+	Quantity Decimal     `json:"quantity" swaggertype:"string" example:"100.00"`
+	// status of orders, empty for fills
+	Status OrderStatus `json:"status,omitempty" example:"open"`
+	// "maker" or "taker" for fills, empty for orders
+	Role string `json:"role,omitempty" example:"taker"`
+	// ID of the resting order or RFQ quote filled, empty for orders
+	MakerOrderID string `json:"maker_order_id,omitempty" example:"uuid"`
+	OffBook      bool   `json:"off_book" example:"false"`
+	// when orders were filled or cancelled, nil for open and pending orders and for fills
+	ClosedAt *time.Time `json:"closed_at,omitempty" example:"2021-01-01T00:00:00Z"`
+}
+
+// HistoryCSVHeader is the header row of order histories exported as CSV, in the order of HistoryRecord.CSV
+var HistoryCSVHeader = []string{"kind", "id", "created_at", "action", "price", "quantity", "status", "role", "maker_order_id", "off_book", "closed_at"}
+
+// NewOrderRecord returns the history record of an order
+func NewOrderRecord(order *Order) *HistoryRecord {
+	return &HistoryRecord{
+		Kind:      HistoryOrder,
+		ID:        order.ID,
+		CreatedAt: order.CreatedAt,
+		Action:    order.Action,
+		Price:     order.Price,
+		Quantity:  order.Quantity + order.HiddenQuantity, // including the hidden remainder of iceberg orders
+		Status:    order.Status,
+		ClosedAt:  order.ClosedAt,
+	}
+}
+
+// NewFillRecord returns the history record of a trade taken or made by given user
+func NewFillRecord(trade *Trade, userID string) *HistoryRecord {
+	record := &HistoryRecord{
+		Kind:         HistoryFill,
+		ID:           trade.ID,
+		CreatedAt:    trade.CreatedAt,
+		Action:       trade.Action,
+		Price:        trade.Price,
+		Quantity:     trade.Quantity,
+		Role:         "taker",
+		MakerOrderID: trade.MakerOrderID,
+		OffBook:      trade.OffBook,
+	}
+	// a user taking its own order is recorded as the taker
+	if trade.TakerID != userID {
+		record.Role = "maker"
+		record.Action = Sell
+		if trade.Action == Sell {
+			record.Action = Buy
+		}
+	}
+	return record
+}
+
+// CSV returns the fields of r in the order of HistoryCSVHeader
+func (r *HistoryRecord) CSV() []string {
+	closedAt := ""
+	if r.ClosedAt != nil {
+		closedAt = r.ClosedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return []string{
+		string(r.Kind),
+		r.ID,
+		r.CreatedAt.UTC().Format(time.RFC3339Nano),
+		string(r.Action),
+		r.Price.String(),
+		r.Quantity.String(),
+		string(r.Status),
+		r.Role,
+		r.MakerOrderID,
+		strconv.FormatBool(r.OffBook),
+		closedAt,
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"sync"
+	"time"
+	// sessions must not depend on the time zone database of the host
+	_ "time/tzdata"
+)
+
+// Instrument defines the trading rules of a tradable product
+type Instrument struct {
+	Symbol string `json:"symbol" db:"symbol" example:"DEMO"`
+	// price of an order must be a multiple of tick size
+	TickSize Decimal `json:"tick_size" db:"tick_size" swaggertype:"string" example:"1.00"`
+	// quantity of an order must be a multiple of lot size
+	LotSize Decimal `json:"lot_size" db:"lot_size" swaggertype:"string" example:"1.00"`
+	// price * quantity of an order must be within [MinNotional, MaxNotional], 0 for no limit
+	MinNotional Decimal `json:"min_notional" db:"min_notional" swaggertype:"string" example:"10.00"`
+	MaxNotional Decimal `json:"max_notional" db:"max_notional" swaggertype:"string" example:"1000000.00"`
+	// price of an order must be within this percentage around the latest price, 0 for no limit
+	PriceBandPercent int `json:"price_band_percent" db:"price_band_percent" example:"50"`
+
+	// daily trading session in TimeZone, trading is allowed all day if either is nil
+	SessionOpen  *string `json:"session_open" db:"session_open" example:"09:00:00"`
+	SessionClose *string `json:"session_close" db:"session_close" example:"13:30:00"`
+	// IANA name of the time zone of the session, UTC if empty
+	TimeZone string `json:"time_zone" db:"time_zone" example:"Asia/Taipei"`
+	// the last AuctionMinutes before SessionOpen and before SessionClose are the call phases
+	// of the opening and closing auctions, 0 to trade continuously through the session
+	AuctionMinutes int `json:"auction_minutes" db:"auction_minutes" example:"5"`
+
+	// trading is halted for HaltMinutes once the latest price moves more than
+	// VolatilityPercent within VolatilityWindowMinutes, 0 to disable the circuit breaker
+	VolatilityPercent       int `json:"volatility_percent" db:"volatility_percent" example:"10"`
+	VolatilityWindowMinutes int `json:"volatility_window_minutes" db:"volatility_window_minutes" example:"5"`
+	HaltMinutes             int `json:"halt_minutes" db:"halt_minutes" example:"15"`
+
+	Halted     bool       `json:"halted" db:"halted" example:"false"`
+	HaltReason *string    `json:"halt_reason" db:"halt_reason" example:"maintenance"`
+	HaltUntil  *time.Time `json:"halt_until" db:"halt_until" example:"2021-01-01T00:00:00Z"` // nil if halted until resumed
+	// when trading was last resumed by an operator, nil if never
+	ResumedAt *time.Time `json:"resumed_at" db:"resumed_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// State returns the market state of the instrument at given time
+func (i *Instrument) State(now time.Time) MarketState {
+	if i.Halted && (i.HaltUntil == nil || now.Before(*i.HaltUntil)) {
+		return MarketHalted
+	}
+	if i.SessionOpen != nil && i.SessionClose != nil {
+		clock := now.In(i.Location()).Format(time.TimeOnly)
+		if i.AuctionMinutes > 0 {
+			call := time.Duration(i.AuctionMinutes) * time.Minute
+			if (clock >= before(*i.SessionOpen, call) && clock < *i.SessionOpen) ||
+				(clock >= before(*i.SessionClose, call) && clock < *i.SessionClose) {
+				return MarketAuction
+			}
+		}
+		if clock < *i.SessionOpen || clock >= *i.SessionClose {
+			return MarketClosed
+		}
+	}
+	return MarketOpen
+}
+
+// Location returns the time zone of the session, regardless of the time zone of the process. Unknown
+// names are rejected when the instrument is loaded, they fall back to UTC.
+func (i *Instrument) Location() *time.Location {
+	loc, err := LoadTimeZone(i.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// timeZones caches the time zones loaded by name
+var timeZones sync.Map
+
+// LoadTimeZone returns the time zone of given IANA name, UTC if name is empty. Each time zone is only
+// read from the time zone database once, as the state of the instrument is checked on every order.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if loc, ok := timeZones.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	timeZones.Store(name, loc)
+	return loc, nil
+}
+
+// before returns the time of day d before given time of day, not earlier than midnight
+func before(clock string, d time.Duration) string {
+	t, err := time.Parse(time.TimeOnly, clock)
+	if err != nil {
+		return clock
+	}
+	if earlier := t.Add(-d); earlier.Day() == t.Day() {
+		return earlier.Format(time.TimeOnly)
+	}
+	return "00:00:00"
+}
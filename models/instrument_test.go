@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentState(t *testing.T) {
+	open, close := "09:00:00", "13:30:00"
+	i := Instrument{SessionOpen: &open, SessionClose: &close, AuctionMinutes: 10}
+	at := func(clock string) time.Time {
+		t, _ := time.Parse(time.DateTime, "2026-01-02 "+clock)
+		return t
+	}
+
+	require.Equal(t, MarketClosed, i.State(at("08:49:59")))
+	require.Equal(t, MarketAuction, i.State(at("08:50:00")), "expect opening call phase")
+	require.Equal(t, MarketOpen, i.State(at("09:00:00")))
+	require.Equal(t, MarketAuction, i.State(at("13:20:00")), "expect closing call phase")
+	require.Equal(t, MarketClosed, i.State(at("13:30:00")))
+
+	// sessions are in the time zone of the instrument, whatever the time zone of now
+	i.TimeZone = "Asia/Taipei"
+	require.Equal(t, MarketClosed, i.State(at("09:00:00")), "expect 17:00 in Taipei")
+	require.Equal(t, MarketAuction, i.State(at("00:50:00")), "expect 08:50 in Taipei")
+	require.Equal(t, MarketOpen, i.State(at("01:00:00").In(time.FixedZone("UTC-5", -5*3600))))
+}
+
+func TestLoadTimeZone(t *testing.T) {
+	loc, err := LoadTimeZone("Asia/Taipei")
+	require.NoError(t, err)
+	cached, err := LoadTimeZone("Asia/Taipei")
+	require.NoError(t, err)
+	require.Same(t, loc, cached, "expect time zone to be loaded once")
+
+	loc, err = LoadTimeZone("")
+	require.NoError(t, err)
+	require.Equal(t, time.UTC, loc)
+
+	_, err = LoadTimeZone("Mars/Olympus_Mons")
+	require.Error(t, err, "expect unknown time zone to be rejected")
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// Lease grants its holder an exclusive role, e.g. being the matcher of an instrument, until it
+// expires, the holder renews it periodically and any replica may take it over once it expires.
+type Lease struct {
+	Name   string `json:"name" db:"name"`
+	Holder string `json:"holder" db:"holder"`
+	// where the holder serves requests, e.g. http://10.0.0.5:8000
+	Address   string    `json:"address" db:"address"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+type MarketState string
+
+const (
+	// orders can be made and taken
+	MarketOpen MarketState = "open"
+	// call phase of an opening or closing auction, orders can be made but do not match
+	// until they are uncrossed at a single price when the call phase ends
+	MarketAuction MarketState = "auction"
+	// outside of the trading session of the instrument
+	MarketClosed MarketState = "closed"
+	// trading is halted by an operator or a circuit breaker
+	MarketHalted MarketState = "halted"
+)
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+)
+
+type NotificationChannel string
+
+const (
+	EmailChannel NotificationChannel = "mail"
+	SMSChannel   NotificationChannel = "sms"
+	PushChannel  NotificationChannel = "push"
+)
+
+// NotificationPreferences defines through which channels a user wants to be notified
+type NotificationPreferences struct {
+	UserID    string    `json:"-" db:"user_id"`
+	Email     bool      `json:"email" db:"email" example:"true"`
+	SMS       bool      `json:"sms" db:"sms" example:"false"`
+	Push      bool      `json:"push" db:"push" example:"true"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// DefaultNotificationPreferences returns preferences of users who never set them, all channels are enabled
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID: userID,
+		Email:  true,
+		SMS:    true,
+		Push:   true,
+	}
+}
+
+type NotificationEvent string
+
+const (
+	OrderCreated NotificationEvent = "order_created"
+	OrderFilled  NotificationEvent = "order_filled"
+	RFQRequested NotificationEvent = "rfq_requested"
+)
+
+// NotificationData holds the variables available to notification templates
+type NotificationData struct {
+	OrderID  string       `json:"order_id" example:"uuid"`
+	Action   OrderAction  `json:"action" example:"buy"`
+	Price    Decimal      `json:"price" swaggertype:"string" example:"10.00"`
+	Quantity Decimal      `json:"quantity" swaggertype:"string" example:"100.00"`
+	Fill     *FillSummary `json:"fill,omitempty"` // only available to OrderFilled
+	// only available to RFQRequested, market makers quote the RFQ until ExpiresAt
+	RFQID     string     `json:"rfq_id,omitempty" example:"uuid"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2021-01-01T00:01:00Z"`
+}
+
+// FillSummary summarizes the trades executed for an order
+type FillSummary struct {
+	Quantity     Decimal `json:"quantity" swaggertype:"string" example:"100.00"`
+	AveragePrice Decimal `json:"average_price" swaggertype:"string" example:"10.50"`
+	Trades       int     `json:"trades" example:"3"`
+}
+
+// Notification is a notification rendered for every channel
+type Notification struct {
+	Subject string      `json:"subject" example:"Your order has been created"`
+	Text    string      `json:"text" example:"Your buy order of 100.00 at 10.00 has been created."`
+	HTML    string      `json:"html" example:"<p>Your buy order of 100.00 at 10.00 has been created.</p>"`
+	SMS     string      `json:"sms" example:"Buy order 100.00@10.00 created"`
+	Push    PushPayload `json:"push"`
+}
+
+type PushPayload struct {
+	Title string            `json:"title" example:"Order created"`
+	Body  string            `json:"body" example:"Buy 100.00 at 10.00"`
+	Data  map[string]string `json:"data"`
+}
+
+// NewFillSummary summarizes given trades, the average price is weighted by quantity
+func NewFillSummary(trades []*Trade) (*FillSummary, error) {
+	summary := &FillSummary{Trades: len(trades)}
+	var notional Decimal
+	for _, trade := range trades {
+		value, err := trade.Price.Mul(trade.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if notional, err = notional.Add(value); err != nil {
+			return nil, err
+		}
+		if summary.Quantity, err = summary.Quantity.Add(trade.Quantity); err != nil {
+			return nil, err
+		}
+	}
+	if summary.Quantity == 0 {
+		return summary, nil
+	}
+	averagePrice, err := notional.Div(summary.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	summary.AveragePrice = averagePrice
+	return summary, nil
+}
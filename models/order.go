@@ -11,6 +11,15 @@ const (
 	Sell OrderAction = "sell"
 )
 
+type OrderStatus string
+
+const (
+	OrderOpen        OrderStatus = "open"      // on the board
+	OrderPending     OrderStatus = "pending"   // stop orders waiting for the latest price to reach their stop price
+	OrderFullyFilled OrderStatus = "filled"    // off the board, nothing is left to fill
+	OrderCancelled   OrderStatus = "cancelled" // off the board, cancelled by its owner, an operator or its one-cancels-other group
+)
+
 type OrderBoardType string
 
 const (
@@ -21,15 +30,135 @@ const (
 
 type Order struct {
 	ID     string      `json:"id" db:"id" example:"uuid"`
+	UserID *string     `json:"-" db:"user_id"` // owner of the order, nil for orders created before ownership was recorded
 	Action OrderAction `json:"action" db:"action" example:"buy"`
-	// using int instead of float64 to avoid floating point precision issue
-	Price     int       `json:"price" db:"price" example:"10"`
-	Quantity  int       `json:"quantity" db:"quantity" example:"100"`
+	// using fixed-point Decimal instead of float64 to avoid floating point precision issue
+	Price     Decimal   `json:"price" db:"price" swaggertype:"string" example:"10.00"`
+	Quantity  Decimal   `json:"quantity" db:"quantity" swaggertype:"string" example:"100.00"` // the visible quantity of iceberg orders
 	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2021-01-01T00:00:00Z"`
+
+	// iceberg orders only show DisplayQuantity at a time, the hidden remainder replenishes
+	// the order once it is filled, taking new time priority
+	DisplayQuantity *Decimal `json:"-" db:"display_quantity"`
+	HiddenQuantity  Decimal  `json:"-" db:"hidden_quantity"`
+
+	Status OrderStatus `json:"status" db:"status" example:"open"`
+	// stop orders are pending until the latest price reaches StopPrice, when they are put on the board at Price
+	StopPrice *Decimal `json:"stop_price,omitempty" db:"stop_price" swaggertype:"string" example:"9.00"`
+	// orders of the same one-cancels-other group reduce each other when any of them is filled
+	OCOGroupID *string `json:"oco_group_id,omitempty" db:"oco_group_id" example:"uuid"`
+
+	// the stop price of trailing stop orders follows the latest price by TrailAmount or TrailPercent,
+	// and their limit price stays LimitOffset away from the stop price
+	TrailAmount  *Decimal `json:"trail_amount,omitempty" db:"trail_amount" swaggertype:"string" example:"1.00"`
+	TrailPercent *Decimal `json:"trail_percent,omitempty" db:"trail_percent" swaggertype:"string" example:"5.00"`
+	LimitOffset  Decimal  `json:"limit_offset,omitempty" db:"limit_offset" swaggertype:"string" example:"0.50"`
+
+	// when the order was filled or cancelled, nil while it is open or pending
+	ClosedAt *time.Time `json:"closed_at,omitempty" db:"closed_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// Trail moves the stop price of a pending trailing stop order towards given latest price, sell stops
+// only move up and buy stops only move down, rounded away from the latest price to a multiple of tickSize.
+// It returns false if the stop price is not moved.
+func (o *Order) Trail(latestPrice, tickSize Decimal) (bool, error) {
+	if o.Status != OrderPending || (o.TrailAmount == nil && o.TrailPercent == nil) {
+		return false, nil
+	}
+	var trail Decimal
+	if o.TrailAmount != nil {
+		trail = *o.TrailAmount
+	} else {
+		var err error
+		if trail, err = latestPrice.MulDiv(*o.TrailPercent, NewDecimal(100)); err != nil {
+			return false, err
+		}
+	}
+
+	var stop, price Decimal
+	if o.Action == Sell {
+		stop = latestPrice - trail
+		stop -= stop % tickSize
+		if o.StopPrice != nil && stop <= *o.StopPrice {
+			return false, nil
+		}
+		price = stop - o.LimitOffset
+	} else {
+		stop = latestPrice + trail
+		if r := stop % tickSize; r != 0 {
+			stop += tickSize - r
+		}
+		if o.StopPrice != nil && stop >= *o.StopPrice {
+			return false, nil
+		}
+		price = stop + o.LimitOffset
+	}
+	if stop <= 0 || price <= 0 {
+		return false, nil
+	}
+	o.StopPrice = &stop
+	o.Price = price
+	return true, nil
+}
+
+// StopTriggered tells if the stop price of a pending order is reached by given latest price,
+// sell stops are triggered by falling prices and buy stops by rising prices.
+func (o *Order) StopTriggered(latestPrice Decimal) bool {
+	if o.Status != OrderPending || o.StopPrice == nil {
+		return false
+	}
+	if o.Action == Sell {
+		return latestPrice <= *o.StopPrice
+	}
+	return latestPrice >= *o.StopPrice
+}
+
+// Replenish refills the visible quantity of a filled iceberg order from its hidden quantity,
+// it returns false if there is nothing left to show.
+func (o *Order) Replenish(now time.Time) bool {
+	if o.Quantity > 0 || o.DisplayQuantity == nil || o.HiddenQuantity <= 0 {
+		return false
+	}
+	o.Quantity = min(*o.DisplayQuantity, o.HiddenQuantity)
+	o.HiddenQuantity -= o.Quantity
+	o.CreatedAt = now
+	return true
+}
+
+// Requeue moves orders[i] behind the other orders at its price, orders are sorted by price then by
+// creation, so a replenished iceberg order goes to the tail of its price level.
+func Requeue(orders []*Order, i int) {
+	order := orders[i]
+	j := i
+	for j+1 < len(orders) && orders[j+1].Price == order.Price {
+		orders[j] = orders[j+1]
+		j++
+	}
+	orders[j] = order
 }
 
 type Board struct {
-	LatestPrice int      `json:"latest_price"`
-	BuyOrders   []*Order `json:"buy_orders"`
-	SellOrders  []*Order `json:"sell_orders"`
+	LatestPrice Decimal     `json:"latest_price" swaggertype:"string" example:"10.00"`
+	MarketState MarketState `json:"market_state" example:"open"`
+	// indicative price and volume during the call phase of an auction
+	Auction    *Auction `json:"auction,omitempty"`
+	BuyOrders  []*Order `json:"buy_orders"`
+	SellOrders []*Order `json:"sell_orders"`
+}
+
+// OrderFilter selects orders of all users, nil fields match any order
+type OrderFilter struct {
+	UserID *string
+	Action *OrderAction
+	Status *OrderStatus
+	// orders created at or after From and before To
+	From *time.Time
+	To   *time.Time
+}
+
+// UserActivity is what a user currently holds and recently did, for operators to review
+type UserActivity struct {
+	Position *Position `json:"position"`
+	Orders   []*Order  `json:"orders"` // most recent first, including filled and cancelled orders
+	Trades   []*Trade  `json:"trades"` // most recent first
 }
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxMessage is a message to be published to the message queue, it is written in the same
+// transaction as the change it describes and relayed to the message queue afterwards.
+type OutboxMessage struct {
+	ID int64 `json:"id" db:"id"`
+	// messages of the same aggregate are published in the order they are created
+	AggregateID string          `json:"aggregate_id" db:"aggregate_id"`
+	Topic       string          `json:"topic" db:"topic"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
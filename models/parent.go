@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+type ParentOrderStatus string
+
+const (
+	ParentActive    ParentOrderStatus = "active"    // sending child orders on schedule
+	ParentPaused    ParentOrderStatus = "paused"    // no child order is sent until resumed
+	ParentCancelled ParentOrderStatus = "cancelled" // no more child orders, the sent ones are kept
+	ParentCompleted ParentOrderStatus = "completed" // every slice is sent
+)
+
+// ParentOrder splits a large quantity into child orders sent evenly over [StartAt, EndAt] (TWAP),
+// child orders are takes, or limit orders at LimitPrice if it is given.
+type ParentOrder struct {
+	ID         string            `json:"id" db:"id" example:"uuid"`
+	UserID     string            `json:"-" db:"user_id"`
+	Action     OrderAction       `json:"action" db:"action" example:"buy"`
+	Quantity   Decimal           `json:"quantity" db:"quantity" swaggertype:"string" example:"1000.00"`
+	LimitPrice *Decimal          `json:"limit_price,omitempty" db:"limit_price" swaggertype:"string" example:"10.00"`
+	Slices     int               `json:"slices" db:"slices" example:"10"`
+	StartAt    time.Time         `json:"start_at" db:"start_at" example:"2021-01-01T09:00:00Z"`
+	EndAt      time.Time         `json:"end_at" db:"end_at" example:"2021-01-01T10:00:00Z"`
+	Status     ParentOrderStatus `json:"status" db:"status" example:"active"`
+
+	// progress, the quantity of a failed slice is spread over the remaining ones
+	SlicesSent   int        `json:"slices_sent" db:"slices_sent" example:"3"`
+	SentQuantity Decimal    `json:"sent_quantity" db:"sent_quantity" swaggertype:"string" example:"300.00"`
+	NextSliceAt  *time.Time `json:"next_slice_at,omitempty" db:"next_slice_at" example:"2021-01-01T09:18:00Z"` // nil once completed or cancelled
+	LastError    *string    `json:"last_error,omitempty" db:"last_error" example:"market closed"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at" example:"2021-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// SliceAt returns when the i-th slice is due, slices are spaced evenly with the first one at StartAt
+func (p *ParentOrder) SliceAt(i int) time.Time {
+	if p.Slices <= 1 {
+		return p.StartAt
+	}
+	return p.StartAt.Add(p.EndAt.Sub(p.StartAt) * time.Duration(i) / time.Duration(p.Slices-1))
+}
+
+// NextSliceQuantity returns the quantity of the next slice, the remaining quantity spread evenly
+// over the remaining slices in multiples of lotSize, the last slice takes whatever remains.
+func (p *ParentOrder) NextSliceQuantity(lotSize Decimal) Decimal {
+	remaining := p.Quantity - p.SentQuantity
+	slices := Decimal(p.Slices - p.SlicesSent)
+	if slices <= 1 {
+		return remaining
+	}
+	quantity := remaining / slices
+	return quantity - quantity%lotSize
+}
+
+// Sent records the result of the next slice and schedules the one after it, quantity is 0 if it failed
+func (p *ParentOrder) Sent(quantity Decimal, err error) {
+	p.SlicesSent++
+	p.SentQuantity += quantity
+	p.LastError = nil
+	if err != nil {
+		reason := err.Error()
+		p.LastError = &reason
+	}
+	if p.SlicesSent >= p.Slices {
+		p.Status = ParentCompleted
+		p.NextSliceAt = nil
+		return
+	}
+	next := p.SliceAt(p.SlicesSent)
+	p.NextSliceAt = &next
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+)
+
+// Trade records a single fill between a resting (maker) order and a taker
+type Trade struct {
+	ID           string      `json:"id" db:"id" example:"uuid"`
+	MakerOrderID string      `json:"maker_order_id" db:"maker_order_id" example:"uuid"`
+	MakerID      *string     `json:"maker_id" db:"maker_id" example:"uuid"`
+	TakerID      string      `json:"taker_id" db:"taker_id" example:"uuid"`
+	Action       OrderAction `json:"action" db:"action" example:"buy"` // action of the taker
+	Price        Decimal     `json:"price" db:"price" swaggertype:"string" example:"10.00"`
+	Quantity     Decimal     `json:"quantity" db:"quantity" swaggertype:"string" example:"100.00"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at" example:"2021-01-01T00:00:00Z"`
+	// executed by accepting a quote of an RFQ instead of on the board, MakerOrderID is then the ID of the quote
+	OffBook bool `json:"off_book" db:"off_book" example:"false"`
+}
+
+// TradeFilter narrows down the trades of a user
+type TradeFilter struct {
+	// trades taken or made by the user
+	UserID string
+	// trades executed at or after From and before To
+	From *time.Time
+	To   *time.Time
+}
+
+// Position is the net holding of a user, it is maintained incrementally from the user's trades
+type Position struct {
+	UserID string `json:"user_id" db:"user_id" example:"uuid"`
+	// positive for a long position, negative for a short one
+	Quantity Decimal `json:"quantity" db:"quantity" swaggertype:"string" example:"100.00"`
+	// total entry cost of the open quantity, i.e. average price * |quantity|
+	Cost          Decimal   `json:"-" db:"cost"`
+	AveragePrice  Decimal   `json:"average_price" db:"-" swaggertype:"string" example:"10.50"`
+	RealizedPnL   Decimal   `json:"realized_pnl" db:"realized_pnl" swaggertype:"string" example:"50.00"`
+	UnrealizedPnL Decimal   `json:"unrealized_pnl" db:"-" swaggertype:"string" example:"-20.00"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at" example:"2021-01-01T00:00:00Z"`
+}
+
+// Fill applies a trade of given action, price and quantity to the position,
+// realizing PnL on the part of quantity which closes the current position.
+// It returns ErrorWrongParams if any of the amounts overflows.
+func (p *Position) Fill(action OrderAction, price, quantity Decimal) error {
+	sign := Decimal(1)
+	if action == Sell {
+		sign = -1
+	}
+
+	var err error
+	if p.Quantity == 0 || (p.Quantity > 0) == (sign > 0) {
+		// opening or increasing the position
+		cost, err := price.Mul(quantity)
+		if err != nil {
+			return err
+		}
+		if p.Quantity, err = p.Quantity.Add(sign * quantity); err != nil {
+			return err
+		}
+		p.Cost, err = p.Cost.Add(cost)
+		return err
+	}
+
+	open, side := p.Quantity.Abs(), p.Quantity/p.Quantity.Abs()
+	closed := min(quantity, open)
+	closedCost := p.Cost
+	if closed < open {
+		if closedCost, err = p.Cost.MulDiv(closed, open); err != nil {
+			return err
+		}
+	}
+	proceeds, err := price.Mul(closed)
+	if err != nil {
+		return err
+	}
+	if p.RealizedPnL, err = p.RealizedPnL.Add(side * (proceeds - closedCost)); err != nil {
+		return err
+	}
+	p.Cost -= closedCost
+	p.Quantity += sign * closed
+
+	// the remaining quantity flips the position to the other side
+	if remaining := quantity - closed; remaining > 0 {
+		p.Quantity = sign * remaining
+		p.Cost, err = price.Mul(remaining)
+	}
+	return err
+}
+
+// Mark calculates the average entry price and the unrealized PnL of the position at given price
+func (p *Position) Mark(price Decimal) error {
+	p.AveragePrice, p.UnrealizedPnL = 0, 0
+	if p.Quantity == 0 {
+		return nil
+	}
+	open, side := p.Quantity.Abs(), p.Quantity/p.Quantity.Abs()
+
+	var err error
+	if p.AveragePrice, err = p.Cost.Div(open); err != nil {
+		return err
+	}
+	value, err := price.Mul(open)
+	if err != nil {
+		return err
+	}
+	p.UnrealizedPnL = side * (value - p.Cost)
+	return nil
+}
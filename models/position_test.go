@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionFill(t *testing.T) {
+	p := Position{}
+
+	// open a long position at average price 11
+	require.NoError(t, p.Fill(Buy, NewDecimal(10), NewDecimal(10)))
+	require.NoError(t, p.Fill(Buy, NewDecimal(12), NewDecimal(10)))
+	require.Equal(t, NewDecimal(20), p.Quantity, "expect quantity to be 20")
+	require.Equal(t, NewDecimal(220), p.Cost, "expect cost to be 220")
+
+	require.NoError(t, p.Mark(NewDecimal(15)))
+	require.Equal(t, NewDecimal(11), p.AveragePrice, "expect average price to be 11")
+	require.Equal(t, NewDecimal(80), p.UnrealizedPnL, "expect unrealized pnl to be 80")
+
+	// close half of it
+	require.NoError(t, p.Fill(Sell, NewDecimal(13), NewDecimal(10)))
+	require.Equal(t, NewDecimal(10), p.Quantity, "expect quantity to be 10")
+	require.Equal(t, NewDecimal(20), p.RealizedPnL, "expect realized pnl to be 20")
+
+	// flip to a short position
+	require.NoError(t, p.Fill(Sell, NewDecimal(9), NewDecimal(15)))
+	require.Equal(t, NewDecimal(-5), p.Quantity, "expect quantity to be -5")
+	require.Equal(t, NewDecimal(0), p.RealizedPnL, "expect realized pnl to be 0")
+	require.Equal(t, NewDecimal(45), p.Cost, "expect cost to be 45")
+
+	require.NoError(t, p.Mark(NewDecimal(7)))
+	require.Equal(t, NewDecimal(9), p.AveragePrice, "expect average price to be 9")
+	require.Equal(t, NewDecimal(10), p.UnrealizedPnL, "expect unrealized pnl to be 10")
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+type RFQStatus string
+
+const (
+	RFQOpen     RFQStatus = "open"     // collecting quotes until it expires
+	RFQAccepted RFQStatus = "accepted" // a quote is accepted and executed as an off-book trade
+	RFQExpired  RFQStatus = "expired"  // no quote was accepted in time
+)
+
+// RFQ is a request for quote of a block trade, it is broadcast to market makers instead of
+// hitting the board, and executed off-book at the price of the quote the requester accepts.
+type RFQ struct {
+	ID          string      `json:"id" db:"id" example:"uuid"`
+	RequesterID string      `json:"-" db:"requester_id"`
+	Action      OrderAction `json:"action" db:"action" example:"buy"` // action of the requester
+	Quantity    Decimal     `json:"quantity" db:"quantity" swaggertype:"string" example:"10000.00"`
+	Status      RFQStatus   `json:"status" db:"status" example:"open"`
+	ExpiresAt   time.Time   `json:"expires_at" db:"expires_at" example:"2021-01-01T00:01:00Z"`
+	TradeID     *string     `json:"trade_id,omitempty" db:"trade_id" example:"uuid"` // the off-book trade once accepted
+	CreatedAt   time.Time   `json:"created_at" db:"created_at" example:"2021-01-01T00:00:00Z"`
+	Quotes      []*Quote    `json:"quotes" db:"-"`
+}
+
+// Expire marks an open RFQ expired if it is past ExpiresAt at now, it returns false if it is no longer open
+func (r *RFQ) Expire(now time.Time) bool {
+	if r.Status == RFQOpen && !now.Before(r.ExpiresAt) {
+		r.Status = RFQExpired
+	}
+	return r.Status == RFQOpen
+}
+
+// Quote is the price a market maker offers to trade the quantity of an RFQ at, until ExpiresAt
+type Quote struct {
+	ID            string    `json:"id" db:"id" example:"uuid"`
+	RFQID         string    `json:"rfq_id" db:"rfq_id" example:"uuid"`
+	MarketMakerID string    `json:"market_maker_id" db:"market_maker_id" example:"uuid"`
+	Price         Decimal   `json:"price" db:"price" swaggertype:"string" example:"10.00"`
+	ExpiresAt     time.Time `json:"expires_at" db:"expires_at" example:"2021-01-01T00:00:10Z"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at" example:"2021-01-01T00:00:00Z"`
+}
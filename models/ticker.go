@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Ticker is the statistics of trades of an instrument on the board over the last 24 hours
+type Ticker struct {
+	Symbol string  `json:"symbol" db:"symbol" example:"AAPL"`
+	Open   Decimal `json:"open" db:"open" swaggertype:"string" example:"10.00"`
+	High   Decimal `json:"high" db:"high" swaggertype:"string" example:"10.80"`
+	Low    Decimal `json:"low" db:"low" swaggertype:"string" example:"9.90"`
+	Last   Decimal `json:"last" db:"last" swaggertype:"string" example:"10.50"`
+	Volume Decimal `json:"volume" db:"volume" swaggertype:"string" example:"12000.00"`
+	// volume weighted average price
+	VWAP Decimal `json:"vwap" db:"vwap" swaggertype:"string" example:"10.32"`
+	// change of Last from Open in percent
+	ChangePercent Decimal `json:"change_percent" db:"-" swaggertype:"string" example:"5.00"`
+	// start of the window, statistics are kept per minute
+	Since time.Time `json:"since" db:"-" example:"2021-01-01T00:00:00Z"`
+}
+
+// SetChange computes ChangePercent from Open and Last
+func (t *Ticker) SetChange() error {
+	if t.Open == 0 {
+		t.ChangePercent = 0
+		return nil
+	}
+	change, err := (t.Last - t.Open).MulDiv(NewDecimal(100), t.Open)
+	if err != nil {
+		return err
+	}
+	t.ChangePercent = change
+	return nil
+}
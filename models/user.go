@@ -10,6 +10,8 @@ type User struct {
 	Type      UserType  `json:"-" db:"type"`
 	PushToken *string   `json:"-" db:"push_token"`
 	Email     *string   `json:"email,omitempty" db:"email"`
+	Phone     *string   `json:"phone,omitempty" db:"phone"`
+	Locale    *string   `json:"locale,omitempty" db:"locale"`
 	CreatedAt time.Time `json:"-" db:"created_at"`
 	UpdatedAt time.Time `json:"-" db:"updated_at"`
 }
@@ -8,30 +8,33 @@ import (
 	"syscall"
 
 	"github.com/A-pen-app/kickstart/api"
+	"github.com/A-pen-app/kickstart/api/rpc"
 	"github.com/A-pen-app/kickstart/config"
 	"github.com/A-pen-app/kickstart/global"
 	"github.com/A-pen-app/logging"
 )
 
-// CreateServer creates an HTTP server listening on the specified address.
-func CreateServer(ctx context.Context, address string) *http.Server {
+// CreateServer creates an HTTP server listening on the specified address, and
+// the gRPC server to run next to it.
+func CreateServer(ctx context.Context, address string) (*http.Server, *rpc.Server) {
 
 	// Setup HTTP Server.
+	router, rpcServer := api.NewRouter(ctx)
 	server := &http.Server{
 		Addr:    address,
-		Handler: api.NewRouter(),
+		Handler: router,
 	}
 
 	// Install the shutdown handler.
-	installShutdownHandler(ctx, server)
+	installShutdownHandler(ctx, server, rpcServer)
 
-	return server
+	return server, rpcServer
 }
 
 // installShutdownHandler registers a shutdown handler for graceful shutdown.
-func installShutdownHandler(ctx context.Context, server *http.Server) {
+func installShutdownHandler(ctx context.Context, server *http.Server, rpcServer *rpc.Server) {
 	// Create signal channel & shutdown timeout context.
-	sigChan := make(chan os.Signal)
+	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	timeoutCtx, cancel := context.WithTimeout(ctx,
 		config.GetMilliseconds("SERVER_SHUTDOWN_GRACE_PERIOD_MS"))
@@ -48,8 +51,20 @@ func installShutdownHandler(ctx context.Context, server *http.Server) {
 		// Perform graceful shutdown.
 		logging.Warn(ctx, "Initiating graceful shutdown...")
 		global.Alive = false
+		rpcStopped := make(chan struct{})
+		go func() {
+			defer close(rpcStopped)
+			rpcServer.GracefulStop()
+		}()
 		if err := server.Shutdown(timeoutCtx); err != nil {
 			logging.Error(ctx, "Failed to shutdown: %s", err.Error())
 		}
+
+		// Cut off the calls still being served once the grace period is over.
+		select {
+		case <-rpcStopped:
+		case <-timeoutCtx.Done():
+			rpcServer.Stop()
+		}
 	}()
 }
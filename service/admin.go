@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+)
+
+// ADMIN_ACTIVITY_LIMIT is the number of orders and trades shown in the activity of a user
+const ADMIN_ACTIVITY_LIMIT = 100
+
+type adminSvc struct {
+	c store.Order
+	p store.Position
+	a Audit
+	o Order
+}
+
+// NewAdmin returns an implementation of service.Admin changing the board through o,
+// every action is recorded to the audit trail by a.
+func NewAdmin(c store.Order, p store.Position, a Audit, o Order) Admin {
+	return &adminSvc{
+		c: c,
+		p: p,
+		a: a,
+		o: o,
+	}
+}
+
+func (s *adminSvc) ListOrders(ctx context.Context, actorID string, filter *models.OrderFilter, next string, count int) ([]*models.Order, string, error) {
+	// actions which are not recorded must not be taken
+	if err := s.a.Record(ctx, actorID, models.AuditAdminListOrders, "orders", map[string]interface{}{
+		"user_id": filter.UserID,
+		"action":  filter.Action,
+		"status":  filter.Status,
+		"next":    next,
+	}); err != nil {
+		return nil, "", err
+	}
+	orders, next, err := s.c.List(ctx, filter, next, count)
+	if err != nil {
+		logging.Errorw(ctx, "service list orders failed", "err", err)
+		return nil, "", err
+	}
+	return orders, next, nil
+}
+
+func (s *adminSvc) CancelOrder(ctx context.Context, actorID, orderID, reason string) error {
+	order, err := s.c.Get(ctx, orderID)
+	if err != nil {
+		logging.Errorw(ctx, "service get order to cancel failed", "err", err, "orderID", orderID)
+		return err
+	}
+	entry, err := newAuditEntry(ctx, actorID, models.AuditAdminCancelOrder, orderID, map[string]interface{}{
+		"reason":  reason,
+		"user_id": order.UserID,
+	})
+	if err != nil {
+		logging.Errorw(ctx, "service build audit entry failed", "err", err, "action", models.AuditAdminCancelOrder)
+		return err
+	}
+	if err := s.o.ForceDelete(ctx, orderID, entry); err != nil {
+		logging.Errorw(ctx, "service cancel order failed", "err", err, "orderID", orderID)
+		return err
+	}
+	logging.Infow(ctx, "order cancelled by operator", "orderID", orderID, "actorID", actorID, "reason", reason)
+	return nil
+}
+
+func (s *adminSvc) CancelUserOrders(ctx context.Context, actorID, userID, reason string) (int, error) {
+	entry, err := newAuditEntry(ctx, actorID, models.AuditAdminCancelUser, userID, map[string]interface{}{
+		"reason": reason,
+	})
+	if err != nil {
+		logging.Errorw(ctx, "service build audit entry failed", "err", err, "action", models.AuditAdminCancelUser)
+		return 0, err
+	}
+	cancelled, err := s.o.ForceDeleteUser(ctx, userID, entry)
+	if err != nil {
+		logging.Errorw(ctx, "service cancel orders of user failed", "err", err, "userID", userID)
+		return 0, err
+	}
+	logging.Infow(ctx, "orders of user cancelled by operator", "userID", userID, "actorID", actorID, "reason", reason, "cancelled", cancelled)
+	return cancelled, nil
+}
+
+func (s *adminSvc) GetUserActivity(ctx context.Context, actorID, userID string) (*models.UserActivity, error) {
+	if err := s.a.Record(ctx, actorID, models.AuditAdminViewActivity, userID, nil); err != nil {
+		return nil, err
+	}
+	position, err := s.o.GetPosition(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	orders, _, err := s.c.List(ctx, &models.OrderFilter{UserID: &userID}, "", ADMIN_ACTIVITY_LIMIT)
+	if err != nil {
+		logging.Errorw(ctx, "service list orders of user failed", "err", err, "userID", userID)
+		return nil, err
+	}
+	trades, err := s.p.GetTrades(ctx, userID, ADMIN_ACTIVITY_LIMIT)
+	if err != nil {
+		logging.Errorw(ctx, "service get trades of user failed", "err", err, "userID", userID)
+		return nil, err
+	}
+	return &models.UserActivity{
+		Position: position,
+		Orders:   orders,
+		Trades:   trades,
+	}, nil
+}
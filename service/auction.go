@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+)
+
+func (s *orderSvc) Uncross(ctx context.Context) (*models.Auction, error) {
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	// orders keep accumulating during the call phase, and nothing matches while halted
+	if state := instrument.State(s.now()); state == models.MarketAuction || state == models.MarketHalted {
+		return nil, nil
+	}
+
+	// the board only crosses after a call phase, check it before locking the board
+	bestBuy, err := s.c.GetBestPrice(ctx, models.Buy)
+	if err != nil {
+		logging.Errorw(ctx, "service get best price failed", "err", err, "action", models.Buy)
+		return nil, err
+	}
+	bestSell, err := s.c.GetBestPrice(ctx, models.Sell)
+	if err != nil {
+		logging.Errorw(ctx, "service get best price failed", "err", err, "action", models.Sell)
+		return nil, err
+	}
+	if bestBuy == nil || bestSell == nil || *bestBuy < *bestSell {
+		return nil, nil
+	}
+
+	// the fills are only known within the transaction executing the trades, each buyer and each seller
+	// is notified of its own fills
+	outbox := func(trades []*models.Trade) ([]*models.OutboxMessage, error) {
+		buyers, err := s.fillNotifications(ctx, trades, models.Buy, takerOf)
+		if err != nil {
+			return nil, err
+		}
+		sellers, err := s.fillNotifications(ctx, trades, models.Sell, makerOf)
+		if err != nil {
+			return nil, err
+		}
+		return append(buyers, sellers...), nil
+	}
+
+	s.BoardGuard.Lock()
+	defer s.BoardGuard.Unlock()
+	auction, err := s.c.Uncross(ctx, DEFAULT_PRICE, outbox)
+	if err != nil {
+		logging.Errorw(ctx, "service uncross orders failed", "err", err)
+		return nil, err
+	}
+	if auction.Price == nil {
+		return nil, nil
+	}
+	// also covers the stop orders put on the board below
+	defer s.invalidateBoard(ctx)
+	invalidateTickers(ctx)
+	logging.Infow(ctx, "board uncrossed", "price", auction.Price.String(), "volume", auction.Volume.String(), "imbalance", auction.Imbalance.String())
+	s.checkVolatility(ctx, instrument, *auction.Price)
+	if err := s.updateStops(ctx, instrument, *auction.Price); err != nil {
+		return nil, err
+	}
+	return auction, nil
+}
+
+func (s *orderSvc) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.uncrossInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.Uncross(ctx); err != nil {
+			logging.Errorw(ctx, "uncross board failed", "err", err)
+		}
+	}
+}
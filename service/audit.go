@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AUDIT_VERIFY_BATCH is the number of entries read at a time when verifying the audit trail
+const AUDIT_VERIFY_BATCH = 1000
+
+type auditSvc struct {
+	a store.Audit
+}
+
+// NewAudit returns an implementation of service.Audit
+func NewAudit(a store.Audit) Audit {
+	return &auditSvc{
+		a: a,
+	}
+}
+
+// newAuditEntry returns an entry recording action taken by actor on target, detail is encoded as JSON.
+// The request ID, IP and payload hash are taken from the request carried by ctx, if any.
+func newAuditEntry(ctx context.Context, actorID string, action models.AuditAction, target string, detail interface{}) (*models.AuditEntry, error) {
+	entry := &models.AuditEntry{
+		ActorID: actorID,
+		Action:  action,
+		Target:  target,
+	}
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		entry.RequestID = spanCtx.TraceID().String()
+	}
+	if ip, ok := ctx.Value("client_ip").(string); ok {
+		entry.IP = ip
+	}
+	// the hash of a streamed payload is only known once it is read
+	switch payloadHash := ctx.Value("payload_hash").(type) {
+	case string:
+		entry.PayloadHash = payloadHash
+	case fmt.Stringer:
+		entry.PayloadHash = payloadHash.String()
+	}
+	if detail != nil {
+		b, err := json.Marshal(detail)
+		if err != nil {
+			return nil, err
+		}
+		entry.Detail = b
+	}
+	return entry, nil
+}
+
+func (s *auditSvc) Record(ctx context.Context, actorID string, action models.AuditAction, target string, detail interface{}) error {
+	entry, err := newAuditEntry(ctx, actorID, action, target, detail)
+	if err != nil {
+		logging.Errorw(ctx, "service build audit entry failed", "err", err, "action", action)
+		return err
+	}
+	if err := s.a.Record(ctx, entry); err != nil {
+		logging.Errorw(ctx, "service record audit entry failed", "err", err, "action", action)
+		return err
+	}
+	return nil
+}
+
+func (s *auditSvc) List(ctx context.Context, actorID string, filter *models.AuditFilter, next string, count int) ([]*models.AuditEntry, string, error) {
+	// reading the audit trail is an admin action as well
+	if err := s.Record(ctx, actorID, models.AuditAdminListAudit, "audit", map[string]interface{}{
+		"actor_id": filter.ActorID,
+		"action":   filter.Action,
+		"target":   filter.Target,
+		"from":     filter.From,
+		"to":       filter.To,
+		"next":     next,
+	}); err != nil {
+		return nil, "", err
+	}
+	entries, next, err := s.a.List(ctx, filter, next, count)
+	if err != nil {
+		logging.Errorw(ctx, "service list audit entries failed", "err", err)
+		return nil, "", err
+	}
+	return entries, next, nil
+}
+
+func (s *auditSvc) Verify(ctx context.Context) (*models.AuditVerification, error) {
+	result := &models.AuditVerification{
+		Valid: true,
+	}
+	var afterID int64
+	for {
+		entries, err := s.a.Scan(ctx, afterID, AUDIT_VERIFY_BATCH)
+		if err != nil {
+			logging.Errorw(ctx, "service scan audit entries failed", "err", err, "afterID", afterID)
+			return nil, err
+		}
+		for _, entry := range entries {
+			result.Checked++
+			afterID = entry.ID
+			// entries recorded before the trail was chained can only precede the chain
+			if entry.Hash == "" && result.Head == "" {
+				result.Unchained++
+				continue
+			}
+			hash, err := entry.Digest()
+			if err != nil || entry.PrevHash != result.Head || entry.Hash != hash {
+				id := entry.ID
+				result.Valid = false
+				result.BrokenID = &id
+				logging.Errorw(ctx, "audit trail chain is broken", "id", id)
+				return result, nil
+			}
+			result.Head = entry.Hash
+		}
+		if len(entries) < AUDIT_VERIFY_BATCH {
+			return result, nil
+		}
+	}
+}
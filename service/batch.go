@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+)
+
+func (s *orderSvc) Batch(ctx context.Context, userID string, operations []*models.BatchOperation, atomic bool) ([]*models.BatchResult, error) {
+	if len(operations) == 0 || len(operations) > s.maxBatchSize {
+		return nil, &models.FieldError{
+			Field:  "operations",
+			Reason: fmt.Sprintf("must have 1 to %d operations", s.maxBatchSize),
+		}
+	}
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+
+	// the whole batch is checked against the board as it is, so no other change interleaves
+	s.BoardGuard.Lock()
+	defer s.BoardGuard.Unlock()
+	results := make([]*models.BatchResult, len(operations))
+	done := false
+	defer func() {
+		if done {
+			s.invalidateBoard(ctx)
+		}
+	}()
+
+	if !atomic {
+		// every operation is executed on its own before the next one is checked
+		for i, operation := range operations {
+			item, err := s.prepareBatchItem(ctx, instrument, userID, operation, nil)
+			if err == nil {
+				err = s.c.Batch(ctx, userID, []*models.BatchItem{item})
+			}
+			results[i] = newBatchResult(operation, item, err)
+			done = done || err == nil
+		}
+		return results, nil
+	}
+
+	// makes are checked against the board before the batch, and against the other makes of the batch
+	// so the batch never crosses itself
+	items := make([]*models.BatchItem, len(operations))
+	makes := []*models.Order{}
+	failed := -1
+	for i, operation := range operations {
+		item, err := s.prepareBatchItem(ctx, instrument, userID, operation, makes)
+		if err != nil {
+			results[i] = newBatchResult(operation, item, err)
+			failed = i
+			break
+		}
+		if item.Order != nil {
+			makes = append(makes, item.Order)
+		}
+		items[i] = item
+	}
+	if failed < 0 {
+		err := s.c.Batch(ctx, userID, items)
+		batchErr := &models.BatchError{}
+		switch {
+		case err == nil:
+			for i, operation := range operations {
+				results[i] = newBatchResult(operation, items[i], nil)
+			}
+			done = true
+			return results, nil
+		case errors.As(err, &batchErr):
+			failed = batchErr.Index
+			results[failed] = newBatchResult(operations[failed], items[failed], batchErr.Err)
+		default:
+			logging.Errorw(ctx, "service execute batch failed", "err", err)
+			return nil, err
+		}
+	}
+	for i := range results {
+		if i != failed {
+			results[i] = &models.BatchResult{Status: models.BatchAborted}
+		}
+	}
+	return results, nil
+}
+
+// prepareBatchItem checks an operation of a batch and returns it ready to be executed, makes are also
+// checked not to cross pending, the orders made earlier in the same batch
+func (s *orderSvc) prepareBatchItem(ctx context.Context, instrument *models.Instrument, userID string, operation *models.BatchOperation, pending []*models.Order) (*models.BatchItem, error) {
+	switch operation.Type {
+	case models.BatchCancel:
+		return &models.BatchItem{OrderID: operation.OrderID}, nil
+	case models.BatchMake:
+	default:
+		return nil, &models.FieldError{Field: "type", Reason: fmt.Sprintf("unexpected operation type %s", operation.Type)}
+	}
+
+	opt := makeOption{
+		displayQuantity: operation.DisplayQuantity,
+		postOnly:        operation.PostOnly,
+	}
+	order, messages, err := s.prepareMake(ctx, instrument, userID, operation.Action, operation.Price, operation.Quantity, opt)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pending {
+		if p.Action != order.Action &&
+			((order.Action == models.Buy && order.Price >= p.Price) || (order.Action == models.Sell && order.Price <= p.Price)) {
+			return nil, &models.FieldError{
+				Field:  "price",
+				Reason: fmt.Sprintf("would match order %s made earlier in the batch", p.ID),
+			}
+		}
+	}
+	return &models.BatchItem{Order: order, Messages: messages}, nil
+}
+
+// newBatchResult returns the result of an operation executed as item, or failed by err
+func newBatchResult(operation *models.BatchOperation, item *models.BatchItem, err error) *models.BatchResult {
+	result := &models.BatchResult{
+		Status:  models.BatchDone,
+		OrderID: operation.OrderID,
+	}
+	if item != nil && item.Order != nil {
+		result.OrderID = item.Order.ID
+	}
+	if err != nil {
+		result.Status = models.BatchFailed
+		result.Error = err.Error()
+		var fieldErr *models.FieldError
+		if errors.As(err, &fieldErr) {
+			result.Field = fieldErr.Field
+		}
+		var batchErr *models.BatchError
+		if errors.As(err, &batchErr) {
+			result.Error = batchErr.Err.Error()
+		}
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+)
+
+// EXPORT_PAGE_SIZE is the number of orders or trades read from the store at a time when exporting
+const EXPORT_PAGE_SIZE = 500
+
+func (s *orderSvc) ExportHistory(ctx context.Context, userID string, from, to *time.Time, write func([]*models.HistoryRecord) error) error {
+	if from != nil && to != nil && !from.Before(*to) {
+		logging.Errorw(ctx, "service export history rejected by empty period", "from", from, "to", to)
+		return models.ErrorWrongParams
+	}
+
+	// every order whether open, pending, filled or cancelled first, then every fill, each most recent first
+	orderFilter := &models.OrderFilter{UserID: &userID, From: from, To: to}
+	for next := ""; ; {
+		orders, n, err := s.c.List(ctx, orderFilter, next, EXPORT_PAGE_SIZE)
+		if err != nil {
+			logging.Errorw(ctx, "service list orders to export failed", "err", err, "userID", userID)
+			return err
+		}
+		records := make([]*models.HistoryRecord, 0, len(orders))
+		for _, order := range orders {
+			records = append(records, models.NewOrderRecord(order))
+		}
+		if len(records) > 0 {
+			if err := write(records); err != nil {
+				return err
+			}
+		}
+		if next = n; next == "" {
+			break
+		}
+	}
+
+	tradeFilter := &models.TradeFilter{UserID: userID, From: from, To: to}
+	for next := ""; ; {
+		trades, n, err := s.p.ListTrades(ctx, tradeFilter, next, EXPORT_PAGE_SIZE)
+		if err != nil {
+			logging.Errorw(ctx, "service list trades to export failed", "err", err, "userID", userID)
+			return err
+		}
+		records := make([]*models.HistoryRecord, 0, len(trades))
+		for _, trade := range trades {
+			records = append(records, models.NewFillRecord(trade, userID))
+		}
+		if len(records) > 0 {
+			if err := write(records); err != nil {
+				return err
+			}
+		}
+		if next = n; next == "" {
+			return nil
+		}
+	}
+}
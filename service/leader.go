@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+	"github.com/google/uuid"
+)
+
+type leaderSvc struct {
+	l       store.Lease
+	name    string
+	holder  string
+	address string
+	ttl     time.Duration
+	now     func() time.Time
+	// run only while holding the lease, e.g. the auction uncrossing and the parent order dispatching
+	workers []func(context.Context)
+
+	mu sync.RWMutex
+	// the lease as of the last campaign
+	lease *models.Lease
+	// when the lease as of the last campaign expires by the local clock, whoever holds it
+	expiresAt time.Time
+}
+
+// NewLeader returns an implementation of service.Leader campaigning for the matcher of the configured
+// instrument, other processes reach this one at MATCHER_ADDRESS while it leads.
+func NewLeader(l store.Lease, workers ...func(context.Context)) Leader {
+	host, _ := os.Hostname()
+	return &leaderSvc{
+		l:       l,
+		name:    fmt.Sprintf("matcher.%s", config.GetString("INSTRUMENT")),
+		holder:  fmt.Sprintf("%s.%s", host, uuid.NewString()),
+		address: config.GetString("MATCHER_ADDRESS"),
+		ttl:     config.GetMilliseconds("MATCHER_LEASE_TTL_MS"),
+		now:     time.Now,
+		workers: workers,
+	}
+}
+
+func (s *leaderSvc) Run(ctx context.Context) {
+	// renew well before the lease expires, so a slow round trip does not lose it
+	ticker := time.NewTicker(s.ttl / 3)
+	defer ticker.Stop()
+
+	w := sync.WaitGroup{}
+	var stop context.CancelFunc
+	stopWorkers := func() {
+		if stop == nil {
+			return
+		}
+		stop()
+		w.Wait()
+		stop = nil
+	}
+	defer func() {
+		stopWorkers()
+		if s.IsLeader() {
+			// ctx is done, hand over without waiting for the lease to expire
+			if err := s.l.Release(context.Background(), s.name, s.holder); err != nil {
+				logging.Errorw(ctx, "release matcher lease failed", "err", err, "name", s.name)
+			}
+		}
+	}()
+
+	for {
+		leading := s.campaign(ctx)
+		if leading && stop == nil {
+			logging.Infow(ctx, "elected as matcher", "name", s.name, "holder", s.holder)
+			workCtx, cancel := context.WithCancel(ctx)
+			stop = cancel
+			for _, worker := range s.workers {
+				w.Add(1)
+				go func(worker func(context.Context)) {
+					defer w.Done()
+					worker(workCtx)
+				}(worker)
+			}
+		} else if !leading && stop != nil {
+			// another process may already lead, its workers are serialized with ours by the book lock until ours stop
+			logging.Infow(ctx, "lost matcher lease", "name", s.name, "holder", s.holder)
+			stopWorkers()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign takes or renews the lease and reports whether this process holds it, the lease is kept
+// until it expires by the local clock when the store cannot be reached.
+func (s *leaderSvc) campaign(ctx context.Context) bool {
+	// counted from before the round trip, so the local expiry never outlives the one in the store
+	start := s.now()
+	lease, err := s.l.Acquire(ctx, s.name, s.holder, s.address, s.ttl)
+	if err != nil {
+		logging.Errorw(ctx, "acquire matcher lease failed", "err", err, "name", s.name)
+		return s.IsLeader()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lease = lease
+	// the lease is renewed before start by whoever holds it, or else it would have been taken over
+	s.expiresAt = start.Add(s.ttl)
+	return s.leading()
+}
+
+func (s *leaderSvc) IsLeader() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.leading()
+}
+
+// leading reports whether this process holds an unexpired lease, it should be called with mu held
+func (s *leaderSvc) leading() bool {
+	return s.lease != nil && s.lease.Holder == s.holder && s.now().Before(s.expiresAt)
+}
+
+func (s *leaderSvc) Leader(ctx context.Context) (*models.Lease, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.lease == nil || !s.now().Before(s.expiresAt) {
+		logging.Errorw(ctx, "service matcher leader unknown", "name", s.name)
+		return nil, models.ErrorNoLeader
+	}
+	return s.lease, nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/A-pen-app/cache"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+)
+
+// TICKER_WINDOW is the period covered by tickers, within the statistics kept by store.Ticker
+const TICKER_WINDOW = 24 * time.Hour
+
+// TICKER_CACHE_KEY caches the tickers of all instruments, it is dropped whenever trades are executed
+// on the board
+const TICKER_CACHE_KEY = "get_tickers"
+
+type marketSvc struct {
+	i store.Instrument
+	t store.Ticker
+}
+
+// NewMarket returns an implementation of service.Market
+func NewMarket(i store.Instrument, t store.Ticker) Market {
+	return &marketSvc{
+		i: i,
+		t: t,
+	}
+}
+
+func (s *marketSvc) GetInstrument(ctx context.Context, symbol string) (*models.Instrument, models.MarketState, error) {
+	instrument, err := s.i.Get(ctx, symbol)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", symbol)
+		return nil, "", err
+	}
+	return instrument, instrument.State(time.Now()), nil
+}
+
+func (s *marketSvc) GetTickers(ctx context.Context) ([]*models.Ticker, error) {
+	tickers := []*models.Ticker{}
+	if err := cache.Get(ctx, TICKER_CACHE_KEY, &tickers); err == nil {
+		return tickers, nil
+	}
+
+	since := time.Now().Add(-TICKER_WINDOW).Truncate(time.Minute)
+	tickers, err := s.t.List(ctx, since)
+	if err != nil {
+		logging.Errorw(ctx, "service list tickers failed", "err", err)
+		return nil, err
+	}
+	for _, ticker := range tickers {
+		ticker.Since = since
+		if err := ticker.SetChange(); err != nil {
+			logging.Errorw(ctx, "service compute ticker change failed", "err", err, "symbol", ticker.Symbol)
+			return nil, err
+		}
+	}
+	// the window moves on without trades, so tickers are only cached for a while
+	if err := cache.SetWithTTL(ctx, TICKER_CACHE_KEY, tickers, time.Minute); err != nil {
+		logging.Errorw(ctx, "cache tickers failed", "err", err)
+	}
+	return tickers, nil
+}
+
+// invalidateTickers drops the cached tickers after trades are committed
+func invalidateTickers(ctx context.Context) {
+	if err := cache.Delete(ctx, TICKER_CACHE_KEY); err != nil {
+		// the trades are committed anyway, the tickers are at worst stale until the cache entry expires
+		logging.Errorw(ctx, "invalidate ticker cache failed", "err", err)
+	}
+}
+
+func (s *marketSvc) Halt(ctx context.Context, symbol, reason string) error {
+	if err := s.i.Halt(ctx, symbol, reason, 0); err != nil {
+		logging.Errorw(ctx, "service halt instrument failed", "err", err, "symbol", symbol)
+		return err
+	}
+	logging.Infow(ctx, "instrument halted", "symbol", symbol, "reason", reason)
+	return nil
+}
+
+func (s *marketSvc) Resume(ctx context.Context, symbol string) error {
+	if err := s.i.Resume(ctx, symbol); err != nil {
+		logging.Errorw(ctx, "service resume instrument failed", "err", err, "symbol", symbol)
+		return err
+	}
+	logging.Infow(ctx, "instrument resumed", "symbol", symbol)
+	return nil
+}
+
+func (s *marketSvc) SetTimeZone(ctx context.Context, symbol, timeZone string) error {
+	if _, err := models.LoadTimeZone(timeZone); err != nil {
+		return &models.FieldError{
+			Field:  "time_zone",
+			Reason: fmt.Sprintf("should be an IANA time zone name, got %q", timeZone),
+		}
+	}
+	if err := s.i.SetTimeZone(ctx, symbol, timeZone); err != nil {
+		logging.Errorw(ctx, "service set time zone of instrument failed", "err", err, "symbol", symbol)
+		return err
+	}
+	logging.Infow(ctx, "instrument time zone set", "symbol", symbol, "timeZone", timeZone)
+	return nil
+}
+
+// checkMarket verifies if orders of the instrument can be made or taken at now
+func checkMarket(instrument *models.Instrument, now time.Time) error {
+	switch instrument.State(now) {
+	case models.MarketHalted:
+		return models.ErrorMarketHalted
+	case models.MarketClosed:
+		return models.ErrorMarketClosed
+	}
+	return nil
+}
+
+// checkVolatility halts the instrument if the latest price moved more than the configured percentage
+// from any price traded on the board within the configured window. Prices traded before the last halt
+// ended are left out, so the window starts over once trading resumes, whether the halt expired or an
+// operator resumed trading.
+func (s *orderSvc) checkVolatility(ctx context.Context, instrument *models.Instrument, price models.Decimal) {
+	if instrument.VolatilityPercent <= 0 || instrument.VolatilityWindowMinutes <= 0 {
+		return
+	}
+	since := s.now().Add(-time.Duration(instrument.VolatilityWindowMinutes) * time.Minute)
+	for _, start := range []*time.Time{instrument.HaltUntil, instrument.ResumedAt} {
+		if start != nil && start.After(since) {
+			since = *start
+		}
+	}
+	// the prices traded by every replica, including the latest one
+	low, high, err := s.c.GetPriceRange(ctx, since)
+	if err != nil {
+		logging.Errorw(ctx, "service get price range failed", "err", err, "since", since)
+		return
+	}
+
+	for _, p := range []*models.Decimal{low, high} {
+		if p == nil {
+			continue
+		}
+		move, err := (price - *p).Abs().MulDiv(models.NewDecimal(100), *p)
+		if err != nil || move <= models.NewDecimal(int64(instrument.VolatilityPercent)) {
+			continue
+		}
+		reason := fmt.Sprintf("price moved %s%% from %s to %s within %d minutes", move, *p, price, instrument.VolatilityWindowMinutes)
+		if err := s.i.Halt(ctx, instrument.Symbol, reason, instrument.HaltMinutes); err != nil {
+			logging.Errorw(ctx, "service halt instrument by circuit breaker failed", "err", err, "symbol", instrument.Symbol)
+			return
+		}
+		logging.Warn(ctx, "instrument %s halted by circuit breaker: %s", instrument.Symbol, reason)
+		return
+	}
+}
@@ -0,0 +1,142 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAdmin is an autogenerated mock type for the Admin type
+type MockAdmin struct {
+	mock.Mock
+}
+
+// CancelOrder provides a mock function with given fields: ctx, actorID, orderID, reason
+func (_m *MockAdmin) CancelOrder(ctx context.Context, actorID string, orderID string, reason string) error {
+	ret := _m.Called(ctx, actorID, orderID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, actorID, orderID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelUserOrders provides a mock function with given fields: ctx, actorID, userID, reason
+func (_m *MockAdmin) CancelUserOrders(ctx context.Context, actorID string, userID string, reason string) (int, error) {
+	ret := _m.Called(ctx, actorID, userID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelUserOrders")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (int, error)); ok {
+		return rf(ctx, actorID, userID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) int); ok {
+		r0 = rf(ctx, actorID, userID, reason)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, actorID, userID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserActivity provides a mock function with given fields: ctx, actorID, userID
+func (_m *MockAdmin) GetUserActivity(ctx context.Context, actorID string, userID string) (*models.UserActivity, error) {
+	ret := _m.Called(ctx, actorID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserActivity")
+	}
+
+	var r0 *models.UserActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.UserActivity, error)); ok {
+		return rf(ctx, actorID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.UserActivity); ok {
+		r0 = rf(ctx, actorID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, actorID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOrders provides a mock function with given fields: ctx, actorID, filter, next, count
+func (_m *MockAdmin) ListOrders(ctx context.Context, actorID string, filter *models.OrderFilter, next string, count int) ([]*models.Order, string, error) {
+	ret := _m.Called(ctx, actorID, filter, next, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrders")
+	}
+
+	var r0 []*models.Order
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.OrderFilter, string, int) ([]*models.Order, string, error)); ok {
+		return rf(ctx, actorID, filter, next, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.OrderFilter, string, int) []*models.Order); ok {
+		r0 = rf(ctx, actorID, filter, next, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.OrderFilter, string, int) string); ok {
+		r1 = rf(ctx, actorID, filter, next, count)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *models.OrderFilter, string, int) error); ok {
+		r2 = rf(ctx, actorID, filter, next, count)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewMockAdmin creates a new instance of MockAdmin. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAdmin(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAdmin {
+	mock := &MockAdmin{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLeader is an autogenerated mock type for the Leader type
+type MockLeader struct {
+	mock.Mock
+}
+
+// IsLeader provides a mock function with given fields:
+func (_m *MockLeader) IsLeader() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLeader")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Leader provides a mock function with given fields: ctx
+func (_m *MockLeader) Leader(ctx context.Context) (*models.Lease, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Leader")
+	}
+
+	var r0 *models.Lease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.Lease, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.Lease); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Lease)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *MockLeader) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// NewMockLeader creates a new instance of MockLeader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLeader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLeader {
+	mock := &MockLeader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
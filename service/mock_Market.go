@@ -0,0 +1,150 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockMarket is an autogenerated mock type for the Market type
+type MockMarket struct {
+	mock.Mock
+}
+
+// GetInstrument provides a mock function with given fields: ctx, symbol
+func (_m *MockMarket) GetInstrument(ctx context.Context, symbol string) (*models.Instrument, models.MarketState, error) {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInstrument")
+	}
+
+	var r0 *models.Instrument
+	var r1 models.MarketState
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Instrument, models.MarketState, error)); ok {
+		return rf(ctx, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Instrument); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Instrument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) models.MarketState); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Get(1).(models.MarketState)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, symbol)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTickers provides a mock function with given fields: ctx
+func (_m *MockMarket) GetTickers(ctx context.Context) ([]*models.Ticker, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTickers")
+	}
+
+	var r0 []*models.Ticker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Ticker, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Ticker); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Ticker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Halt provides a mock function with given fields: ctx, symbol, reason
+func (_m *MockMarket) Halt(ctx context.Context, symbol string, reason string) error {
+	ret := _m.Called(ctx, symbol, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Halt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, symbol, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Resume provides a mock function with given fields: ctx, symbol
+func (_m *MockMarket) Resume(ctx context.Context, symbol string) error {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTimeZone provides a mock function with given fields: ctx, symbol, timeZone
+func (_m *MockMarket) SetTimeZone(ctx context.Context, symbol string, timeZone string) error {
+	ret := _m.Called(ctx, symbol, timeZone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimeZone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, symbol, timeZone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockMarket creates a new instance of MockMarket. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMarket(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMarket {
+	mock := &MockMarket{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 
 	models "github.com/A-pen-app/kickstart/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockOrder is an autogenerated mock type for the Order type
@@ -14,17 +16,47 @@ type MockOrder struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: ctx, orderID
-func (_m *MockOrder) Delete(ctx context.Context, orderID string) error {
-	ret := _m.Called(ctx, orderID)
+// Batch provides a mock function with given fields: ctx, userID, operations, atomic
+func (_m *MockOrder) Batch(ctx context.Context, userID string, operations []*models.BatchOperation, atomic bool) ([]*models.BatchResult, error) {
+	ret := _m.Called(ctx, userID, operations, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 []*models.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*models.BatchOperation, bool) ([]*models.BatchResult, error)); ok {
+		return rf(ctx, userID, operations, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*models.BatchOperation, bool) []*models.BatchResult); ok {
+		r0 = rf(ctx, userID, operations, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []*models.BatchOperation, bool) error); ok {
+		r1 = rf(ctx, userID, operations, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, orderID
+func (_m *MockOrder) Delete(ctx context.Context, userID string, orderID string) error {
+	ret := _m.Called(ctx, userID, orderID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, orderID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,6 +64,70 @@ func (_m *MockOrder) Delete(ctx context.Context, orderID string) error {
 	return r0
 }
 
+// ExportHistory provides a mock function with given fields: ctx, userID, from, to, write
+func (_m *MockOrder) ExportHistory(ctx context.Context, userID string, from *time.Time, to *time.Time, write func([]*models.HistoryRecord) error) error {
+	ret := _m.Called(ctx, userID, from, to, write)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time, func([]*models.HistoryRecord) error) error); ok {
+		r0 = rf(ctx, userID, from, to, write)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForceDelete provides a mock function with given fields: ctx, orderID, audit
+func (_m *MockOrder) ForceDelete(ctx context.Context, orderID string, audit *models.AuditEntry) error {
+	ret := _m.Called(ctx, orderID, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, orderID, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForceDeleteUser provides a mock function with given fields: ctx, userID, audit
+func (_m *MockOrder) ForceDeleteUser(ctx context.Context, userID string, audit *models.AuditEntry) (int, error) {
+	ret := _m.Called(ctx, userID, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDeleteUser")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.AuditEntry) (int, error)); ok {
+		return rf(ctx, userID, audit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.AuditEntry) int); ok {
+		r0 = rf(ctx, userID, audit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.AuditEntry) error); ok {
+		r1 = rf(ctx, userID, audit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBoard provides a mock function with given fields: ctx, boardType
 func (_m *MockOrder) GetBoard(ctx context.Context, boardType models.OrderBoardType) (*models.Board, string, error) {
 	ret := _m.Called(ctx, boardType)
@@ -69,17 +165,93 @@ func (_m *MockOrder) GetBoard(ctx context.Context, boardType models.OrderBoardTy
 	return r0, r1, r2
 }
 
-// Make provides a mock function with given fields: ctx, action, price, quantity
-func (_m *MockOrder) Make(ctx context.Context, action models.OrderAction, price int, quantity int) error {
-	ret := _m.Called(ctx, action, price, quantity)
+// GetOrder provides a mock function with given fields: ctx, userID, orderID
+func (_m *MockOrder) GetOrder(ctx context.Context, userID string, orderID string) (*models.Order, []*models.Order, error) {
+	ret := _m.Called(ctx, userID, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrder")
+	}
+
+	var r0 *models.Order
+	var r1 []*models.Order
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Order, []*models.Order, error)); ok {
+		return rf(ctx, userID, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Order); ok {
+		r0 = rf(ctx, userID, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) []*models.Order); ok {
+		r1 = rf(ctx, userID, orderID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, userID, orderID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPosition provides a mock function with given fields: ctx, userID
+func (_m *MockOrder) GetPosition(ctx context.Context, userID string) (*models.Position, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPosition")
+	}
+
+	var r0 *models.Position
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Position, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Position); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Position)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Make provides a mock function with given fields: ctx, userID, action, price, quantity, options
+func (_m *MockOrder) Make(ctx context.Context, userID string, action models.OrderAction, price models.Decimal, quantity models.Decimal, options ...MakeOption) error {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID, action, price, quantity)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Make")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.OrderAction, int, int) error); ok {
-		r0 = rf(ctx, action, price, quantity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, models.Decimal, ...MakeOption) error); ok {
+		r0 = rf(ctx, userID, action, price, quantity, options...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -87,17 +259,82 @@ func (_m *MockOrder) Make(ctx context.Context, action models.OrderAction, price
 	return r0
 }
 
-// Take provides a mock function with given fields: ctx, action, quantity
-func (_m *MockOrder) Take(ctx context.Context, action models.OrderAction, quantity int) error {
-	ret := _m.Called(ctx, action, quantity)
+// MakeOCO provides a mock function with given fields: ctx, userID, action, quantity, takeProfitPrice, stopPrice, stopLimitPrice
+func (_m *MockOrder) MakeOCO(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, takeProfitPrice models.Decimal, stopPrice models.Decimal, stopLimitPrice models.Decimal) ([]*models.Order, error) {
+	ret := _m.Called(ctx, userID, action, quantity, takeProfitPrice, stopPrice, stopLimitPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MakeOCO")
+	}
+
+	var r0 []*models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, models.Decimal, models.Decimal, models.Decimal) ([]*models.Order, error)); ok {
+		return rf(ctx, userID, action, quantity, takeProfitPrice, stopPrice, stopLimitPrice)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, models.Decimal, models.Decimal, models.Decimal) []*models.Order); ok {
+		r0 = rf(ctx, userID, action, quantity, takeProfitPrice, stopPrice, stopLimitPrice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.OrderAction, models.Decimal, models.Decimal, models.Decimal, models.Decimal) error); ok {
+		r1 = rf(ctx, userID, action, quantity, takeProfitPrice, stopPrice, stopLimitPrice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MakeTrailingStop provides a mock function with given fields: ctx, userID, action, quantity, trailAmount, trailPercent, limitOffset
+func (_m *MockOrder) MakeTrailingStop(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, trailAmount *models.Decimal, trailPercent *models.Decimal, limitOffset models.Decimal) (*models.Order, error) {
+	ret := _m.Called(ctx, userID, action, quantity, trailAmount, trailPercent, limitOffset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MakeTrailingStop")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, *models.Decimal, *models.Decimal, models.Decimal) (*models.Order, error)); ok {
+		return rf(ctx, userID, action, quantity, trailAmount, trailPercent, limitOffset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, *models.Decimal, *models.Decimal, models.Decimal) *models.Order); ok {
+		r0 = rf(ctx, userID, action, quantity, trailAmount, trailPercent, limitOffset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.OrderAction, models.Decimal, *models.Decimal, *models.Decimal, models.Decimal) error); ok {
+		r1 = rf(ctx, userID, action, quantity, trailAmount, trailPercent, limitOffset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *MockOrder) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Take provides a mock function with given fields: ctx, userID, action, quantity
+func (_m *MockOrder) Take(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal) error {
+	ret := _m.Called(ctx, userID, action, quantity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Take")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.OrderAction, int) error); ok {
-		r0 = rf(ctx, action, quantity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal) error); ok {
+		r0 = rf(ctx, userID, action, quantity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -105,6 +342,36 @@ func (_m *MockOrder) Take(ctx context.Context, action models.OrderAction, quanti
 	return r0
 }
 
+// Uncross provides a mock function with given fields: ctx
+func (_m *MockOrder) Uncross(ctx context.Context) (*models.Auction, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Uncross")
+	}
+
+	var r0 *models.Auction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.Auction, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.Auction); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Auction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockOrder creates a new instance of MockOrder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockOrder(t interface {
@@ -0,0 +1,29 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockOrderOption is an autogenerated mock type for the OrderOption type
+type MockOrderOption struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: _a0
+func (_m *MockOrderOption) Execute(_a0 *orderSvc) {
+	_m.Called(_a0)
+}
+
+// NewMockOrderOption creates a new instance of MockOrderOption. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderOption(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderOption {
+	mock := &MockOrderOption{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
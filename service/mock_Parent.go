@@ -0,0 +1,186 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockParent is an autogenerated mock type for the Parent type
+type MockParent struct {
+	mock.Mock
+}
+
+// Cancel provides a mock function with given fields: ctx, userID, parentID
+func (_m *MockParent) Cancel(ctx context.Context, userID string, parentID string) (*models.ParentOrder, error) {
+	ret := _m.Called(ctx, userID, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *models.ParentOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ParentOrder, error)); ok {
+		return rf(ctx, userID, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ParentOrder); ok {
+		r0 = rf(ctx, userID, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ParentOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetParent provides a mock function with given fields: ctx, userID, parentID
+func (_m *MockParent) GetParent(ctx context.Context, userID string, parentID string) (*models.ParentOrder, error) {
+	ret := _m.Called(ctx, userID, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParent")
+	}
+
+	var r0 *models.ParentOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ParentOrder, error)); ok {
+		return rf(ctx, userID, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ParentOrder); ok {
+		r0 = rf(ctx, userID, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ParentOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MakeTWAP provides a mock function with given fields: ctx, userID, action, quantity, limitPrice, startAt, endAt, slices
+func (_m *MockParent) MakeTWAP(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, limitPrice *models.Decimal, startAt time.Time, endAt time.Time, slices int) (*models.ParentOrder, error) {
+	ret := _m.Called(ctx, userID, action, quantity, limitPrice, startAt, endAt, slices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MakeTWAP")
+	}
+
+	var r0 *models.ParentOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, *models.Decimal, time.Time, time.Time, int) (*models.ParentOrder, error)); ok {
+		return rf(ctx, userID, action, quantity, limitPrice, startAt, endAt, slices)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, *models.Decimal, time.Time, time.Time, int) *models.ParentOrder); ok {
+		r0 = rf(ctx, userID, action, quantity, limitPrice, startAt, endAt, slices)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ParentOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.OrderAction, models.Decimal, *models.Decimal, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, userID, action, quantity, limitPrice, startAt, endAt, slices)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Pause provides a mock function with given fields: ctx, userID, parentID
+func (_m *MockParent) Pause(ctx context.Context, userID string, parentID string) (*models.ParentOrder, error) {
+	ret := _m.Called(ctx, userID, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pause")
+	}
+
+	var r0 *models.ParentOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ParentOrder, error)); ok {
+		return rf(ctx, userID, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ParentOrder); ok {
+		r0 = rf(ctx, userID, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ParentOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resume provides a mock function with given fields: ctx, userID, parentID
+func (_m *MockParent) Resume(ctx context.Context, userID string, parentID string) (*models.ParentOrder, error) {
+	ret := _m.Called(ctx, userID, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 *models.ParentOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ParentOrder, error)); ok {
+		return rf(ctx, userID, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ParentOrder); ok {
+		r0 = rf(ctx, userID, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ParentOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *MockParent) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// NewMockParent creates a new instance of MockParent. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockParent(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockParent {
+	mock := &MockParent{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
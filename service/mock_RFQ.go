@@ -0,0 +1,149 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRFQ is an autogenerated mock type for the RFQ type
+type MockRFQ struct {
+	mock.Mock
+}
+
+// Accept provides a mock function with given fields: ctx, userID, rfqID, quoteID
+func (_m *MockRFQ) Accept(ctx context.Context, userID string, rfqID string, quoteID string) (*models.Trade, error) {
+	ret := _m.Called(ctx, userID, rfqID, quoteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accept")
+	}
+
+	var r0 *models.Trade
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.Trade, error)); ok {
+		return rf(ctx, userID, rfqID, quoteID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.Trade); ok {
+		r0 = rf(ctx, userID, rfqID, quoteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Trade)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, rfqID, quoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRFQ provides a mock function with given fields: ctx, userID, scope, rfqID
+func (_m *MockRFQ) GetRFQ(ctx context.Context, userID string, scope models.UserType, rfqID string) (*models.RFQ, error) {
+	ret := _m.Called(ctx, userID, scope, rfqID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRFQ")
+	}
+
+	var r0 *models.RFQ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UserType, string) (*models.RFQ, error)); ok {
+		return rf(ctx, userID, scope, rfqID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UserType, string) *models.RFQ); ok {
+		r0 = rf(ctx, userID, scope, rfqID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RFQ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UserType, string) error); ok {
+		r1 = rf(ctx, userID, scope, rfqID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Quote provides a mock function with given fields: ctx, userID, rfqID, price
+func (_m *MockRFQ) Quote(ctx context.Context, userID string, rfqID string, price models.Decimal) (*models.Quote, error) {
+	ret := _m.Called(ctx, userID, rfqID, price)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quote")
+	}
+
+	var r0 *models.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Decimal) (*models.Quote, error)); ok {
+		return rf(ctx, userID, rfqID, price)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Decimal) *models.Quote); ok {
+		r0 = rf(ctx, userID, rfqID, price)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.Decimal) error); ok {
+		r1 = rf(ctx, userID, rfqID, price)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Request provides a mock function with given fields: ctx, userID, action, quantity
+func (_m *MockRFQ) Request(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal) (*models.RFQ, error) {
+	ret := _m.Called(ctx, userID, action, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Request")
+	}
+
+	var r0 *models.RFQ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal) (*models.RFQ, error)); ok {
+		return rf(ctx, userID, action, quantity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal) *models.RFQ); ok {
+		r0 = rf(ctx, userID, action, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RFQ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.OrderAction, models.Decimal) error); ok {
+		r1 = rf(ctx, userID, action, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockRFQ creates a new instance of MockRFQ. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRFQ(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRFQ {
+	mock := &MockRFQ{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,33 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRelay is an autogenerated mock type for the Relay type
+type MockRelay struct {
+	mock.Mock
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *MockRelay) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// NewMockRelay creates a new instance of MockRelay. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRelay(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRelay {
+	mock := &MockRelay{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTemplate is an autogenerated mock type for the Template type
+type MockTemplate struct {
+	mock.Mock
+}
+
+// Preview provides a mock function with given fields: ctx, event, locale, data
+func (_m *MockTemplate) Preview(ctx context.Context, event models.NotificationEvent, locale string, data *models.NotificationData) (*models.Notification, error) {
+	ret := _m.Called(ctx, event, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Preview")
+	}
+
+	var r0 *models.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.NotificationEvent, string, *models.NotificationData) (*models.Notification, error)); ok {
+		return rf(ctx, event, locale, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.NotificationEvent, string, *models.NotificationData) *models.Notification); ok {
+		r0 = rf(ctx, event, locale, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.NotificationEvent, string, *models.NotificationData) error); ok {
+		r1 = rf(ctx, event, locale, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Render provides a mock function with given fields: ctx, event, locale, data
+func (_m *MockTemplate) Render(ctx context.Context, event models.NotificationEvent, locale string, data *models.NotificationData) (*models.Notification, error) {
+	ret := _m.Called(ctx, event, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Render")
+	}
+
+	var r0 *models.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.NotificationEvent, string, *models.NotificationData) (*models.Notification, error)); ok {
+		return rf(ctx, event, locale, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.NotificationEvent, string, *models.NotificationData) *models.Notification); ok {
+		r0 = rf(ctx, event, locale, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.NotificationEvent, string, *models.NotificationData) error); ok {
+		r1 = rf(ctx, event, locale, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockTemplate creates a new instance of MockTemplate. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTemplate(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTemplate {
+	mock := &MockTemplate{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
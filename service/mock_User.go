@@ -0,0 +1,89 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUser is an autogenerated mock type for the User type
+type MockUser struct {
+	mock.Mock
+}
+
+// GetNotificationPreferences provides a mock function with given fields: ctx, userID
+func (_m *MockUser) GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationPreferences")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateNotificationPreferences provides a mock function with given fields: ctx, userID, email, sms, push
+func (_m *MockUser) UpdateNotificationPreferences(ctx context.Context, userID string, email bool, sms bool, push bool) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, userID, email, sms, push)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationPreferences")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, bool, bool) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, userID, email, sms, push)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, bool, bool) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, userID, email, sms, push)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, bool, bool) error); ok {
+		r1 = rf(ctx, userID, email, sms, push)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockUser creates a new instance of MockUser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUser(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUser {
+	mock := &MockUser{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+)
+
+type mailMessage struct {
+	Address string
+	Subject string
+	Content string
+	HTML    string
+}
+
+type smsMessage struct {
+	Number  string
+	Content string
+}
+
+type pushMessage struct {
+	Token   string
+	Title   string
+	Content string
+	Data    map[string]string
+}
+
+// notifier builds the notifications of users in their locale for the channels they prefer
+type notifier struct {
+	u store.User
+	t Template
+}
+
+// recipient is a user to notify through the channels it prefers
+type recipient struct {
+	userID      string
+	user        *models.User
+	preferences *models.NotificationPreferences
+}
+
+// recipient returns the user to notify, or nil if the user is unknown
+func (s *notifier) recipient(ctx context.Context, userID string) (*recipient, error) {
+	user, err := s.u.Get(ctx, userID)
+	if err == models.ErrorNotFound {
+		// users only known by their tokens have no contact information
+		logging.Infow(ctx, "skip notifications to unknown user", "userID", userID)
+		return nil, nil
+	} else if err != nil {
+		logging.Errorw(ctx, "service get user to notify failed", "err", err, "userID", userID)
+		return nil, err
+	}
+	preferences, err := s.u.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		logging.Errorw(ctx, "service get notification preferences failed", "err", err, "userID", userID)
+		return nil, err
+	}
+	return &recipient{
+		userID:      userID,
+		user:        user,
+		preferences: preferences,
+	}, nil
+}
+
+// notifications renders the notification of an event in the locale of the recipient, and builds
+// the messages for the channels the recipient prefers and has contact information of.
+func (s *notifier) notifications(ctx context.Context, r *recipient, event models.NotificationEvent, data *models.NotificationData) ([]*models.OutboxMessage, error) {
+	if r == nil {
+		return nil, nil
+	}
+	user, preferences := r.user, r.preferences
+	email := preferences.Email && user.Email != nil
+	sms := preferences.SMS && user.Phone != nil
+	push := preferences.Push && user.PushToken != nil
+	if !email && !sms && !push {
+		return nil, nil
+	}
+
+	locale := DEFAULT_LOCALE
+	if user.Locale != nil {
+		locale = *user.Locale
+	}
+	notification, err := s.t.Render(ctx, event, locale, data)
+	if err != nil {
+		logging.Errorw(ctx, "service render order notification failed", "err", err, "userID", r.userID)
+		return nil, err
+	}
+
+	messages := []*models.OutboxMessage{}
+	if email {
+		m, err := newOutboxMessage(r.userID, string(models.EmailChannel), &mailMessage{
+			Address: *user.Email,
+			Subject: notification.Subject,
+			Content: notification.Text,
+			HTML:    notification.HTML,
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	if sms {
+		m, err := newOutboxMessage(r.userID, string(models.SMSChannel), &smsMessage{
+			Number:  *user.Phone,
+			Content: notification.SMS,
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	if push {
+		m, err := newOutboxMessage(r.userID, string(models.PushChannel), &pushMessage{
+			Token:   *user.PushToken,
+			Title:   notification.Push.Title,
+			Content: notification.Push.Body,
+			Data:    notification.Push.Data,
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// fillNotifications builds an OrderFilled notification for every party of trades, summarizing the trades
+// the party took given side of. party returns nil for trades without such party, like trades of orders
+// created before ownership was recorded.
+func (s *notifier) fillNotifications(ctx context.Context, trades []*models.Trade, action models.OrderAction, party func(*models.Trade) *string) ([]*models.OutboxMessage, error) {
+	userIDs := []string{}
+	byUser := map[string][]*models.Trade{}
+	for _, trade := range trades {
+		userID := party(trade)
+		if userID == nil {
+			continue
+		}
+		if _, ok := byUser[*userID]; !ok {
+			userIDs = append(userIDs, *userID)
+		}
+		byUser[*userID] = append(byUser[*userID], trade)
+	}
+
+	messages := []*models.OutboxMessage{}
+	for _, userID := range userIDs {
+		summary, err := models.NewFillSummary(byUser[userID])
+		if err != nil {
+			logging.Errorw(ctx, "service summarize fills failed", "err", err, "userID", userID)
+			return nil, err
+		}
+		r, err := s.recipient(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		m, err := s.notifications(ctx, r, models.OrderFilled, &models.NotificationData{
+			Action:   action,
+			Price:    summary.AveragePrice,
+			Quantity: summary.Quantity,
+			Fill:     summary,
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m...)
+	}
+	return messages, nil
+}
+
+// takerOf returns the taker of a trade to notify of its fill
+func takerOf(trade *models.Trade) *string {
+	return &trade.TakerID
+}
+
+// makerOf returns the owner of the resting order of a trade to notify of its fill
+func makerOf(trade *models.Trade) *string {
+	return trade.MakerID
+}
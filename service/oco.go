@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+)
+
+func (s *orderSvc) MakeOCO(ctx context.Context, userID string, action models.OrderAction, quantity, takeProfitPrice, stopPrice, stopLimitPrice models.Decimal) ([]*models.Order, error) {
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	if err := checkMarket(instrument, s.now()); err != nil {
+		logging.Errorw(ctx, "service make oco orders rejected by market state", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	if _, err := s.postPrice(ctx, instrument, action, takeProfitPrice, false); err != nil {
+		logging.Errorw(ctx, "service make oco orders rejected by the board", "err", err)
+		return nil, err
+	}
+	latestPrice, err := s.latestPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLimitOrder(instrument, takeProfitPrice, quantity, latestPrice); err != nil {
+		logging.Errorw(ctx, "service make oco orders rejected by trading rules", "err", err)
+		return nil, err
+	}
+	if err := checkStopPrice(instrument, action, stopPrice, latestPrice); err != nil {
+		logging.Errorw(ctx, "service make oco orders rejected by trading rules", "err", err)
+		return nil, err
+	}
+	if err := checkLimitOrder(instrument, stopLimitPrice, quantity, latestPrice); err != nil {
+		if fieldErr, ok := err.(*models.FieldError); ok && fieldErr.Field == "price" {
+			fieldErr.Field = "stop_limit_price"
+		}
+		logging.Errorw(ctx, "service make oco orders rejected by trading rules", "err", err)
+		return nil, err
+	}
+
+	takeProfit := &models.Order{
+		ID:       s.newID(),
+		UserID:   &userID,
+		Action:   action,
+		Price:    takeProfitPrice,
+		Quantity: quantity,
+		Status:   models.OrderOpen,
+	}
+	stopLoss := &models.Order{
+		ID:        s.newID(),
+		UserID:    &userID,
+		Action:    action,
+		Price:     stopLimitPrice,
+		Quantity:  quantity,
+		Status:    models.OrderPending,
+		StopPrice: &stopPrice,
+	}
+	r, err := s.recipient(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := s.notifications(ctx, r, models.OrderCreated, &models.NotificationData{
+		OrderID:  takeProfit.ID,
+		Action:   action,
+		Price:    takeProfitPrice,
+		Quantity: quantity,
+	})
+	if err != nil {
+		logging.Errorw(ctx, "service build order notifications failed", "err", err)
+		return nil, err
+	}
+
+	orders := []*models.Order{takeProfit, stopLoss}
+	s.BoardGuard.Lock()
+	defer s.BoardGuard.Unlock()
+	if err := s.c.MakeOCO(ctx, orders, messages); err != nil {
+		logging.Errorw(ctx, "service make oco orders failed", "err", err)
+		return nil, err
+	}
+	s.invalidateBoard(ctx)
+	return orders, nil
+}
+
+func (s *orderSvc) GetOrder(ctx context.Context, userID, orderID string) (*models.Order, []*models.Order, error) {
+	order, err := s.c.Get(ctx, orderID)
+	if err != nil {
+		logging.Errorw(ctx, "service get order failed", "err", err, "orderID", orderID)
+		return nil, nil, err
+	}
+	// orders of others are not found to avoid leaking their existence
+	if order.UserID == nil || *order.UserID != userID {
+		return nil, nil, models.ErrorNotFound
+	}
+
+	linked := []*models.Order{}
+	if order.OCOGroupID == nil {
+		return order, linked, nil
+	}
+	group, err := s.c.GetOCOGroup(ctx, *order.OCOGroupID)
+	if err != nil {
+		logging.Errorw(ctx, "service get oco group failed", "err", err, "orderID", orderID)
+		return nil, nil, err
+	}
+	for _, o := range group {
+		if o.ID != order.ID {
+			linked = append(linked, o)
+		}
+	}
+	return order, linked, nil
+}
@@ -4,85 +4,104 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/A-pen-app/cache"
+	"github.com/A-pen-app/kickstart/config"
 	"github.com/A-pen-app/kickstart/models"
 	"github.com/A-pen-app/kickstart/store"
-	"github.com/A-pen-app/kickstart/util"
 	"github.com/A-pen-app/logging"
-	"github.com/A-pen-app/mq"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
 type orderSvc struct {
-	LatestPrice int
-	BoardGuard  sync.Mutex
-	c           store.Order
-	q           mq.MQ
+	// BoardGuard serializes board changes within this process, changes across replicas are serialized
+	// by the book lock of store.Order.
+	BoardGuard sync.Mutex
+	Instrument string
+	// reprices post-only orders one tick away from the other side of the board instead of rejecting them
+	RepricePostOnly bool
+	c               store.Order
+	p               store.Position
+	i               store.Instrument
+	notifier
+	now   func() time.Time
+	newID func() string
+	// how often Run checks if the board is to be uncrossed
+	uncrossInterval time.Duration
+	// how long each query loading the board may take
+	boardQueryTimeout time.Duration
+	// the most operations a batch may have
+	maxBatchSize int
+
+	// concurrent cache misses of a board share a single load of it
+	boards singleflight.Group
+	// counts the changes of the board, to tell if it changed while being loaded
+	boardVersion atomic.Int64
 }
 
-const DEFAULT_PRICE int = 10
+var DEFAULT_PRICE = models.NewDecimal(10)
 
 // NewOrder returns an implementation of service.Order
-func NewOrder(c store.Order, q mq.MQ) Order {
-	return &orderSvc{
-		LatestPrice: DEFAULT_PRICE,
-		BoardGuard:  sync.Mutex{},
-		c:           c,
-		q:           q,
+func NewOrder(c store.Order, p store.Position, i store.Instrument, u store.User, t Template, options ...OrderOption) Order {
+	s := &orderSvc{
+		BoardGuard:        sync.Mutex{},
+		Instrument:        config.GetString("INSTRUMENT"),
+		RepricePostOnly:   config.GetBool("POST_ONLY_REPRICE"),
+		c:                 c,
+		p:                 p,
+		i:                 i,
+		notifier:          notifier{u: u, t: t},
+		now:               time.Now,
+		newID:             uuid.NewString,
+		uncrossInterval:   config.GetMilliseconds("AUCTION_UNCROSS_INTERVAL_MS"),
+		boardQueryTimeout: config.GetMilliseconds("BOARD_QUERY_TIMEOUT_MS"),
+		maxBatchSize:      config.GetInt("ORDER_BATCH_MAX_SIZE"),
+	}
+	for _, option := range options {
+		option(s)
 	}
+	return s
 }
 
 // return type is ([]*models.Order, string, error) corresponding to (orders, next, error)
 func (s *orderSvc) GetBoard(ctx context.Context, boardType models.OrderBoardType) (*models.Board, string, error) {
-	var cacheKey string
-	// return value of f is (buyOrders, sellOrders, getBuyOrders, getSellOrders, error)
-	var f func() (*models.Board, error)
+	cacheKey := boardCacheKey(boardType)
+	var f func(ctx context.Context) (*models.Board, error)
 
 	switch boardType {
 	case models.Live:
-		cacheKey = fmt.Sprintf("get_orders.%s", models.Live)
-		f = func() (*models.Board, error) {
-			spawned := 0
-			w, errCh := sync.WaitGroup{}, make(chan error, spawned)
-
-			buyOrders := []*models.Order{}
-			spawned += 1
-			w.Add(1)
-			go func(ctx context.Context, orders *[]*models.Order) {
-				defer w.Done()
-				var err error
-				if *orders, err = s.c.GetLiveOrders(ctx, models.Buy); err != nil {
-					logging.Errorw(ctx, "get live buy orders failed", "err", err)
-					errCh <- err
-				}
-			}(ctx, &buyOrders)
-
-			sellOrders := []*models.Order{}
-			spawned += 1
-			w.Add(1)
-			go func(ctx context.Context, orders *[]*models.Order) {
-				defer w.Done()
-				var err error
-				if sellOrders, err = s.c.GetLiveOrders(ctx, models.Sell); err != nil {
-					logging.Errorw(ctx, "get live sell orders failed", "err", err)
-					errCh <- err
-				}
-			}(ctx, &sellOrders)
-
-			w.Wait()
-			if err := util.ChErrHandler(errCh, spawned); err != nil {
+		f = func(ctx context.Context) (*models.Board, error) {
+			// the first failing query cancels the other
+			g, gctx := errgroup.WithContext(ctx)
+			board := &models.Board{}
+			for action, orders := range map[models.OrderAction]*[]*models.Order{
+				models.Buy:  &board.BuyOrders,
+				models.Sell: &board.SellOrders,
+			} {
+				g.Go(func() error {
+					qctx, cancel := context.WithTimeout(gctx, s.boardQueryTimeout)
+					defer cancel()
+					var err error
+					if *orders, err = s.c.GetLiveOrders(qctx, action); err != nil {
+						logging.Errorw(ctx, "get live orders failed", "err", err, "action", action)
+						return err
+					}
+					return nil
+				})
+			}
+			if err := g.Wait(); err != nil {
 				return nil, err
 			}
-			return &models.Board{
-				BuyOrders:  buyOrders,
-				SellOrders: sellOrders,
-			}, nil
+			return board, nil
 		}
-	case models.History:
-	// FIXME: finish this
-	case models.Removed:
-	// FIXME: finish this
+	case models.History, models.Removed:
+		// FIXME: finish this
+		logging.Errorw(ctx, "service board type not supported yet", "boardType", boardType)
+		return nil, "", models.ErrorUnsupported
 	default:
 		err := fmt.Errorf("unexpected board type: %s", boardType)
 		logging.Errorw(ctx, "service unexpected board type accessed in getBoard", "err", err, "boardType", boardType)
@@ -90,12 +109,15 @@ func (s *orderSvc) GetBoard(ctx context.Context, boardType models.OrderBoardType
 	}
 
 	var board *models.Board
-	// here we assume cache providing the single source of truth, the integrity of the order list need to be maintained for each modification to the list
-	// eg: make, take should also trigger cache update
+	// here we assume cache providing the single source of truth, every change of the board invalidates it
 	if err := cache.Get(ctx, cacheKey, &board); err == nil {
 		if board == nil { // if last get returns [], it will be cached as null, conerting for ease of frontend integration
+			latestPrice, err := s.latestPrice(ctx)
+			if err != nil {
+				return nil, "", err
+			}
 			board = &models.Board{
-				LatestPrice: s.LatestPrice,
+				LatestPrice: latestPrice,
 				BuyOrders:   []*models.Order{},
 				SellOrders:  []*models.Order{},
 			}
@@ -109,148 +131,348 @@ func (s *orderSvc) GetBoard(ctx context.Context, boardType models.OrderBoardType
 	} else if err == cache.ErrorNotFound {
 		logging.Infow(ctx, "get orders response not found in cache", "err", err)
 
-		board, err = f()
+		// the load is shared by other requests, it should not be cancelled along with this one
+		loadCtx := context.WithoutCancel(ctx)
+		loaded, err, _ := s.boards.Do(cacheKey, func() (interface{}, error) {
+			return s.loadBoard(loadCtx, cacheKey, f)
+		})
 		if err != nil {
 			return nil, "", err
 		}
-		board.LatestPrice = s.LatestPrice
-
-		// FIXME: add Board aggregation
-		if err := aggregateBoard(ctx, board); err != nil {
-			logging.Errorw(ctx, "aggregate orders failed", "err", err)
-			return nil, "", err
-		}
-
-		// to return order list faster, use a goroutine to set cache
-		go func(ctx context.Context) {
-			if err := cache.SetWithTTL(ctx, cacheKey, board, time.Second); err != nil {
-				logging.Errorw(ctx, "set buy orders cache failed", "err", err)
-			}
-		}(ctx)
+		board = loaded.(*models.Board)
 	} else {
 		logging.Errorw(ctx, "unexpected error while getting orders from cache", "err", err)
 		return nil, "", err
 	}
 
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, "", err
+	}
+	// market state changes independently of the cached board, copy the board as it may be being cached
+	withState := *board
+	withState.MarketState = instrument.State(s.now())
+	if withState.MarketState == models.MarketAuction {
+		// from the orders Uncross executes, which the board aggregates and shows without their hidden quantity
+		if withState.Auction, err = s.c.GetIndicativeAuction(ctx, DEFAULT_PRICE); err != nil {
+			logging.Errorw(ctx, "service get indicative auction failed", "err", err)
+			return nil, "", err
+		}
+	}
+	board = &withState
+
 	// FIXME: next page token assignment
 	next := ""
 
 	return board, next, nil
 }
 
-func (s *orderSvc) Make(ctx context.Context, action models.OrderAction, price, quantity int) error {
-	if action == models.Buy && price >= s.LatestPrice {
-		err := fmt.Errorf("price to buy %d should not be lower than latest price %d", price, s.LatestPrice)
-		logging.Errorw(ctx, "service make order failed", "err", err)
+func (s *orderSvc) Make(ctx context.Context, userID string, action models.OrderAction, price, quantity models.Decimal, options ...MakeOption) error {
+	opt := makeOption{}
+	for _, f := range options {
+		if err := f(&opt); err != nil {
+			return err
+		}
+	}
+
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
 		return err
 	}
-	if action == models.Sell && price <= s.LatestPrice {
-		err := fmt.Errorf("price to sell %d should not be higher than latest price %d", price, s.LatestPrice)
-		logging.Errorw(ctx, "service make order failed", "err", err)
+	order, messages, err := s.prepareMake(ctx, instrument, userID, action, price, quantity, opt)
+	if err != nil {
 		return err
 	}
 
 	s.BoardGuard.Lock()
-	// FIXME: should update to cache after make order
-	if err := s.c.Make(ctx, action, price, quantity); err != nil {
+	defer s.BoardGuard.Unlock()
+	if err := s.c.Make(ctx, order, messages); err != nil {
 		logging.Errorw(ctx, "service make order failed", "err", err)
 		return err
 	}
-	s.BoardGuard.Unlock()
-
-	go func(ctx context.Context) {
-		// send email to user
-		go func(ctx context.Context) {
-			if err := s.q.Send("mail", struct {
-				Address string
-				Content string
-			}{
-				Address: "user@gmail.com",
-				Content: "your order has been created",
-			}); err != nil {
-				logging.Errorw(ctx, "send email failed", "err", err)
-			}
-		}(ctx)
-
-		// send sms message to user
-		go func(ctx context.Context) {
-			if err := s.q.Send("sms", struct {
-				Number  string
-				Content string
-			}{
-				Number:  "0911122233",
-				Content: "your order has been created",
-			}); err != nil {
-				logging.Errorw(ctx, "send sms failed", "err", err)
-			}
-		}(ctx)
-	}(ctx)
+	s.invalidateBoard(ctx)
 	return nil
 }
 
-func (s *orderSvc) Take(ctx context.Context, action models.OrderAction, quantity int) error {
+// prepareMake checks an order to make against the market state, the board and the trading rules of
+// instrument, and returns it along with the notifications of its creation
+func (s *orderSvc) prepareMake(ctx context.Context, instrument *models.Instrument, userID string, action models.OrderAction, price, quantity models.Decimal, opt makeOption) (*models.Order, []*models.OutboxMessage, error) {
+	if err := checkMarket(instrument, s.now()); err != nil {
+		logging.Errorw(ctx, "service make order rejected by market state", "err", err, "symbol", s.Instrument)
+		return nil, nil, err
+	}
+	var err error
+	if price, err = s.postPrice(ctx, instrument, action, price, opt.postOnly); err != nil {
+		logging.Errorw(ctx, "service make order rejected by the board", "err", err)
+		return nil, nil, err
+	}
+	latestPrice, err := s.latestPrice(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkLimitOrder(instrument, price, quantity, latestPrice); err != nil {
+		logging.Errorw(ctx, "service make order rejected by trading rules", "err", err)
+		return nil, nil, err
+	}
+	if opt.displayQuantity != nil {
+		if err := checkDisplayQuantity(instrument, *opt.displayQuantity, quantity); err != nil {
+			logging.Errorw(ctx, "service make iceberg order rejected by trading rules", "err", err)
+			return nil, nil, err
+		}
+	}
+
+	order := &models.Order{
+		ID:       s.newID(),
+		UserID:   &userID,
+		Action:   action,
+		Price:    price,
+		Quantity: quantity,
+	}
+	if opt.displayQuantity != nil {
+		order.DisplayQuantity = opt.displayQuantity
+		order.Quantity = *opt.displayQuantity
+		order.HiddenQuantity = quantity - *opt.displayQuantity
+	}
+	r, err := s.recipient(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	messages, err := s.notifications(ctx, r, models.OrderCreated, &models.NotificationData{
+		OrderID:  order.ID,
+		Action:   action,
+		Price:    price,
+		Quantity: quantity,
+	})
+	if err != nil {
+		logging.Errorw(ctx, "service build order notifications failed", "err", err)
+		return nil, nil, err
+	}
+
+	return order, messages, nil
+}
+
+func (s *orderSvc) Take(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal) error {
 	if action != models.Buy {
 		err := fmt.Errorf("currently, only buy action is supported, got %s", action)
 		logging.Errorw(ctx, "service take order failed", "err", err)
 		return err
 	}
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return err
+	}
+	if err := checkMarket(instrument, s.now()); err != nil {
+		logging.Errorw(ctx, "service take order rejected by market state", "err", err, "symbol", s.Instrument)
+		return err
+	}
+	if instrument.State(s.now()) == models.MarketAuction {
+		logging.Errorw(ctx, "service take order rejected by call auction", "symbol", s.Instrument)
+		return models.ErrorMarketAuction
+	}
+	if err := checkQuantity(instrument, quantity); err != nil {
+		logging.Errorw(ctx, "service take order rejected by trading rules", "err", err)
+		return err
+	}
+
+	r, err := s.recipient(ctx, userID)
+	if err != nil {
+		return err
+	}
+	makerAction := models.Sell
+	if action == models.Sell {
+		makerAction = models.Buy
+	}
+	// the fill is only known within the transaction executing the trades, the taker and the owner of
+	// every resting order taken are notified
+	outbox := func(trades []*models.Trade) ([]*models.OutboxMessage, error) {
+		if len(trades) == 0 {
+			return nil, nil
+		}
+		summary, err := models.NewFillSummary(trades)
+		if err != nil {
+			logging.Errorw(ctx, "service summarize fills failed", "err", err)
+			return nil, err
+		}
+		messages, err := s.notifications(ctx, r, models.OrderFilled, &models.NotificationData{
+			Action:   action,
+			Price:    summary.AveragePrice,
+			Quantity: quantity,
+			Fill:     summary,
+		})
+		if err != nil {
+			return nil, err
+		}
+		makers, err := s.fillNotifications(ctx, trades, makerAction, makerOf)
+		if err != nil {
+			return nil, err
+		}
+		return append(messages, makers...), nil
+	}
 
-	// FIXME: should update to cache after take order
 	s.BoardGuard.Lock()
-	latestPrice, err := s.c.Take(ctx, action, quantity)
+	defer s.BoardGuard.Unlock()
+	latestPrice, err := s.c.Take(ctx, userID, action, quantity, outbox)
 	if err != nil {
 		logging.Errorw(ctx, "service take order failed", "err", err)
 		return err
 	}
-	// in case orders are all taken
+	// also covers the stop orders put on the board below
+	defer s.invalidateBoard(ctx)
+	invalidateTickers(ctx)
+	// nothing is traded if there is no order to take
 	if latestPrice == 0 {
-		s.LatestPrice = DEFAULT_PRICE
-	} else {
-		s.LatestPrice = latestPrice
-	}
-	s.BoardGuard.Unlock()
-
-	go func(ctx context.Context) {
-		// send email to user
-		go func(ctx context.Context) {
-			if err := s.q.Send("mail", struct {
-				Address string
-				Content string
-			}{
-				Address: "user@gmail.com",
-				Content: "your order has been fulfilled",
-			}); err != nil {
-				logging.Errorw(ctx, "send email failed", "err", err)
-			}
-		}(ctx)
-
-		// send sms message to user
-		go func(ctx context.Context) {
-			if err := s.q.Send("sms", struct {
-				Number  string
-				Content string
-			}{
-				Number:  "0911122233",
-				Content: "your order has been fulfilled",
-			}); err != nil {
-				logging.Errorw(ctx, "send sms failed", "err", err)
-			}
-		}(ctx)
-	}(ctx)
-	return nil
+		return nil
+	}
+	s.checkVolatility(ctx, instrument, latestPrice)
+	return s.updateStops(ctx, instrument, latestPrice)
+}
+
+func (s *orderSvc) Delete(ctx context.Context, userID, orderID string) error {
+	return s.deleteOrder(ctx, &userID, orderID, nil)
 }
 
-func (s *orderSvc) Delete(ctx context.Context, orderID string) error {
-	// FIXME: should update to cache after delete order
+func (s *orderSvc) ForceDelete(ctx context.Context, orderID string, audit *models.AuditEntry) error {
+	return s.deleteOrder(ctx, nil, orderID, audit)
+}
+
+// deleteOrder deletes an order of the user, or of any user if userID is nil
+func (s *orderSvc) deleteOrder(ctx context.Context, userID *string, orderID string, audit *models.AuditEntry) error {
 	s.BoardGuard.Lock()
-	if err := s.c.Delete(ctx, orderID); err != nil {
+	defer s.BoardGuard.Unlock()
+	if err := s.c.Delete(ctx, userID, orderID, audit); err != nil {
 		logging.Errorw(ctx, "attend order failed", "err", err, "orderID", orderID)
 		return err
 	}
-	s.BoardGuard.Unlock()
+	s.invalidateBoard(ctx)
 	return nil
 }
 
+func (s *orderSvc) ForceDeleteUser(ctx context.Context, userID string, audit *models.AuditEntry) (int, error) {
+	s.BoardGuard.Lock()
+	defer s.BoardGuard.Unlock()
+	deleted, err := s.c.DeleteByUser(ctx, userID, audit)
+	if err != nil {
+		logging.Errorw(ctx, "service delete orders of user failed", "err", err, "userID", userID)
+		return 0, err
+	}
+	s.invalidateBoard(ctx)
+	return deleted, nil
+}
+
+func boardCacheKey(boardType models.OrderBoardType) string {
+	return fmt.Sprintf("get_orders.%s", boardType)
+}
+
+// loadBoard loads a board by f and caches it, the board is dropped from the cache again if it changed
+// while being loaded, so a load racing a change never leaves the board before the change cached.
+func (s *orderSvc) loadBoard(ctx context.Context, cacheKey string, f func(context.Context) (*models.Board, error)) (*models.Board, error) {
+	version := s.boardVersion.Load()
+	board, err := f(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if board.LatestPrice, err = s.latestPrice(ctx); err != nil {
+		return nil, err
+	}
+
+	// FIXME: add Board aggregation
+	if err := aggregateBoard(ctx, board); err != nil {
+		logging.Errorw(ctx, "aggregate orders failed", "err", err)
+		return nil, err
+	}
+
+	if err := cache.SetWithTTL(ctx, cacheKey, board, time.Second); err != nil {
+		logging.Errorw(ctx, "set board cache failed", "err", err)
+	} else if s.boardVersion.Load() != version {
+		if err := cache.Delete(ctx, cacheKey); err != nil {
+			logging.Errorw(ctx, "drop stale board cache failed", "err", err)
+		}
+	}
+	return board, nil
+}
+
+// invalidateBoard drops the cached live board after a change of the board is committed, the next
+// GetBoard loads and caches it again. It should be called with BoardGuard held.
+func (s *orderSvc) invalidateBoard(ctx context.Context) {
+	s.boardVersion.Add(1)
+	if err := cache.Delete(ctx, boardCacheKey(models.Live)); err != nil {
+		// the change is committed anyway, the board is at worst stale until the cache entry expires
+		logging.Errorw(ctx, "invalidate board cache failed", "err", err)
+	}
+}
+
+// postPrice returns the price to make an order at without matching the other side of the board,
+// orders which would immediately match are rejected as making orders never matches, unless they are
+// post-only and RepricePostOnly is set, in which case they are repriced one tick away from the best price.
+// Crossing orders are accepted during the call phase of an auction, as they are only matched when it ends.
+func (s *orderSvc) postPrice(ctx context.Context, instrument *models.Instrument, action models.OrderAction, price models.Decimal, postOnly bool) (models.Decimal, error) {
+	if instrument.State(s.now()) == models.MarketAuction {
+		return price, nil
+	}
+	other, tick := models.Sell, -instrument.TickSize
+	if action == models.Sell {
+		other, tick = models.Buy, instrument.TickSize
+	}
+	best, err := s.c.GetBestPrice(ctx, other)
+	if err != nil {
+		logging.Errorw(ctx, "service get best price failed", "err", err, "action", other)
+		return 0, err
+	}
+	if best == nil || (action == models.Buy && price < *best) || (action == models.Sell && price > *best) {
+		return price, nil
+	}
+
+	if !postOnly {
+		return 0, &models.FieldError{
+			Field:  "price",
+			Reason: fmt.Sprintf("would immediately match the best %s price %s, take the order instead", other, *best),
+		}
+	}
+	repriced := *best + tick
+	if !s.RepricePostOnly || repriced <= 0 {
+		return 0, &models.FieldError{
+			Field:  "price",
+			Reason: fmt.Sprintf("of post-only order would immediately match the best %s price %s", other, *best),
+		}
+	}
+	logging.Infow(ctx, "post-only order repriced", "action", action, "price", price.String(), "repriced", repriced.String())
+	return repriced, nil
+}
+
+func (s *orderSvc) GetPosition(ctx context.Context, userID string) (*models.Position, error) {
+	position, err := s.p.Get(ctx, userID)
+	if err != nil {
+		logging.Errorw(ctx, "service get position failed", "err", err, "userID", userID)
+		return nil, err
+	}
+	latestPrice, err := s.latestPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := position.Mark(latestPrice); err != nil {
+		logging.Errorw(ctx, "service mark position failed", "err", err, "userID", userID)
+		return nil, err
+	}
+	return position, nil
+}
+
+// latestPrice returns the price of the latest trade on the board, which is shared by all replicas, or
+// DEFAULT_PRICE if nothing has traded yet
+func (s *orderSvc) latestPrice(ctx context.Context) (models.Decimal, error) {
+	price, err := s.c.GetLatestPrice(ctx)
+	if err != nil {
+		logging.Errorw(ctx, "service get latest price failed", "err", err)
+		return 0, err
+	}
+	if price == nil {
+		return DEFAULT_PRICE, nil
+	}
+	return *price, nil
+}
+
 // this cloud be placed under service package as aggregator package
 func aggregateBoard(ctx context.Context, board *models.Board) error {
 	return nil
@@ -2,14 +2,21 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/A-pen-app/cache"
 	"github.com/A-pen-app/kickstart/config"
 	"github.com/A-pen-app/kickstart/models"
 	"github.com/A-pen-app/kickstart/mq"
 	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/kickstart/store/memory"
 	"github.com/A-pen-app/logging"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -52,8 +59,16 @@ func TestGetOrders(t *testing.T) {
 		},
 		nil,
 	)
-	mq := new(mq.MockMQ)
-	kickstartSvc := NewOrder(orderStore, mq)
+	orderStore.On("GetLatestPrice", mock.Anything).Return(nil, nil)
+	positionStore := new(store.MockPosition)
+	instrumentStore := new(store.MockInstrument)
+	instrumentStore.On("Get", mock.Anything, mock.Anything).Return(
+		&models.Instrument{
+			Symbol: "DEMO",
+		},
+		nil,
+	)
+	kickstartSvc := NewOrder(orderStore, positionStore, instrumentStore, new(store.MockUser), NewTemplate())
 
 	board, next, err := kickstartSvc.GetBoard(context.Background(), models.Live)
 	if err != nil {
@@ -64,6 +79,7 @@ func TestGetOrders(t *testing.T) {
 
 	require.Equal(t, "", next, "expect next to be empty")
 	require.Equal(t, true, board != nil, "expect at least one order exists")
+	require.Equal(t, models.MarketOpen, board.MarketState, "expect market to be open")
 }
 
 // Test all models.Order status
@@ -112,3 +128,834 @@ func TestAggregatedOrdersStatus(t *testing.T) {
 	require.Equal(t, "order remoevd", "order remoevd")
 	require.Equal(t, "order fulfilled", "order fulfilled")
 }
+
+func TestCheckLimitOrder(t *testing.T) {
+	instrument := &models.Instrument{
+		Symbol:           "DEMO",
+		TickSize:         models.NewDecimal(5),
+		LotSize:          models.NewDecimal(10),
+		MinNotional:      models.NewDecimal(100),
+		MaxNotional:      models.NewDecimal(10000),
+		PriceBandPercent: 20,
+	}
+
+	cases := []struct {
+		price, quantity int64
+		field           string
+	}{
+		{price: 100, quantity: 10},
+		{price: 101, quantity: 10, field: "price"},
+		{price: 125, quantity: 10, field: "price"},
+		{price: 100, quantity: 15, field: "quantity"},
+		{price: 100, quantity: 200, field: "quantity"},
+	}
+	for _, c := range cases {
+		err := checkLimitOrder(instrument, models.NewDecimal(c.price), models.NewDecimal(c.quantity), models.NewDecimal(100))
+		if c.field == "" {
+			require.NoError(t, err)
+			continue
+		}
+		fieldErr, ok := err.(*models.FieldError)
+		require.Equal(t, true, ok, "expect a field error")
+		require.Equal(t, c.field, fieldErr.Field)
+		require.ErrorIs(t, err, models.ErrorWrongParams)
+	}
+
+	_, err := models.NewDecimal(1 << 40).Mul(models.NewDecimal(1 << 40))
+	require.ErrorIs(t, err, models.ErrorWrongParams, "expect overflowing notional to be rejected")
+}
+
+func TestPostOnly(t *testing.T) {
+	bestSell := models.NewDecimal(11)
+	orderStore := new(store.MockOrder)
+	orderStore.On("GetBestPrice", mock.Anything, models.Sell).Return(&bestSell, nil)
+	orderStore.On("GetBestPrice", mock.Anything, models.Buy).Return(nil, nil)
+
+	s := &orderSvc{c: orderStore, now: time.Now}
+	instrument := &models.Instrument{
+		Symbol:   "DEMO",
+		TickSize: models.NewDecimal(1),
+	}
+	ctx := context.Background()
+
+	price, err := s.postPrice(ctx, instrument, models.Buy, models.NewDecimal(10), false)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(10), price, "expect order not crossing the board to keep its price")
+
+	price, err = s.postPrice(ctx, instrument, models.Sell, models.NewDecimal(5), true)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(5), price, "expect order to keep its price on an empty side")
+
+	_, err = s.postPrice(ctx, instrument, models.Buy, models.NewDecimal(12), false)
+	require.ErrorIs(t, err, models.ErrorWrongParams, "expect crossing order to be rejected")
+
+	_, err = s.postPrice(ctx, instrument, models.Buy, models.NewDecimal(11), true)
+	require.ErrorIs(t, err, models.ErrorWrongParams, "expect crossing post-only order to be rejected")
+
+	s.RepricePostOnly = true
+	price, err = s.postPrice(ctx, instrument, models.Buy, models.NewDecimal(12), true)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(10), price, "expect crossing post-only order to be repriced one tick below the best sell")
+}
+
+func TestBoardCache(t *testing.T) {
+	cache.Initialize(&cache.Config{
+		Type:   cache.TypeLocal,
+		Prefix: "local-dev",
+	})
+	defer cache.Finalize()
+	ctx := context.Background()
+	require.NoError(t, cache.Delete(ctx, boardCacheKey(models.Live)))
+
+	release := make(chan struct{})
+	orderStore := new(store.MockOrder)
+	orderStore.On("GetLiveOrders", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		<-release
+	}).Return([]*models.Order{}, nil)
+	orderStore.On("GetLatestPrice", mock.Anything).Return(nil, nil)
+	instrumentStore := new(store.MockInstrument)
+	instrumentStore.On("Get", mock.Anything, mock.Anything).Return(&models.Instrument{Symbol: "DEMO"}, nil)
+	s := &orderSvc{c: orderStore, i: instrumentStore, now: time.Now, boardQueryTimeout: time.Second}
+
+	w := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		w.Add(1)
+		go func() {
+			defer w.Done()
+			_, _, err := s.GetBoard(ctx, models.Live)
+			require.NoError(t, err)
+		}()
+	}
+	// let every request miss the cache before the first load finishes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	w.Wait()
+	orderStore.AssertNumberOfCalls(t, "GetLiveOrders", 2)
+
+	s.invalidateBoard(ctx)
+	_, _, err := s.GetBoard(ctx, models.Live)
+	require.NoError(t, err)
+	orderStore.AssertNumberOfCalls(t, "GetLiveOrders", 4)
+}
+
+func TestGetBoardErrors(t *testing.T) {
+	cache.Initialize(&cache.Config{
+		Type:   cache.TypeLocal,
+		Prefix: "local-dev",
+	})
+	defer cache.Finalize()
+	ctx := context.Background()
+
+	// waits for the query to be cancelled, either by the other query failing or by timing out
+	blocked := func(ctx context.Context, action models.OrderAction) ([]*models.Order, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	failed := errors.New("connection reset")
+	orderStore := new(store.MockOrder)
+	orderStore.On("GetLiveOrders", mock.Anything, models.Buy).Return(nil, failed)
+	orderStore.On("GetLiveOrders", mock.Anything, models.Sell).Return(blocked)
+	s := &orderSvc{c: orderStore, now: time.Now, boardQueryTimeout: time.Minute}
+	require.NoError(t, cache.Delete(ctx, boardCacheKey(models.Live)))
+	_, _, err := s.GetBoard(ctx, models.Live)
+	require.ErrorIs(t, err, failed, "expect the failing query to cancel the other")
+
+	orderStore = new(store.MockOrder)
+	orderStore.On("GetLiveOrders", mock.Anything, mock.Anything).Return(blocked)
+	s = &orderSvc{c: orderStore, now: time.Now, boardQueryTimeout: 10 * time.Millisecond}
+	_, _, err = s.GetBoard(ctx, models.Live)
+	require.ErrorIs(t, err, context.DeadlineExceeded, "expect slow queries to time out")
+
+	_, _, err = s.GetBoard(ctx, models.History)
+	require.ErrorIs(t, err, models.ErrorUnsupported)
+}
+
+func TestBoardGuardReleased(t *testing.T) {
+	orderStore := new(store.MockOrder)
+	orderStore.On("Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(models.ErrorBookBusy).Twice()
+
+	s := &orderSvc{c: orderStore, now: time.Now}
+	ctx := context.Background()
+	require.ErrorIs(t, s.Delete(ctx, "user", "order"), models.ErrorBookBusy)
+	// deadlocks if the failed delete kept BoardGuard
+	require.ErrorIs(t, s.Delete(ctx, "user", "order"), models.ErrorBookBusy)
+	require.True(t, s.BoardGuard.TryLock(), "expect BoardGuard to be released on error")
+	orderStore.AssertExpectations(t)
+}
+
+func TestCheckVolatility(t *testing.T) {
+	instrumentStore := new(store.MockInstrument)
+	instrumentStore.On("Halt", mock.Anything, "DEMO", mock.Anything, 15).Return(nil).Once()
+	orderStore := new(store.MockOrder)
+
+	now := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	s := &orderSvc{c: orderStore, i: instrumentStore, now: func() time.Time { return now }}
+	instrument := &models.Instrument{
+		Symbol:                  "DEMO",
+		VolatilityPercent:       10,
+		VolatilityWindowMinutes: 5,
+		HaltMinutes:             15,
+	}
+	priceRange := func(since time.Time, low, high int64) {
+		l, h := models.NewDecimal(low), models.NewDecimal(high)
+		orderStore.On("GetPriceRange", mock.Anything, since).Return(&l, &h, nil).Once()
+	}
+
+	// moves within 10% should not halt
+	priceRange(now.Add(-5*time.Minute), 100, 110)
+	s.checkVolatility(context.Background(), instrument, models.NewDecimal(110))
+	instrumentStore.AssertNotCalled(t, "Halt", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// 100 -> 111 moves 11%, whichever replica traded at 100
+	priceRange(now.Add(-5*time.Minute), 100, 111)
+	s.checkVolatility(context.Background(), instrument, models.NewDecimal(111))
+	instrumentStore.AssertExpectations(t)
+
+	// prices before the halt ended are left out
+	haltUntil := now.Add(-time.Minute)
+	instrument.Halted, instrument.HaltUntil = true, &haltUntil
+	priceRange(haltUntil, 111, 111)
+	s.checkVolatility(context.Background(), instrument, models.NewDecimal(111))
+	orderStore.AssertExpectations(t)
+}
+
+func TestResumeVolatility(t *testing.T) {
+	cache.Initialize(&cache.Config{
+		Type:   cache.TypeLocal,
+		Prefix: "local-dev",
+	})
+	defer cache.Finalize()
+	ctx := context.Background()
+
+	now := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	db := memory.NewDB(clock)
+	db.PutInstrument(&models.Instrument{
+		Symbol:                  config.GetString("INSTRUMENT"),
+		TickSize:                models.NewDecimal(1),
+		LotSize:                 models.NewDecimal(1),
+		MaxNotional:             models.NewDecimal(1000000),
+		VolatilityPercent:       10,
+		VolatilityWindowMinutes: 5,
+	})
+	instrumentStore := memory.NewInstrument(db)
+	s := NewOrder(memory.NewOrder(db), memory.NewPosition(db), instrumentStore, memory.NewUser(db), NewTemplate(), WithClock(clock))
+	trade := func(price int64) {
+		now = now.Add(time.Second)
+		require.NoError(t, s.Make(ctx, "maker", models.Sell, models.NewDecimal(price), models.NewDecimal(1)))
+		require.NoError(t, s.Take(ctx, "taker", models.Buy, models.NewDecimal(1)))
+	}
+	state := func() models.MarketState {
+		instrument, err := instrumentStore.Get(ctx, config.GetString("INSTRUMENT"))
+		require.NoError(t, err)
+		return instrument.State(now)
+	}
+
+	trade(100)
+	trade(120)
+	require.Equal(t, models.MarketHalted, state(), "expect 100 -> 120 to trip the circuit breaker")
+
+	now = now.Add(time.Minute)
+	require.NoError(t, instrumentStore.Resume(ctx, config.GetString("INSTRUMENT")))
+	trade(121)
+	require.Equal(t, models.MarketOpen, state(), "expect prices before the resume to be left out of the window")
+	trade(140)
+	require.Equal(t, models.MarketHalted, state(), "expect 121 -> 140 after the resume to trip the circuit breaker")
+}
+
+func TestOutboxRelay(t *testing.T) {
+	message := &models.OutboxMessage{
+		ID:          1,
+		AggregateID: "7849583d-197c-48de-b48a-ce81cc26eca2",
+		Topic:       "mail",
+		Payload:     json.RawMessage(`{"Address":"user@gmail.com","Content":"your order has been created"}`),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := new(mq.MockMQ)
+	q.On("SendWithContext", mock.Anything, "mail", message.Payload).Return(nil).Once()
+
+	outboxStore := new(store.MockOutbox)
+	outboxStore.On("Relay", mock.Anything, 10, mock.Anything).Run(func(args mock.Arguments) {
+		publish := args.Get(2).(func(*models.OutboxMessage) error)
+		require.NoError(t, publish(message))
+		cancel()
+	}).Return(1, nil).Once()
+
+	r := &outboxRelay{
+		o:        outboxStore,
+		q:        q,
+		interval: time.Millisecond,
+		batch:    10,
+	}
+	r.Run(ctx)
+
+	outboxStore.AssertExpectations(t)
+	q.AssertExpectations(t)
+}
+
+func TestNotifications(t *testing.T) {
+	userID := "7849583d-197c-48de-b48a-ce81cc26eca2"
+	email, phone := "user@gmail.com", "0911122233"
+
+	userStore := new(store.MockUser)
+	userStore.On("Get", mock.Anything, userID).Return(&models.User{
+		DisplayUser: models.DisplayUser{ID: userID},
+		Email:       &email,
+		Phone:       &phone,
+	}, nil)
+	userStore.On("GetNotificationPreferences", mock.Anything, userID).Return(&models.NotificationPreferences{
+		UserID: userID,
+		Email:  true,
+		SMS:    false,
+		Push:   true,
+	}, nil)
+	userStore.On("Get", mock.Anything, mock.Anything).Return(nil, models.ErrorNotFound)
+
+	s := &orderSvc{notifier: notifier{u: userStore, t: NewTemplate()}}
+	data := &models.NotificationData{
+		OrderID:  "0b5e0c9e-6f0a-4d8f-9a51-4b1a4c2f2b1e",
+		Action:   models.Buy,
+		Price:    models.NewDecimal(10),
+		Quantity: models.NewDecimal(100),
+	}
+	r, err := s.recipient(context.Background(), userID)
+	require.NoError(t, err)
+	messages, err := s.notifications(context.Background(), r, models.OrderCreated, data)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(messages), "expect only email to be sent, sms is disabled and push token is absent")
+	require.Equal(t, "mail", messages[0].Topic)
+	require.Equal(t, userID, messages[0].AggregateID)
+	mail := mailMessage{}
+	require.NoError(t, json.Unmarshal(messages[0].Payload, &mail))
+	require.Equal(t, email, mail.Address)
+	require.Equal(t, "Your order has been created", mail.Subject)
+	require.Contains(t, mail.Content, "buy order 0b5e0c9e-6f0a-4d8f-9a51-4b1a4c2f2b1e of 100.00 at 10.00")
+	require.Contains(t, mail.HTML, "<b>100.00</b>")
+
+	r, err = s.recipient(context.Background(), "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f")
+	require.NoError(t, err)
+	messages, err = s.notifications(context.Background(), r, models.OrderCreated, data)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(messages), "expect unknown user not to be notified")
+}
+
+func TestFillNotifications(t *testing.T) {
+	cache.Initialize(&cache.Config{
+		Type:   cache.TypeLocal,
+		Prefix: "local-dev",
+	})
+	defer cache.Finalize()
+	ctx := context.Background()
+
+	open, close := "09:00:00", "17:00:00"
+	now := time.Date(2026, 1, 2, 8, 55, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	db := memory.NewDB(clock)
+	db.PutInstrument(&models.Instrument{
+		Symbol:         config.GetString("INSTRUMENT"),
+		TickSize:       models.NewDecimal(1),
+		LotSize:        models.NewDecimal(1),
+		MaxNotional:    models.NewDecimal(1000000),
+		SessionOpen:    &open,
+		SessionClose:   &close,
+		AuctionMinutes: 10,
+	})
+	for _, userID := range []string{"maker", "buyer", "taker"} {
+		email := userID + "@example.com"
+		db.PutUser(&models.User{DisplayUser: models.DisplayUser{ID: userID}, Email: &email})
+	}
+	s := NewOrder(memory.NewOrder(db), memory.NewPosition(db), memory.NewInstrument(db), memory.NewUser(db), NewTemplate(), WithClock(clock))
+	// returns the subject and content of the mails sent to each user since the last call
+	mails := func() map[string][]mailMessage {
+		sent := map[string][]mailMessage{}
+		_, err := memory.NewOutbox(db).Relay(ctx, 100, func(m *models.OutboxMessage) error {
+			mail := mailMessage{}
+			require.NoError(t, json.Unmarshal(m.Payload, &mail))
+			sent[m.AggregateID] = append(sent[m.AggregateID], mail)
+			return nil
+		})
+		require.NoError(t, err)
+		return sent
+	}
+
+	// the resting sell order of the maker is filled by the opening auction
+	require.NoError(t, s.Make(ctx, "maker", models.Sell, models.NewDecimal(10), models.NewDecimal(5)))
+	require.NoError(t, s.Make(ctx, "buyer", models.Buy, models.NewDecimal(10), models.NewDecimal(2)))
+	mails()
+	now = time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	_, err := s.Uncross(ctx)
+	require.NoError(t, err)
+	sent := mails()
+	require.Equal(t, 1, len(sent["buyer"]))
+	require.Equal(t, 1, len(sent["maker"]), "expect the seller to be notified of the uncross")
+	require.Equal(t, "Your order has been filled", sent["maker"][0].Subject)
+	require.Contains(t, sent["maker"][0].Content, "sell order of 2.00 has been filled")
+
+	// and then taken
+	require.NoError(t, s.Take(ctx, "taker", models.Buy, models.NewDecimal(3)))
+	sent = mails()
+	require.Equal(t, 1, len(sent["taker"]))
+	require.Equal(t, 1, len(sent["maker"]), "expect the maker to be notified of the take")
+	require.Equal(t, "Your order has been filled", sent["maker"][0].Subject)
+	require.Contains(t, sent["maker"][0].Content, "sell order of 3.00 has been filled")
+}
+
+func TestRenderTemplate(t *testing.T) {
+	ctx := context.Background()
+	s := NewTemplate()
+	data := &models.NotificationData{
+		Action:   models.Sell,
+		Price:    models.NewDecimal(12),
+		Quantity: models.NewDecimal(30),
+		Fill: &models.FillSummary{
+			Quantity:     models.NewDecimal(20),
+			AveragePrice: models.NewDecimal(12),
+			Trades:       2,
+		},
+	}
+
+	n, err := s.Render(ctx, models.OrderFilled, "zh-TW", data)
+	require.NoError(t, err)
+	require.Equal(t, "您的委託已成交", n.Subject)
+	require.Equal(t, "賣出 20.00@12.00 已成交", n.SMS)
+	require.Equal(t, map[string]string{"event": "order_filled"}, n.Push.Data)
+
+	n, err = s.Render(ctx, models.OrderFilled, "zh", data)
+	require.NoError(t, err)
+	require.Equal(t, "您的委託已成交", n.Subject, "expect locale to match by language")
+
+	n, err = s.Render(ctx, models.OrderFilled, "fr-FR", data)
+	require.NoError(t, err)
+	require.Equal(t, "Your order has been filled", n.Subject, "expect unknown locale to fall back to default")
+
+	data.OrderID = "<script>"
+	n, err = s.Render(ctx, models.OrderCreated, DEFAULT_LOCALE, data)
+	require.NoError(t, err)
+	require.Contains(t, n.HTML, "&lt;script&gt;", "expect html to be escaped")
+	require.Contains(t, n.Text, "<script>")
+
+	data.Fill = nil
+	_, err = s.Render(ctx, models.OrderFilled, DEFAULT_LOCALE, data)
+	require.ErrorIs(t, err, models.ErrorWrongParams)
+}
+
+func TestParentDispatch(t *testing.T) {
+	ctx := context.Background()
+	userID := "7849583d-197c-48de-b48a-ce81cc26eca2"
+	startAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	parent := &models.ParentOrder{
+		ID:          "a8d1e9b6-5f3c-4f0e-9a63-0d2d7c1f4b11",
+		UserID:      userID,
+		Action:      models.Buy,
+		Quantity:    models.NewDecimal(10),
+		Slices:      3,
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Hour),
+		Status:      models.ParentActive,
+		NextSliceAt: &startAt,
+	}
+
+	instrumentStore := new(store.MockInstrument)
+	instrumentStore.On("Get", mock.Anything, mock.Anything).Return(&models.Instrument{
+		Symbol:  "DEMO",
+		LotSize: models.NewDecimal(1),
+	}, nil)
+	parentStore := new(store.MockParent)
+	parentStore.On("Dispatch", mock.Anything, mock.Anything, 10, mock.Anything).Run(func(args mock.Arguments) {
+		send := args.Get(3).(func(*models.ParentOrder) error)
+		require.NoError(t, send(parent))
+	}).Return(1, nil)
+	orderSvc := new(MockOrder)
+	orderSvc.On("Take", mock.Anything, userID, models.Buy, models.NewDecimal(3)).Return(models.ErrorMarketClosed).Once()
+	orderSvc.On("Take", mock.Anything, userID, models.Buy, models.NewDecimal(5)).Return(nil).Twice()
+
+	s := &parentSvc{
+		p:     parentStore,
+		i:     instrumentStore,
+		o:     orderSvc,
+		batch: 10,
+		now:   func() time.Time { return startAt },
+	}
+
+	// the failed first slice is spread over the remaining two
+	_, err := s.dispatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, models.Decimal(0), parent.SentQuantity)
+	require.Equal(t, models.ErrorMarketClosed.Error(), *parent.LastError)
+	require.Equal(t, startAt.Add(30*time.Minute), *parent.NextSliceAt)
+
+	_, err = s.dispatch(ctx)
+	require.NoError(t, err)
+	require.Nil(t, parent.LastError)
+	require.Equal(t, models.NewDecimal(5), parent.SentQuantity)
+
+	// the last slice takes whatever remains
+	_, err = s.dispatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(10), parent.SentQuantity)
+	require.Equal(t, models.ParentCompleted, parent.Status)
+	require.Nil(t, parent.NextSliceAt)
+	orderSvc.AssertExpectations(t)
+}
+
+func TestRFQ(t *testing.T) {
+	ctx := context.Background()
+	requesterID := "7849583d-197c-48de-b48a-ce81cc26eca2"
+	marketMakerID := "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f"
+	rfqID := "00000000-0000-4000-8000-000000000001"
+	token := "push-token"
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	instrument := &models.Instrument{
+		Symbol:   "DEMO",
+		TickSize: models.NewDecimal(1),
+		LotSize:  models.NewDecimal(1),
+	}
+	instrumentStore := new(store.MockInstrument)
+	instrumentStore.On("Get", mock.Anything, mock.Anything).Return(instrument, nil)
+	userStore := new(store.MockUser)
+	userStore.On("GetByType", mock.Anything, models.Official).Return([]*models.User{
+		{DisplayUser: models.DisplayUser{ID: marketMakerID}, Type: models.Official, PushToken: &token},
+		{DisplayUser: models.DisplayUser{ID: requesterID}, Type: models.Official},
+	}, nil)
+	userStore.On("Get", mock.Anything, marketMakerID).Return(&models.User{
+		DisplayUser: models.DisplayUser{ID: marketMakerID},
+		PushToken:   &token,
+	}, nil)
+	userStore.On("GetNotificationPreferences", mock.Anything, marketMakerID).Return(models.DefaultNotificationPreferences(marketMakerID), nil)
+
+	rfqStore := new(store.MockRFQ)
+	rfqStore.On("Create", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		messages := args.Get(2).([]*models.OutboxMessage)
+		require.Equal(t, 1, len(messages), "expect only the other market maker to be notified")
+		push := pushMessage{}
+		require.NoError(t, json.Unmarshal(messages[0].Payload, &push))
+		require.Equal(t, rfqID, push.Data["rfq_id"])
+	}).Return(nil).Once()
+
+	s := &rfqSvc{
+		r:        rfqStore,
+		i:        instrumentStore,
+		notifier: notifier{u: userStore, t: NewTemplate()},
+		ttl:      time.Minute,
+		quoteTTL: 10 * time.Second,
+		now:      func() time.Time { return now },
+		newID:    func() string { return rfqID },
+	}
+	rfq, err := s.Request(ctx, requesterID, models.Buy, models.NewDecimal(10000))
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Minute), rfq.ExpiresAt)
+	rfqStore.AssertExpectations(t)
+
+	rfqStore.On("Get", mock.Anything, rfqID).Return(rfq, nil)
+	rfqStore.On("CreateQuote", mock.Anything, mock.Anything, now).Return(nil).Once()
+	_, err = s.Quote(ctx, requesterID, rfqID, models.NewDecimal(10))
+	require.ErrorIs(t, err, models.ErrorNotAllowed, "expect requester not to quote its own rfq")
+	quote, err := s.Quote(ctx, marketMakerID, rfqID, models.NewDecimal(10))
+	require.NoError(t, err)
+	require.Equal(t, now.Add(10*time.Second), quote.ExpiresAt)
+
+	_, err = s.Accept(ctx, marketMakerID, rfqID, "00000000-0000-4000-8000-000000000002")
+	require.ErrorIs(t, err, models.ErrorNotFound, "expect only the requester to accept")
+	instrument.Halted = true
+	_, err = s.Accept(ctx, requesterID, rfqID, quote.ID)
+	require.ErrorIs(t, err, models.ErrorMarketHalted, "expect no block trade while halted")
+	rfqStore.AssertNotCalled(t, "Accept", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	instrument.Halted = false
+
+	now = now.Add(time.Minute)
+	_, err = s.Quote(ctx, marketMakerID, rfqID, models.NewDecimal(10))
+	require.ErrorIs(t, err, models.ErrorNotAllowed, "expect expired rfq not to be quoted")
+	rfqStore.AssertExpectations(t)
+}
+
+func TestLeader(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	ours := &models.Lease{Name: "matcher.DEMO", Holder: "a", Address: "http://a:8000", ExpiresAt: now.Add(10 * time.Second)}
+	theirs := &models.Lease{Name: "matcher.DEMO", Holder: "b", Address: "http://b:8000", ExpiresAt: now.Add(10 * time.Second)}
+
+	leaseStore := new(store.MockLease)
+	s := &leaderSvc{
+		l:       leaseStore,
+		name:    "matcher.DEMO",
+		holder:  "a",
+		address: "http://a:8000",
+		ttl:     10 * time.Second,
+		now:     func() time.Time { return now },
+	}
+	_, err := s.Leader(ctx)
+	require.ErrorIs(t, err, models.ErrorNoLeader, "expect no leader before campaigning")
+
+	leaseStore.On("Acquire", mock.Anything, "matcher.DEMO", "a", "http://a:8000", 10*time.Second).Return(ours, nil).Once()
+	require.True(t, s.campaign(ctx))
+	require.True(t, s.IsLeader())
+
+	// the lease is kept until it expires locally while the store is unreachable
+	leaseStore.On("Acquire", mock.Anything, "matcher.DEMO", "a", "http://a:8000", 10*time.Second).Return(nil, errors.New("connection refused")).Twice()
+	now = now.Add(5 * time.Second)
+	require.True(t, s.campaign(ctx))
+	now = now.Add(5 * time.Second)
+	require.False(t, s.campaign(ctx))
+	_, err = s.Leader(ctx)
+	require.ErrorIs(t, err, models.ErrorNoLeader, "expect expired lease of this process not to be forwarded to")
+
+	leaseStore.On("Acquire", mock.Anything, "matcher.DEMO", "a", "http://a:8000", 10*time.Second).Return(theirs, nil).Once()
+	require.False(t, s.campaign(ctx))
+	lease, err := s.Leader(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "http://b:8000", lease.Address)
+
+	// the lease of another process expires as well while the store is unreachable
+	leaseStore.On("Acquire", mock.Anything, "matcher.DEMO", "a", "http://a:8000", 10*time.Second).Return(nil, errors.New("connection refused")).Once()
+	now = now.Add(10 * time.Second)
+	require.False(t, s.campaign(ctx))
+	_, err = s.Leader(ctx)
+	require.ErrorIs(t, err, models.ErrorNoLeader, "expect expired lease of another process not to be forwarded to")
+	leaseStore.AssertExpectations(t)
+}
+
+func TestAdmin(t *testing.T) {
+	ctx := context.Background()
+	adminID := "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f"
+	userID := "7849583d-197c-48de-b48a-ce81cc26eca2"
+	orderID := "00000000-0000-4000-8000-000000000001"
+
+	orderStore := new(store.MockOrder)
+	orderStore.On("Get", mock.Anything, orderID).Return(&models.Order{ID: orderID, UserID: &userID}, nil)
+	auditSvc := new(MockAudit)
+	auditSvc.On("Record", mock.Anything, adminID, models.AuditAdminListOrders, "orders", mock.Anything).Return(errors.New("connection refused")).Once()
+	orderSvc := new(MockOrder)
+	orderSvc.On("ForceDelete", mock.Anything, orderID, mock.Anything).Run(func(args mock.Arguments) {
+		entry := args.Get(2).(*models.AuditEntry)
+		require.Equal(t, adminID, entry.ActorID)
+		require.Equal(t, models.AuditAdminCancelOrder, entry.Action)
+		require.Equal(t, orderID, entry.Target)
+		require.JSONEq(t, `{"reason":"fat finger","user_id":"`+userID+`"}`, string(entry.Detail))
+	}).Return(nil).Once()
+
+	s := NewAdmin(orderStore, new(store.MockPosition), auditSvc, orderSvc)
+	_, _, err := s.ListOrders(ctx, adminID, &models.OrderFilter{}, "", 10)
+	require.Error(t, err, "expect orders not to be listed without being audited")
+	orderStore.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	require.NoError(t, s.CancelOrder(ctx, adminID, orderID, "fat finger"))
+	orderSvc.AssertExpectations(t)
+	auditSvc.AssertExpectations(t)
+}
+
+func TestAudit(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "client_ip", "203.0.113.7")
+	// the HTTP middleware hashes the body as it is read, so the hash is taken when an entry is built
+	payloadHash := &strings.Builder{}
+	ctx = context.WithValue(ctx, "payload_hash", payloadHash)
+	adminID := "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f"
+
+	// a legacy entry recorded before chaining followed by a chain of three
+	entries := []*models.AuditEntry{{ID: 1, ActorID: adminID, Action: models.AuditAdminListOrders, Target: "orders"}}
+	prevHash := ""
+	for i := int64(2); i <= 4; i++ {
+		entry := &models.AuditEntry{
+			ID:        i,
+			ActorID:   adminID,
+			Action:    models.AuditAdminCancelOrder,
+			Target:    "00000000-0000-4000-8000-000000000001",
+			Detail:    json.RawMessage(`{"user_id": "u", "reason": "fat finger"}`),
+			CreatedAt: time.Date(2026, 1, 1, 0, 0, int(i), 0, time.UTC),
+		}
+		require.NoError(t, entry.Chain(prevHash))
+		prevHash = entry.Hash
+		entries = append(entries, entry)
+	}
+	auditStore := new(store.MockAudit)
+	auditStore.On("Scan", mock.Anything, int64(0), AUDIT_VERIFY_BATCH).Return(entries, nil)
+	auditStore.On("Record", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		entry := args.Get(1).(*models.AuditEntry)
+		require.Equal(t, models.AuditPermissionDenied, entry.Action)
+		require.Equal(t, "203.0.113.7", entry.IP, "expect IP to be taken from the request")
+		require.Equal(t, "8f434346", entry.PayloadHash, "expect hash of the payload read so far")
+	}).Return(nil).Once()
+
+	s := NewAudit(auditStore)
+	payloadHash.WriteString("8f434346")
+	require.NoError(t, s.Record(ctx, adminID, models.AuditPermissionDenied, "/admin/orders", nil))
+
+	result, err := s.Verify(ctx)
+	require.NoError(t, err)
+	require.True(t, result.Valid)
+	require.Equal(t, 4, result.Checked)
+	require.Equal(t, 1, result.Unchained)
+	require.Equal(t, prevHash, result.Head)
+
+	// detail re-encoded by the database keeps the chain intact, a changed reason breaks it
+	entries[2].Detail = json.RawMessage(`{"reason":"fat finger","user_id":"u"}`)
+	result, err = s.Verify(ctx)
+	require.NoError(t, err)
+	require.True(t, result.Valid)
+	entries[2].Detail = json.RawMessage(`{"reason":"no reason","user_id":"u"}`)
+	result, err = s.Verify(ctx)
+	require.NoError(t, err)
+	require.False(t, result.Valid)
+	require.Equal(t, int64(3), *result.BrokenID)
+	auditStore.AssertExpectations(t)
+}
+
+func TestTickers(t *testing.T) {
+	cache.Initialize(&cache.Config{
+		Type:   cache.TypeLocal,
+		Prefix: "local-dev",
+	})
+	defer cache.Finalize()
+	ctx := context.Background()
+	invalidateTickers(ctx)
+
+	tickerStore := new(store.MockTicker)
+	tickerStore.On("List", mock.Anything, mock.Anything).Return([]*models.Ticker{{
+		Symbol: "DEMO",
+		Open:   models.NewDecimal(10),
+		High:   models.NewDecimal(12),
+		Low:    models.NewDecimal(9),
+		Last:   models.NewDecimal(11),
+		Volume: models.NewDecimal(300),
+		VWAP:   models.NewDecimal(10),
+	}}, nil)
+	s := NewMarket(new(store.MockInstrument), tickerStore)
+
+	tickers, err := s.GetTickers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tickers))
+	require.Equal(t, models.NewDecimal(10), tickers[0].ChangePercent)
+	require.WithinDuration(t, time.Now().Add(-TICKER_WINDOW), tickers[0].Since, time.Minute)
+
+	// the local cache applies sets asynchronously
+	time.Sleep(10 * time.Millisecond)
+	_, err = s.GetTickers(ctx)
+	require.NoError(t, err)
+	tickerStore.AssertNumberOfCalls(t, "List", 1)
+
+	invalidateTickers(ctx)
+	_, err = s.GetTickers(ctx)
+	require.NoError(t, err)
+	tickerStore.AssertNumberOfCalls(t, "List", 2)
+}
+
+func TestSetTimeZone(t *testing.T) {
+	ctx := context.Background()
+	instrumentStore := new(store.MockInstrument)
+	instrumentStore.On("SetTimeZone", mock.Anything, "DEMO", "Asia/Taipei").Return(nil).Once()
+	s := NewMarket(instrumentStore, new(store.MockTicker))
+
+	require.NoError(t, s.SetTimeZone(ctx, "DEMO", "Asia/Taipei"))
+	var fieldErr *models.FieldError
+	require.ErrorAs(t, s.SetTimeZone(ctx, "DEMO", "Taipei"), &fieldErr, "expect unknown time zone to be rejected")
+	require.Equal(t, "time_zone", fieldErr.Field)
+	instrumentStore.AssertExpectations(t)
+}
+
+func TestExportHistory(t *testing.T) {
+	ctx := context.Background()
+	userID := "7849583d-197c-48de-b48a-ce81cc26eca2"
+	otherID := "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f"
+	closedAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	orderStore := new(store.MockOrder)
+	orderStore.On("List", mock.Anything, mock.Anything, "", EXPORT_PAGE_SIZE).Return([]*models.Order{
+		{ID: "order", UserID: &userID, Action: models.Sell, Price: models.NewDecimal(12), Quantity: models.NewDecimal(1), Status: models.OrderOpen},
+		{ID: "filled", UserID: &userID, Action: models.Sell, Price: models.NewDecimal(11), Status: models.OrderFullyFilled, ClosedAt: &closedAt},
+	}, "", nil)
+	positionStore := new(store.MockPosition)
+	positionStore.On("ListTrades", mock.Anything, mock.Anything, "", EXPORT_PAGE_SIZE).Return([]*models.Trade{
+		{ID: "taken", TakerID: userID, Action: models.Buy, Price: models.NewDecimal(11), Quantity: models.NewDecimal(2)},
+	}, "taken", nil)
+	positionStore.On("ListTrades", mock.Anything, mock.Anything, "taken", EXPORT_PAGE_SIZE).Return([]*models.Trade{
+		{ID: "made", MakerID: &userID, TakerID: otherID, Action: models.Buy, Price: models.NewDecimal(12), Quantity: models.NewDecimal(1)},
+	}, "", nil)
+	s := &orderSvc{c: orderStore, p: positionStore}
+
+	pages := [][]*models.HistoryRecord{}
+	require.NoError(t, s.ExportHistory(ctx, userID, nil, nil, func(records []*models.HistoryRecord) error {
+		pages = append(pages, records)
+		return nil
+	}))
+	require.Equal(t, 3, len(pages), "expect a page per store read")
+	require.Equal(t, models.HistoryOrder, pages[0][0].Kind)
+	require.Equal(t, 2, len(pages[0]), "expect filled orders to be exported along with open ones")
+	require.Equal(t, models.OrderFullyFilled, pages[0][1].Status)
+	require.Equal(t, "2026-01-01T09:00:00Z", pages[0][1].CSV()[len(models.HistoryCSVHeader)-1])
+	require.Equal(t, "taker", pages[1][0].Role)
+	require.Equal(t, "maker", pages[2][0].Role)
+	require.Equal(t, models.Sell, pages[2][0].Action, "expect makers to take the other side of the taker")
+
+	from := time.Now()
+	require.ErrorIs(t, s.ExportHistory(ctx, userID, &from, &from, nil), models.ErrorWrongParams)
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	userID := "7849583d-197c-48de-b48a-ce81cc26eca2"
+	orderID := "00000000-0000-4000-8000-000000000001"
+
+	orderStore := new(store.MockOrder)
+	orderStore.On("GetBestPrice", mock.Anything, mock.Anything).Return(nil, nil)
+	orderStore.On("GetLatestPrice", mock.Anything).Return(nil, nil)
+	instrumentStore := new(store.MockInstrument)
+	instrumentStore.On("Get", mock.Anything, mock.Anything).Return(&models.Instrument{
+		Symbol:   "DEMO",
+		TickSize: models.NewDecimal(1),
+		LotSize:  models.NewDecimal(1),
+	}, nil)
+	userStore := new(store.MockUser)
+	userStore.On("Get", mock.Anything, userID).Return(nil, models.ErrorNotFound)
+	n := 0
+	s := &orderSvc{
+		c:            orderStore,
+		i:            instrumentStore,
+		notifier:     notifier{u: userStore},
+		now:          time.Now,
+		newID:        func() string { n++; return fmt.Sprintf("order-%d", n) },
+		maxBatchSize: 3,
+	}
+	bid := &models.BatchOperation{Type: models.BatchMake, Action: models.Buy, Price: models.NewDecimal(10), Quantity: models.NewDecimal(1)}
+	ask := &models.BatchOperation{Type: models.BatchMake, Action: models.Sell, Price: models.NewDecimal(10), Quantity: models.NewDecimal(1)}
+	cancel := &models.BatchOperation{Type: models.BatchCancel, OrderID: orderID}
+
+	_, err := s.Batch(ctx, userID, []*models.BatchOperation{bid, bid, bid, bid}, false)
+	require.ErrorIs(t, err, models.ErrorWrongParams, "expect batch over the maximum size to be rejected")
+
+	// one by one, a failed operation does not stop the others
+	orderStore.On("Batch", mock.Anything, userID, mock.MatchedBy(func(items []*models.BatchItem) bool {
+		return len(items) == 1 && items[0].OrderID == orderID
+	})).Return(&models.BatchError{Index: 0, Err: models.ErrorNotFound}).Once()
+	orderStore.On("Batch", mock.Anything, userID, mock.MatchedBy(func(items []*models.BatchItem) bool {
+		return len(items) == 1 && items[0].Order != nil
+	})).Return(nil).Twice()
+	results, err := s.Batch(ctx, userID, []*models.BatchOperation{cancel, bid, bid}, false)
+	require.NoError(t, err)
+	require.Equal(t, models.BatchFailed, results[0].Status)
+	require.Equal(t, models.ErrorNotFound.Error(), results[0].Error)
+	require.Equal(t, models.BatchDone, results[1].Status)
+	require.Equal(t, "order-1", results[1].OrderID)
+	require.Equal(t, models.BatchDone, results[2].Status)
+
+	// all or nothing, the ask crossing the bid of the same batch aborts the batch
+	results, err = s.Batch(ctx, userID, []*models.BatchOperation{bid, ask, cancel}, true)
+	require.NoError(t, err)
+	require.Equal(t, models.BatchAborted, results[0].Status)
+	require.Equal(t, models.BatchFailed, results[1].Status)
+	require.Equal(t, "price", results[1].Field)
+	require.Equal(t, models.BatchAborted, results[2].Status)
+
+	// the failing item of a batch executed at once is reported by the store
+	orderStore.On("Batch", mock.Anything, userID, mock.MatchedBy(func(items []*models.BatchItem) bool {
+		return len(items) == 2
+	})).Return(&models.BatchError{Index: 1, Err: models.ErrorNotFound}).Once()
+	results, err = s.Batch(ctx, userID, []*models.BatchOperation{bid, cancel}, true)
+	require.NoError(t, err)
+	require.Equal(t, models.BatchAborted, results[0].Status)
+	require.Equal(t, models.BatchFailed, results[1].Status)
+	orderStore.AssertExpectations(t)
+}
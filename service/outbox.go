@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+	"github.com/A-pen-app/mq"
+)
+
+// newOutboxMessage encodes data as a message of topic to be published for given aggregate
+func newOutboxMessage(aggregateID, topic string, data interface{}) (*models.OutboxMessage, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OutboxMessage{
+		AggregateID: aggregateID,
+		Topic:       topic,
+		Payload:     payload,
+	}, nil
+}
+
+type outboxRelay struct {
+	o        store.Outbox
+	q        mq.MQ
+	interval time.Duration
+	batch    int
+}
+
+// NewOutboxRelay returns an implementation of service.Relay which publishes outbox messages to q
+func NewOutboxRelay(o store.Outbox, q mq.MQ) Relay {
+	return &outboxRelay{
+		o:        o,
+		q:        q,
+		interval: config.GetMilliseconds("OUTBOX_RELAY_INTERVAL_MS"),
+		batch:    config.GetInt("OUTBOX_RELAY_BATCH_SIZE"),
+	}
+}
+
+func (r *outboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// keep relaying while there are more pending messages than a batch
+		for {
+			published, err := r.o.Relay(ctx, r.batch, func(m *models.OutboxMessage) error {
+				return r.q.SendWithContext(ctx, m.Topic, m.Payload)
+			})
+			if err != nil {
+				logging.Errorw(ctx, "relay outbox messages failed", "err", err)
+				break
+			}
+			if published < r.batch || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+)
+
+type parentSvc struct {
+	Instrument string
+	p          store.Parent
+	i          store.Instrument
+	o          Order
+	interval   time.Duration
+	batch      int
+	now        func() time.Time
+}
+
+// NewParent returns an implementation of service.Parent sending child orders through o
+func NewParent(p store.Parent, i store.Instrument, o Order) Parent {
+	return &parentSvc{
+		Instrument: config.GetString("INSTRUMENT"),
+		p:          p,
+		i:          i,
+		o:          o,
+		interval:   config.GetMilliseconds("PARENT_ORDER_INTERVAL_MS"),
+		batch:      config.GetInt("PARENT_ORDER_BATCH_SIZE"),
+		now:        time.Now,
+	}
+}
+
+func (s *parentSvc) MakeTWAP(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, limitPrice *models.Decimal, startAt, endAt time.Time, slices int) (*models.ParentOrder, error) {
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	now := s.now()
+	if startAt.IsZero() || startAt.Before(now) {
+		startAt = now
+	}
+	if err := checkTWAP(instrument, action, quantity, limitPrice, startAt, endAt, slices); err != nil {
+		logging.Errorw(ctx, "service make parent order rejected by trading rules", "err", err)
+		return nil, err
+	}
+
+	parent := &models.ParentOrder{
+		UserID:      userID,
+		Action:      action,
+		Quantity:    quantity,
+		LimitPrice:  limitPrice,
+		Slices:      slices,
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Status:      models.ParentActive,
+		NextSliceAt: &startAt,
+	}
+	if err := s.p.Create(ctx, parent); err != nil {
+		logging.Errorw(ctx, "service make parent order failed", "err", err)
+		return nil, err
+	}
+	return parent, nil
+}
+
+func (s *parentSvc) GetParent(ctx context.Context, userID, parentID string) (*models.ParentOrder, error) {
+	parent, err := s.p.Get(ctx, parentID)
+	if err != nil {
+		logging.Errorw(ctx, "service get parent order failed", "err", err, "parentID", parentID)
+		return nil, err
+	}
+	// hides the existence of other users' orders
+	if parent.UserID != userID {
+		return nil, models.ErrorNotFound
+	}
+	return parent, nil
+}
+
+func (s *parentSvc) Pause(ctx context.Context, userID, parentID string) (*models.ParentOrder, error) {
+	return s.updateStatus(ctx, userID, parentID, []models.ParentOrderStatus{models.ParentActive}, models.ParentPaused)
+}
+
+func (s *parentSvc) Resume(ctx context.Context, userID, parentID string) (*models.ParentOrder, error) {
+	return s.updateStatus(ctx, userID, parentID, []models.ParentOrderStatus{models.ParentPaused}, models.ParentActive)
+}
+
+func (s *parentSvc) Cancel(ctx context.Context, userID, parentID string) (*models.ParentOrder, error) {
+	return s.updateStatus(ctx, userID, parentID, []models.ParentOrderStatus{models.ParentActive, models.ParentPaused}, models.ParentCancelled)
+}
+
+// updateStatus moves a parent order of given user from one of the statuses in from to status to
+func (s *parentSvc) updateStatus(ctx context.Context, userID, parentID string, from []models.ParentOrderStatus, to models.ParentOrderStatus) (*models.ParentOrder, error) {
+	parent, err := s.GetParent(ctx, userID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := false
+	for _, status := range from {
+		allowed = allowed || parent.Status == status
+	}
+	if !allowed {
+		logging.Errorw(ctx, "service update parent order status not allowed", "parentID", parentID, "from", parent.Status, "to", to)
+		return nil, models.ErrorNotAllowed
+	}
+	parent, err = s.p.UpdateStatus(ctx, parentID, from, to)
+	if err != nil {
+		logging.Errorw(ctx, "service update parent order status failed", "err", err, "parentID", parentID, "status", to)
+		return nil, err
+	}
+	return parent, nil
+}
+
+func (s *parentSvc) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// keep dispatching while there are more due parent orders than a batch
+		for {
+			dispatched, err := s.dispatch(ctx)
+			if err != nil {
+				logging.Errorw(ctx, "dispatch parent orders failed", "err", err)
+				break
+			}
+			if dispatched < s.batch || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+}
+
+// dispatch sends the next slice of every due parent order, a slice failing to be sent is skipped
+// and its quantity is spread over the remaining slices.
+func (s *parentSvc) dispatch(ctx context.Context) (int, error) {
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return 0, err
+	}
+	return s.p.Dispatch(ctx, s.now(), s.batch, func(parent *models.ParentOrder) error {
+		quantity := parent.NextSliceQuantity(instrument.LotSize)
+		var err error
+		switch {
+		case quantity <= 0:
+		case parent.LimitPrice == nil:
+			err = s.o.Take(ctx, parent.UserID, parent.Action, quantity)
+		default:
+			err = s.o.Make(ctx, parent.UserID, parent.Action, *parent.LimitPrice, quantity)
+		}
+		if err != nil {
+			logging.Errorw(ctx, "service send child order failed", "err", err, "parentID", parent.ID, "slice", parent.SlicesSent)
+			quantity = 0
+		}
+		parent.Sent(quantity, err)
+		return nil
+	})
+}
+
+// checkTWAP verifies a parent order sending slices child orders evenly over [startAt, endAt]
+func checkTWAP(instrument *models.Instrument, action models.OrderAction, quantity models.Decimal, limitPrice *models.Decimal, startAt, endAt time.Time, slices int) error {
+	if limitPrice == nil && action != models.Buy {
+		return &models.FieldError{
+			Field:  "limit_price",
+			Reason: fmt.Sprintf("is required by %s parent orders, only buy child orders can take", action),
+		}
+	}
+	if limitPrice != nil && (*limitPrice <= 0 || *limitPrice%instrument.TickSize != 0) {
+		return &models.FieldError{
+			Field:  "limit_price",
+			Reason: fmt.Sprintf("should be a positive multiple of tick size %s", instrument.TickSize),
+		}
+	}
+	if err := checkQuantity(instrument, quantity); err != nil {
+		return err
+	}
+	if slices < 1 || quantity/models.Decimal(slices) < instrument.LotSize {
+		return &models.FieldError{
+			Field:  "slices",
+			Reason: fmt.Sprintf("should be within [1, quantity / lot size %s]", instrument.LotSize),
+		}
+	}
+	if endAt.Before(startAt) || (slices > 1 && !endAt.After(startAt)) {
+		return &models.FieldError{
+			Field:  "end_at",
+			Reason: "should be after start_at",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+	"github.com/google/uuid"
+)
+
+type rfqSvc struct {
+	Instrument string
+	r          store.RFQ
+	i          store.Instrument
+	notifier
+	// how long an RFQ collects quotes, and how long a quote stays valid within it
+	ttl      time.Duration
+	quoteTTL time.Duration
+	now      func() time.Time
+	newID    func() string
+}
+
+// NewRFQ returns an implementation of service.RFQ
+func NewRFQ(r store.RFQ, i store.Instrument, u store.User, t Template) RFQ {
+	return &rfqSvc{
+		Instrument: config.GetString("INSTRUMENT"),
+		r:          r,
+		i:          i,
+		notifier:   notifier{u: u, t: t},
+		ttl:        config.GetMilliseconds("RFQ_TTL_MS"),
+		quoteTTL:   config.GetMilliseconds("RFQ_QUOTE_TTL_MS"),
+		now:        time.Now,
+		newID:      uuid.NewString,
+	}
+}
+
+func (s *rfqSvc) Request(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal) (*models.RFQ, error) {
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	if err := checkMarket(instrument, s.now()); err != nil {
+		logging.Errorw(ctx, "service request quote rejected by market state", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	if err := checkQuantity(instrument, quantity); err != nil {
+		logging.Errorw(ctx, "service request quote rejected by trading rules", "err", err)
+		return nil, err
+	}
+
+	rfq := &models.RFQ{
+		ID:          s.newID(),
+		RequesterID: userID,
+		Action:      action,
+		Quantity:    quantity,
+		Status:      models.RFQOpen,
+		ExpiresAt:   s.now().Add(s.ttl),
+		Quotes:      []*models.Quote{},
+	}
+
+	// broadcasts the request to every market maker but the requester
+	marketMakers, err := s.u.GetByType(ctx, models.Official)
+	if err != nil {
+		logging.Errorw(ctx, "service get market makers failed", "err", err)
+		return nil, err
+	}
+	messages := []*models.OutboxMessage{}
+	for _, marketMaker := range marketMakers {
+		if marketMaker.ID == userID {
+			continue
+		}
+		r, err := s.recipient(ctx, marketMaker.ID)
+		if err != nil {
+			return nil, err
+		}
+		m, err := s.notifications(ctx, r, models.RFQRequested, &models.NotificationData{
+			Action:    action,
+			Quantity:  quantity,
+			RFQID:     rfq.ID,
+			ExpiresAt: &rfq.ExpiresAt,
+		})
+		if err != nil {
+			logging.Errorw(ctx, "service build rfq notifications failed", "err", err)
+			return nil, err
+		}
+		messages = append(messages, m...)
+	}
+
+	if err := s.r.Create(ctx, rfq, messages); err != nil {
+		logging.Errorw(ctx, "service create rfq failed", "err", err)
+		return nil, err
+	}
+	logging.Infow(ctx, "rfq broadcast", "rfqID", rfq.ID, "marketMakers", len(marketMakers))
+	return rfq, nil
+}
+
+func (s *rfqSvc) GetRFQ(ctx context.Context, userID string, scope models.UserType, rfqID string) (*models.RFQ, error) {
+	rfq, err := s.r.Get(ctx, rfqID)
+	if err != nil {
+		logging.Errorw(ctx, "service get rfq failed", "err", err, "rfqID", rfqID)
+		return nil, err
+	}
+	// only the requester and the market makers know about the RFQ
+	if rfq.RequesterID != userID && scope < models.Official {
+		return nil, models.ErrorNotFound
+	}
+	quotes, err := s.r.GetQuotes(ctx, rfqID)
+	if err != nil {
+		logging.Errorw(ctx, "service get quotes failed", "err", err, "rfqID", rfqID)
+		return nil, err
+	}
+	// market makers only see their own quotes
+	rfq.Quotes = []*models.Quote{}
+	for _, quote := range quotes {
+		if rfq.RequesterID == userID || quote.MarketMakerID == userID {
+			rfq.Quotes = append(rfq.Quotes, quote)
+		}
+	}
+	rfq.Expire(s.now())
+	return rfq, nil
+}
+
+func (s *rfqSvc) Quote(ctx context.Context, userID, rfqID string, price models.Decimal) (*models.Quote, error) {
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	if price <= 0 || price%instrument.TickSize != 0 {
+		return nil, &models.FieldError{
+			Field:  "price",
+			Reason: fmt.Sprintf("should be a positive multiple of tick size %s", instrument.TickSize),
+		}
+	}
+	rfq, err := s.r.Get(ctx, rfqID)
+	if err != nil {
+		logging.Errorw(ctx, "service get rfq failed", "err", err, "rfqID", rfqID)
+		return nil, err
+	}
+	if rfq.RequesterID == userID {
+		logging.Errorw(ctx, "service quote own rfq not allowed", "rfqID", rfqID)
+		return nil, models.ErrorNotAllowed
+	}
+	now := s.now()
+	if !rfq.Expire(now) {
+		logging.Errorw(ctx, "service quote rfq not allowed", "rfqID", rfqID, "status", rfq.Status)
+		return nil, models.ErrorNotAllowed
+	}
+
+	quote := &models.Quote{
+		RFQID:         rfqID,
+		MarketMakerID: userID,
+		Price:         price,
+		ExpiresAt:     now.Add(s.quoteTTL),
+	}
+	if quote.ExpiresAt.After(rfq.ExpiresAt) {
+		quote.ExpiresAt = rfq.ExpiresAt
+	}
+	if err := s.r.CreateQuote(ctx, quote, now); err != nil {
+		logging.Errorw(ctx, "service create quote failed", "err", err, "rfqID", rfqID)
+		return nil, err
+	}
+	return quote, nil
+}
+
+func (s *rfqSvc) Accept(ctx context.Context, userID, rfqID, quoteID string) (*models.Trade, error) {
+	rfq, err := s.r.Get(ctx, rfqID)
+	if err != nil {
+		logging.Errorw(ctx, "service get rfq failed", "err", err, "rfqID", rfqID)
+		return nil, err
+	}
+	if rfq.RequesterID != userID {
+		return nil, models.ErrorNotFound
+	}
+	// block trades are only executed while trading is allowed, like trades on the board
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	if err := checkMarket(instrument, s.now()); err != nil {
+		logging.Errorw(ctx, "service accept quote rejected by market state", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+
+	// both parties are notified of the fill
+	outbox := func(trades []*models.Trade) ([]*models.OutboxMessage, error) {
+		messages := []*models.OutboxMessage{}
+		for _, trade := range trades {
+			summary, err := models.NewFillSummary([]*models.Trade{trade})
+			if err != nil {
+				logging.Errorw(ctx, "service summarize fills failed", "err", err)
+				return nil, err
+			}
+			marketMakerAction := models.Sell
+			if trade.Action == models.Sell {
+				marketMakerAction = models.Buy
+			}
+			for userID, action := range map[string]models.OrderAction{
+				trade.TakerID:  trade.Action,
+				*trade.MakerID: marketMakerAction,
+			} {
+				r, err := s.recipient(ctx, userID)
+				if err != nil {
+					return nil, err
+				}
+				m, err := s.notifications(ctx, r, models.OrderFilled, &models.NotificationData{
+					Action:   action,
+					Price:    trade.Price,
+					Quantity: trade.Quantity,
+					Fill:     summary,
+				})
+				if err != nil {
+					return nil, err
+				}
+				messages = append(messages, m...)
+			}
+		}
+		return messages, nil
+	}
+
+	trade, err := s.r.Accept(ctx, rfqID, quoteID, s.now(), outbox)
+	if err != nil {
+		logging.Errorw(ctx, "service accept quote failed", "err", err, "rfqID", rfqID, "quoteID", quoteID)
+		return nil, err
+	}
+	logging.Infow(ctx, "quote accepted", "rfqID", rfqID, "quoteID", quoteID, "tradeID", trade.ID)
+	return trade, nil
+}
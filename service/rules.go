@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/A-pen-app/kickstart/models"
+)
+
+// checkQuantity verifies quantity of an order against the lot size of the instrument
+func checkQuantity(instrument *models.Instrument, quantity models.Decimal) error {
+	if quantity%instrument.LotSize != 0 {
+		return &models.FieldError{
+			Field:  "quantity",
+			Reason: fmt.Sprintf("should be a multiple of lot size %s", instrument.LotSize),
+		}
+	}
+	return nil
+}
+
+// checkLimitOrder verifies price and quantity of a limit order against the trading rules of the instrument
+func checkLimitOrder(instrument *models.Instrument, price, quantity, latestPrice models.Decimal) error {
+	if price%instrument.TickSize != 0 {
+		return &models.FieldError{
+			Field:  "price",
+			Reason: fmt.Sprintf("should be a multiple of tick size %s", instrument.TickSize),
+		}
+	}
+	if band := int64(instrument.PriceBandPercent); band > 0 {
+		low, err := latestPrice.MulDiv(models.NewDecimal(100-band), models.NewDecimal(100))
+		if err != nil {
+			return err
+		}
+		high, err := latestPrice.MulDiv(models.NewDecimal(100+band), models.NewDecimal(100))
+		if err != nil {
+			return err
+		}
+		if price < low || price > high {
+			return &models.FieldError{
+				Field:  "price",
+				Reason: fmt.Sprintf("should be within [%s, %s], %d%% around latest price %s", low, high, band, latestPrice),
+			}
+		}
+	}
+	if err := checkQuantity(instrument, quantity); err != nil {
+		return err
+	}
+	notional, err := price.Mul(quantity)
+	if err != nil {
+		return &models.FieldError{
+			Field:  "quantity",
+			Reason: "notional overflows",
+		}
+	}
+	if instrument.MinNotional > 0 && notional < instrument.MinNotional {
+		return &models.FieldError{
+			Field:  "quantity",
+			Reason: fmt.Sprintf("notional %s should not be lower than %s", notional, instrument.MinNotional),
+		}
+	}
+	if instrument.MaxNotional > 0 && notional > instrument.MaxNotional {
+		return &models.FieldError{
+			Field:  "quantity",
+			Reason: fmt.Sprintf("notional %s should not be higher than %s", notional, instrument.MaxNotional),
+		}
+	}
+	return nil
+}
+
+// checkDisplayQuantity verifies the display quantity of an iceberg order of given quantity
+func checkDisplayQuantity(instrument *models.Instrument, displayQuantity, quantity models.Decimal) error {
+	if displayQuantity <= 0 || displayQuantity >= quantity {
+		return &models.FieldError{
+			Field:  "display_quantity",
+			Reason: fmt.Sprintf("should be positive and lower than quantity %s", quantity),
+		}
+	}
+	if displayQuantity%instrument.LotSize != 0 {
+		return &models.FieldError{
+			Field:  "display_quantity",
+			Reason: fmt.Sprintf("should be a multiple of lot size %s", instrument.LotSize),
+		}
+	}
+	return nil
+}
+
+// checkStopPrice verifies the stop price of a stop order, which should not be reached by the latest price yet
+func checkStopPrice(instrument *models.Instrument, action models.OrderAction, stopPrice, latestPrice models.Decimal) error {
+	if stopPrice <= 0 || stopPrice%instrument.TickSize != 0 {
+		return &models.FieldError{
+			Field:  "stop_price",
+			Reason: fmt.Sprintf("should be a positive multiple of tick size %s", instrument.TickSize),
+		}
+	}
+	if action == models.Sell && stopPrice >= latestPrice {
+		return &models.FieldError{
+			Field:  "stop_price",
+			Reason: fmt.Sprintf("of sell stop should be lower than latest price %s", latestPrice),
+		}
+	}
+	if action == models.Buy && stopPrice <= latestPrice {
+		return &models.FieldError{
+			Field:  "stop_price",
+			Reason: fmt.Sprintf("of buy stop should be higher than latest price %s", latestPrice),
+		}
+	}
+	return nil
+}
+
+// checkTrail verifies the trail of a trailing stop order, exactly one of amount and percent should be given
+func checkTrail(instrument *models.Instrument, amount, percent *models.Decimal, limitOffset models.Decimal) error {
+	if (amount == nil) == (percent == nil) {
+		return &models.FieldError{
+			Field:  "trail_amount",
+			Reason: "or trail_percent should be given, but not both",
+		}
+	}
+	if amount != nil && (*amount <= 0 || *amount%instrument.TickSize != 0) {
+		return &models.FieldError{
+			Field:  "trail_amount",
+			Reason: fmt.Sprintf("should be a positive multiple of tick size %s", instrument.TickSize),
+		}
+	}
+	if percent != nil && (*percent <= 0 || *percent >= models.NewDecimal(100)) {
+		return &models.FieldError{
+			Field:  "trail_percent",
+			Reason: "should be within (0, 100)",
+		}
+	}
+	if limitOffset < 0 || limitOffset%instrument.TickSize != 0 {
+		return &models.FieldError{
+			Field:  "limit_offset",
+			Reason: fmt.Sprintf("should be a non-negative multiple of tick size %s", instrument.TickSize),
+		}
+	}
+	return nil
+}
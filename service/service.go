@@ -19,6 +19,46 @@ func WithTTL(duration time.Duration) IssueOption {
 	}
 }
 
+type makeOption struct {
+	displayQuantity *models.Decimal
+	postOnly        bool
+}
+type MakeOption func(*makeOption) error
+
+// WithDisplayQuantity makes an iceberg order only showing given quantity at a time
+func WithDisplayQuantity(quantity models.Decimal) MakeOption {
+	return func(opt *makeOption) error {
+		opt.displayQuantity = &quantity
+		return nil
+	}
+}
+
+// WithPostOnly makes an order that never matches immediately, it is rejected or repriced
+// one tick away from the other side of the board if it would
+func WithPostOnly() MakeOption {
+	return func(opt *makeOption) error {
+		opt.postOnly = true
+		return nil
+	}
+}
+
+// OrderOption customizes the order service, mainly to replay orders deterministically
+type OrderOption func(*orderSvc)
+
+// WithClock makes the order service read the current time from now instead of the wall clock
+func WithClock(now func() time.Time) OrderOption {
+	return func(s *orderSvc) {
+		s.now = now
+	}
+}
+
+// WithOrderIDs makes the order service generate order IDs with newID instead of random UUIDs
+func WithOrderIDs(newID func() string) OrderOption {
+	return func(s *orderSvc) {
+		s.newID = newID
+	}
+}
+
 type Auth interface {
 	// IssueToken returns a JWT for given userID
 	IssueToken(ctx context.Context, userID string, userType models.UserType, options ...IssueOption) (string, error)
@@ -28,7 +68,120 @@ type Auth interface {
 
 type Order interface {
 	GetBoard(ctx context.Context, boardType models.OrderBoardType) (*models.Board, string, error)
-	Make(ctx context.Context, action models.OrderAction, price, quantity int) error
-	Take(ctx context.Context, action models.OrderAction, quantity int) error
-	Delete(ctx context.Context, orderID string) error
+	Make(ctx context.Context, userID string, action models.OrderAction, price, quantity models.Decimal, options ...MakeOption) error
+	// MakeOCO makes a take-profit limit order and a stop-loss stop-limit order linked as one-cancels-other,
+	// filling either of them reduces the other by the filled quantity.
+	MakeOCO(ctx context.Context, userID string, action models.OrderAction, quantity, takeProfitPrice, stopPrice, stopLimitPrice models.Decimal) ([]*models.Order, error)
+	// MakeTrailingStop makes a stop-limit order whose stop price follows the latest price by either trailAmount
+	// or trailPercent, and whose limit price stays limitOffset away from its stop price.
+	MakeTrailingStop(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, trailAmount, trailPercent *models.Decimal, limitOffset models.Decimal) (*models.Order, error)
+	// GetOrder returns an order of given user along with the other orders of its one-cancels-other group
+	GetOrder(ctx context.Context, userID, orderID string) (*models.Order, []*models.Order, error)
+	Take(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal) error
+	// Delete deletes an order of the user along with the other orders of its one-cancels-other group,
+	// it returns ErrorNotFound if the user has no such order
+	Delete(ctx context.Context, userID, orderID string) error
+	// ForceDelete deletes an order of any user on behalf of an operator, recording audit along with it
+	ForceDelete(ctx context.Context, orderID string, audit *models.AuditEntry) error
+	// ForceDeleteUser deletes all orders of a user recording audit along with them, it returns
+	// the number of deleted orders
+	ForceDeleteUser(ctx context.Context, userID string, audit *models.AuditEntry) (int, error)
+	// Batch makes and cancels orders of a user in the order of operations and returns the result of each.
+	// Operations are executed one by one unless atomic is set, in which case they are executed all at
+	// once in a single transaction or not at all.
+	Batch(ctx context.Context, userID string, operations []*models.BatchOperation, atomic bool) ([]*models.BatchResult, error)
+	// ExportHistory passes the orders, including filled and cancelled ones, and then the fills of a user
+	// created within [from, to) to write a page at a time, so the history is never loaded all at once.
+	ExportHistory(ctx context.Context, userID string, from, to *time.Time, write func([]*models.HistoryRecord) error) error
+	// GetPosition returns the position of given user marked at the latest price
+	GetPosition(ctx context.Context, userID string) (*models.Position, error)
+	// Uncross matches the orders accumulated during the call phase of an auction at a single clearing price
+	// once the call phase ends, it returns nil if the board is not to be uncrossed.
+	Uncross(ctx context.Context) (*models.Auction, error)
+	// Run uncrosses the board whenever a call phase ends until ctx is done
+	Run(ctx context.Context)
+}
+
+type Parent interface {
+	// MakeTWAP makes a parent order sending slices child orders of even quantity evenly over [startAt, endAt],
+	// child orders are takes, or limit orders at limitPrice if it is given. It starts now if startAt is zero.
+	MakeTWAP(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, limitPrice *models.Decimal, startAt, endAt time.Time, slices int) (*models.ParentOrder, error)
+	// GetParent returns a parent order of given user along with its progress
+	GetParent(ctx context.Context, userID, parentID string) (*models.ParentOrder, error)
+	Pause(ctx context.Context, userID, parentID string) (*models.ParentOrder, error)
+	Resume(ctx context.Context, userID, parentID string) (*models.ParentOrder, error)
+	// Cancel stops sending child orders, the child orders already sent are kept
+	Cancel(ctx context.Context, userID, parentID string) (*models.ParentOrder, error)
+	// Run sends the child orders of due parent orders until ctx is done
+	Run(ctx context.Context)
+}
+
+type RFQ interface {
+	// Request broadcasts a request for quote of a block trade to the market makers, users of Official type
+	Request(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal) (*models.RFQ, error)
+	// GetRFQ returns an RFQ with all its quotes to the requester, or with their own quotes to market makers
+	GetRFQ(ctx context.Context, userID string, scope models.UserType, rfqID string) (*models.RFQ, error)
+	// Quote offers a price for an open RFQ, valid for a limited time within the RFQ
+	Quote(ctx context.Context, userID, rfqID string, price models.Decimal) (*models.Quote, error)
+	// Accept executes a quote of an RFQ of given user as an off-book trade
+	Accept(ctx context.Context, userID, rfqID, quoteID string) (*models.Trade, error)
+}
+
+type Market interface {
+	// GetInstrument returns the trading rules and current market state of an instrument
+	GetInstrument(ctx context.Context, symbol string) (*models.Instrument, models.MarketState, error)
+	// GetTickers returns the statistics of trades on the board over the last 24 hours per instrument
+	GetTickers(ctx context.Context) ([]*models.Ticker, error)
+	// Halt stops trading of an instrument until it is resumed
+	Halt(ctx context.Context, symbol, reason string) error
+	Resume(ctx context.Context, symbol string) error
+	// SetTimeZone sets the IANA time zone the trading session of an instrument is in
+	SetTimeZone(ctx context.Context, symbol, timeZone string) error
+}
+
+type User interface {
+	GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, userID string, email, sms, push bool) (*models.NotificationPreferences, error)
+}
+
+type Template interface {
+	// Render renders the notification of an event for every channel in given locale
+	Render(ctx context.Context, event models.NotificationEvent, locale string, data *models.NotificationData) (*models.Notification, error)
+	// Preview renders the notification of an event with sample data if data is nil
+	Preview(ctx context.Context, event models.NotificationEvent, locale string, data *models.NotificationData) (*models.Notification, error)
+}
+
+type Relay interface {
+	// Run relays messages until ctx is done
+	Run(ctx context.Context)
+}
+
+type Leader interface {
+	// Run campaigns for the lease until ctx is done, running the workers only while holding it
+	Run(ctx context.Context)
+	// IsLeader reports whether this process holds the lease
+	IsLeader() bool
+	// Leader returns the current lease, to forward commands to its holder
+	Leader(ctx context.Context) (*models.Lease, error)
+}
+
+type Audit interface {
+	// Record appends an entry of action taken by actor on target to the audit trail, along with the
+	// request ID, IP and payload hash of the request carried by ctx
+	Record(ctx context.Context, actorID string, action models.AuditAction, target string, detail interface{}) error
+	// List returns entries of the audit trail matching filter, newest first, the access is recorded as well
+	List(ctx context.Context, actorID string, filter *models.AuditFilter, next string, count int) ([]*models.AuditEntry, string, error)
+	// Verify checks the hash chain of the whole audit trail and reports the first broken entry
+	Verify(ctx context.Context) (*models.AuditVerification, error)
+}
+
+type Admin interface {
+	// ListOrders returns orders of all users matching filter, including filled and cancelled ones, newest first,
+	// along with the cursor of the next page
+	ListOrders(ctx context.Context, actorID string, filter *models.OrderFilter, next string, count int) ([]*models.Order, string, error)
+	// CancelOrder cancels an order of any user along with its one-cancels-other group
+	CancelOrder(ctx context.Context, actorID, orderID, reason string) error
+	// CancelUserOrders cancels all open and pending orders of a user and returns the number of cancelled orders
+	CancelUserOrders(ctx context.Context, actorID, userID, reason string) (int, error)
+	GetUserActivity(ctx context.Context, actorID, userID string) (*models.UserActivity, error)
 }
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+)
+
+func (s *orderSvc) MakeTrailingStop(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, trailAmount, trailPercent *models.Decimal, limitOffset models.Decimal) (*models.Order, error) {
+	instrument, err := s.i.Get(ctx, s.Instrument)
+	if err != nil {
+		logging.Errorw(ctx, "service get instrument failed", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	if err := checkMarket(instrument, s.now()); err != nil {
+		logging.Errorw(ctx, "service make trailing stop order rejected by market state", "err", err, "symbol", s.Instrument)
+		return nil, err
+	}
+	if err := checkTrail(instrument, trailAmount, trailPercent, limitOffset); err != nil {
+		logging.Errorw(ctx, "service make trailing stop order rejected by trading rules", "err", err)
+		return nil, err
+	}
+
+	order := &models.Order{
+		ID:           s.newID(),
+		UserID:       &userID,
+		Action:       action,
+		Quantity:     quantity,
+		Status:       models.OrderPending,
+		TrailAmount:  trailAmount,
+		TrailPercent: trailPercent,
+		LimitOffset:  limitOffset,
+	}
+	latestPrice, err := s.latestPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// places the initial stop price and limit price
+	moved, err := order.Trail(latestPrice, instrument.TickSize)
+	if err != nil {
+		logging.Errorw(ctx, "service trail stop order failed", "err", err)
+		return nil, err
+	}
+	if !moved {
+		return nil, &models.FieldError{
+			Field:  "trail_amount",
+			Reason: "puts the stop price or limit price below zero",
+		}
+	}
+	if err := checkLimitOrder(instrument, order.Price, quantity, latestPrice); err != nil {
+		logging.Errorw(ctx, "service make trailing stop order rejected by trading rules", "err", err)
+		return nil, err
+	}
+
+	r, err := s.recipient(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := s.notifications(ctx, r, models.OrderCreated, &models.NotificationData{
+		OrderID:  order.ID,
+		Action:   action,
+		Price:    order.Price,
+		Quantity: quantity,
+	})
+	if err != nil {
+		logging.Errorw(ctx, "service build order notifications failed", "err", err)
+		return nil, err
+	}
+
+	s.BoardGuard.Lock()
+	defer s.BoardGuard.Unlock()
+	if err := s.c.Make(ctx, order, messages); err != nil {
+		logging.Errorw(ctx, "service make trailing stop order failed", "err", err)
+		return nil, err
+	}
+	return order, nil
+}
+
+// updateStops moves trailing stop orders after the latest price, then puts the stop orders reached by it
+// on the board. It should be called with BoardGuard held.
+func (s *orderSvc) updateStops(ctx context.Context, instrument *models.Instrument, latestPrice models.Decimal) error {
+	trailed, err := s.c.TrailStops(ctx, latestPrice, instrument.TickSize)
+	if err != nil {
+		logging.Errorw(ctx, "service trail stop orders failed", "err", err, "latestPrice", latestPrice.String())
+		return err
+	}
+	for _, order := range trailed {
+		logging.Infow(ctx, "trailing stop order moved", "orderID", order.ID, "stopPrice", order.StopPrice.String(), "latestPrice", latestPrice.String())
+	}
+
+	triggered, err := s.c.TriggerStops(ctx, latestPrice)
+	if err != nil {
+		logging.Errorw(ctx, "service trigger stop orders failed", "err", err, "latestPrice", latestPrice.String())
+		return err
+	}
+	for _, order := range triggered {
+		logging.Infow(ctx, "stop order triggered", "orderID", order.ID, "stopPrice", order.StopPrice.String(), "latestPrice", latestPrice.String())
+	}
+	return nil
+}
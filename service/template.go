@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// DEFAULT_LOCALE is used when the user has no locale or it has no templates,
+// every event must have templates in this locale.
+const DEFAULT_LOCALE = "en"
+
+// SMS_MAX_LENGTH is the length of a single SMS segment, longer messages are truncated
+const SMS_MAX_LENGTH = 160
+
+var notificationEvents = []models.NotificationEvent{
+	models.OrderCreated,
+	models.OrderFilled,
+	models.RFQRequested,
+}
+
+type templateSvc struct {
+	// keyed by locale then event
+	text map[string]map[models.NotificationEvent]*texttemplate.Template
+	html map[string]map[models.NotificationEvent]*htmltemplate.Template
+}
+
+// NewTemplate returns an implementation of service.Template, it loads the templates of every
+// locale under templates/ and panics if any of them fails to parse or to render sample data.
+func NewTemplate() Template {
+	s := &templateSvc{
+		text: map[string]map[models.NotificationEvent]*texttemplate.Template{},
+		html: map[string]map[models.NotificationEvent]*htmltemplate.Template{},
+	}
+	locales, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	for _, locale := range locales {
+		if !locale.IsDir() {
+			continue
+		}
+		name := locale.Name()
+		s.text[name] = map[models.NotificationEvent]*texttemplate.Template{}
+		s.html[name] = map[models.NotificationEvent]*htmltemplate.Template{}
+		for _, event := range notificationEvents {
+			textFile := path.Join("templates", name, string(event)+".tmpl")
+			htmlFile := path.Join("templates", name, string(event)+".html.tmpl")
+			if _, err := fs.Stat(templateFS, textFile); err != nil {
+				// falls back to DEFAULT_LOCALE
+				continue
+			}
+			t, err := texttemplate.ParseFS(templateFS, textFile)
+			if err != nil {
+				panic(err)
+			}
+			h, err := htmltemplate.ParseFS(templateFS, htmlFile)
+			if err != nil {
+				panic(err)
+			}
+			s.text[name][event] = t
+			s.html[name][event] = h
+		}
+	}
+
+	// templates are only type checked when executed, render them once to fail fast on typos
+	for locale := range s.text {
+		for _, event := range notificationEvents {
+			if _, err := s.Render(context.Background(), event, locale, sampleNotificationData(event)); err != nil {
+				panic(fmt.Sprintf("invalid %s template of locale %s: %v", event, locale, err))
+			}
+		}
+	}
+	return s
+}
+
+func (s *templateSvc) Render(ctx context.Context, event models.NotificationEvent, locale string, data *models.NotificationData) (*models.Notification, error) {
+	if data == nil {
+		err := &models.FieldError{Field: "data", Reason: "is required"}
+		logging.Errorw(ctx, "service render notification failed", "err", err, "event", event)
+		return nil, err
+	}
+	if event == models.OrderFilled && data.Fill == nil {
+		err := &models.FieldError{Field: "fill", Reason: fmt.Sprintf("is required by %s notification", event)}
+		logging.Errorw(ctx, "service render notification failed", "err", err, "event", event)
+		return nil, err
+	}
+	if event == models.RFQRequested && (data.RFQID == "" || data.ExpiresAt == nil) {
+		err := &models.FieldError{Field: "rfq_id", Reason: fmt.Sprintf("and expires_at are required by %s notification", event)}
+		logging.Errorw(ctx, "service render notification failed", "err", err, "event", event)
+		return nil, err
+	}
+	locale = s.resolveLocale(event, locale)
+	t, ok := s.text[locale][event]
+	if !ok {
+		err := &models.FieldError{Field: "event", Reason: fmt.Sprintf("%s is unknown", event)}
+		logging.Errorw(ctx, "service render notification failed", "err", err, "event", event)
+		return nil, err
+	}
+	h := s.html[locale][event]
+
+	notification := &models.Notification{
+		Push: models.PushPayload{
+			Data: map[string]string{
+				"event": string(event),
+			},
+		},
+	}
+	if data.OrderID != "" {
+		notification.Push.Data["order_id"] = data.OrderID
+	}
+	if data.RFQID != "" {
+		notification.Push.Data["rfq_id"] = data.RFQID
+	}
+	for name, out := range map[string]*string{
+		"subject":    &notification.Subject,
+		"text":       &notification.Text,
+		"sms":        &notification.SMS,
+		"push_title": &notification.Push.Title,
+		"push_body":  &notification.Push.Body,
+	} {
+		b := bytes.Buffer{}
+		if err := t.ExecuteTemplate(&b, name, data); err != nil {
+			logging.Errorw(ctx, "service render notification failed", "err", err, "event", event, "locale", locale, "template", name)
+			return nil, err
+		}
+		*out = strings.TrimSpace(b.String())
+	}
+	b := bytes.Buffer{}
+	if err := h.ExecuteTemplate(&b, "html", data); err != nil {
+		logging.Errorw(ctx, "service render notification failed", "err", err, "event", event, "locale", locale, "template", "html")
+		return nil, err
+	}
+	notification.HTML = strings.TrimSpace(b.String())
+	notification.SMS = truncate(notification.SMS, SMS_MAX_LENGTH)
+	return notification, nil
+}
+
+func (s *templateSvc) Preview(ctx context.Context, event models.NotificationEvent, locale string, data *models.NotificationData) (*models.Notification, error) {
+	if data == nil {
+		data = sampleNotificationData(event)
+	}
+	return s.Render(ctx, event, locale, data)
+}
+
+// resolveLocale returns the best locale having templates of given event, matching exactly,
+// then by language, and falling back to DEFAULT_LOCALE.
+func (s *templateSvc) resolveLocale(event models.NotificationEvent, locale string) string {
+	if _, ok := s.text[locale][event]; ok {
+		return locale
+	}
+	language, _, _ := strings.Cut(locale, "-")
+	if language != "" {
+		for candidate, events := range s.text {
+			if _, ok := events[event]; !ok {
+				continue
+			}
+			if l, _, _ := strings.Cut(candidate, "-"); strings.EqualFold(l, language) {
+				return candidate
+			}
+		}
+	}
+	return DEFAULT_LOCALE
+}
+
+// truncate cuts s to at most n runes, ending it with an ellipsis if it is cut
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
+
+// sampleNotificationData returns data to preview the templates of given event
+func sampleNotificationData(event models.NotificationEvent) *models.NotificationData {
+	data := &models.NotificationData{
+		OrderID:  "00000000-0000-4000-8000-000000000000",
+		Action:   models.Buy,
+		Price:    models.NewDecimal(10),
+		Quantity: models.NewDecimal(100),
+	}
+	switch event {
+	case models.OrderFilled:
+		data.Fill = &models.FillSummary{
+			Quantity:     models.NewDecimal(100),
+			AveragePrice: models.NewDecimal(10),
+			Trades:       1,
+		}
+	case models.RFQRequested:
+		expiresAt := time.Date(2021, 1, 1, 0, 1, 0, 0, time.UTC)
+		data.OrderID = ""
+		data.RFQID = "00000000-0000-4000-8000-000000000000"
+		data.ExpiresAt = &expiresAt
+	}
+	return data
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+	"github.com/A-pen-app/logging"
+)
+
+type userSvc struct {
+	u store.User
+}
+
+// NewUser returns an implementation of service.User
+func NewUser(u store.User) User {
+	return &userSvc{
+		u: u,
+	}
+}
+
+func (s *userSvc) GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	preferences, err := s.u.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		logging.Errorw(ctx, "service get notification preferences failed", "err", err, "userID", userID)
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (s *userSvc) UpdateNotificationPreferences(ctx context.Context, userID string, email, sms, push bool) (*models.NotificationPreferences, error) {
+	if err := s.u.UpdateNotificationPreferences(ctx, &models.NotificationPreferences{
+		UserID: userID,
+		Email:  email,
+		SMS:    sms,
+		Push:   push,
+	}); err != nil {
+		logging.Errorw(ctx, "service update notification preferences failed", "err", err, "userID", userID)
+		return nil, err
+	}
+	return s.GetNotificationPreferences(ctx, userID)
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/database"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/A-pen-app/tracing"
+	"github.com/jmoiron/sqlx"
+)
+
+// auditLockKey identifies the advisory lock held while appending to the audit trail, so entries
+// are chained one at a time across all replicas.
+const auditLockKey = 7286033004
+
+// auditColumns are the columns of an audit entry, entries without an actor have a NULL actor_id
+const auditColumns = `
+			id,
+			COALESCE(actor_id::text, '') AS actor_id,
+			action,
+			target,
+			detail,
+			request_id,
+			ip,
+			payload_hash,
+			created_at,
+			prev_hash,
+			hash
+`
+
+type auditStore struct {
+	db *sqlx.DB
+}
+
+// NewAudit returns an implementation of store.Audit
+func NewAudit(db *sqlx.DB) Audit {
+	return &auditStore{
+		db: db,
+	}
+}
+
+func (s *auditStore) Record(ctx context.Context, entry *models.AuditEntry) error {
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		return recordAudit(ctx, tx, entry)
+	}); err != nil {
+		logging.Errorw(ctx, "store record audit entry action failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *auditStore) List(ctx context.Context, filter *models.AuditFilter, next string, count int) ([]*models.AuditEntry, string, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.list.audit").End()
+	}
+
+	entries := []*models.AuditEntry{}
+	query := `SELECT` + auditColumns + `FROM public.audit_log WHERE `
+	conditions := []string{
+		"TRUE",
+	}
+	values := []interface{}{}
+	if filter.ActorID != nil {
+		conditions = append(conditions, "actor_id = ?")
+		values = append(values, *filter.ActorID)
+	}
+	if filter.Action != nil {
+		conditions = append(conditions, "action = ?")
+		values = append(values, *filter.Action)
+	}
+	if filter.Target != nil {
+		conditions = append(conditions, "target = ?")
+		values = append(values, *filter.Target)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		values = append(values, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < ?")
+		values = append(values, *filter.To)
+	}
+	if next != "" {
+		// next is the ID of the last entry of the previous page, IDs grow with the chain
+		id, err := strconv.ParseInt(next, 10, 64)
+		if err != nil {
+			return nil, "", models.ErrorWrongParams
+		}
+		conditions = append(conditions, "id < ?")
+		values = append(values, id)
+	}
+	query = query + strings.Join(conditions, " AND ") + " ORDER BY id DESC LIMIT ?"
+	values = append(values, count)
+
+	query = s.db.Rebind(query)
+	if err := s.db.SelectContext(ctx, &entries, query, values...); err != nil {
+		logging.Errorw(ctx, "store list audit entries failed", "err", err)
+		return nil, "", parseError(err)
+	}
+	if len(entries) < count {
+		return entries, "", nil
+	}
+	return entries, strconv.FormatInt(entries[len(entries)-1].ID, 10), nil
+}
+
+func (s *auditStore) Scan(ctx context.Context, afterID int64, count int) ([]*models.AuditEntry, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.scan.audit").End()
+	}
+
+	entries := []*models.AuditEntry{}
+	query := `SELECT` + auditColumns + `FROM public.audit_log WHERE id > ? ORDER BY id ASC LIMIT ?`
+	query = s.db.Rebind(query)
+	if err := s.db.SelectContext(ctx, &entries, query, afterID, count); err != nil {
+		logging.Errorw(ctx, "store scan audit entries failed", "err", err, "afterID", afterID)
+		return nil, parseError(err)
+	}
+	return entries, nil
+}
+
+// recordAudit appends entry to the audit trail within transaction tx, chaining it to the latest
+// entry. entry.ID, CreatedAt, PrevHash and Hash are filled in. Nothing is recorded if entry is nil.
+// It should be the last statement of tx, the chain lock is held from here until tx ends.
+func recordAudit(ctx context.Context, tx *sqlx.Tx, entry *models.AuditEntry) error {
+	if entry == nil {
+		return nil
+	}
+	// appends are serialized across replicas, so anything failing to hash is caught before locking
+	if _, err := entry.Digest(); err != nil {
+		logging.Errorw(ctx, "store hash audit entry failed", "err", err, "action", entry.Action)
+		return err
+	}
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", auditLockKey); err != nil {
+		logging.Errorw(ctx, "store acquire audit lock failed", "err", err)
+		return parseError(err)
+	}
+	prevHash := ""
+	query := "SELECT hash FROM public.audit_log ORDER BY id DESC LIMIT 1"
+	if err := tx.Get(&prevHash, query); err != nil && err != sql.ErrNoRows {
+		logging.Errorw(ctx, "store get latest audit hash failed", "err", err)
+		return parseError(err)
+	}
+
+	// the database keeps microseconds, so the hash is computed over what is read back
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	if err := entry.Chain(prevHash); err != nil {
+		logging.Errorw(ctx, "store hash audit entry failed", "err", err, "action", entry.Action)
+		return err
+	}
+	var detail []byte
+	if len(entry.Detail) > 0 {
+		detail = entry.Detail
+	}
+	query = `
+		INSERT INTO public.audit_log (
+			actor_id,
+			action,
+			target,
+			detail,
+			request_id,
+			ip,
+			payload_hash,
+			created_at,
+			prev_hash,
+			hash
+		)
+		VALUES (
+			NULLIF(?, '')::uuid,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?
+		)
+		RETURNING id
+	`
+	values := []interface{}{
+		entry.ActorID,
+		entry.Action,
+		entry.Target,
+		detail,
+		entry.RequestID,
+		entry.IP,
+		entry.PayloadHash,
+		entry.CreatedAt,
+		entry.PrevHash,
+		entry.Hash,
+	}
+	query = tx.Rebind(query)
+	if err := tx.QueryRowx(query, values...).Scan(&entry.ID); err != nil {
+		logging.Errorw(ctx, "store record audit entry failed", "err", err, "action", entry.Action)
+		return parseError(err)
+	}
+	return nil
+}
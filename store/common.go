@@ -26,6 +26,8 @@ func parseError(err error) error {
 		return models.ErrorDuplicateEntry
 	case "integrity_constraint_violation":
 		return models.ErrorDuplicateEntry
+	case "lock_not_available":
+		return models.ErrorBookBusy
 	}
 	return err
 }
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/A-pen-app/tracing"
+	"github.com/jmoiron/sqlx"
+)
+
+type instrumentStore struct {
+	db *sqlx.DB
+}
+
+// NewInstrument returns an implementation of store.Instrument
+func NewInstrument(db *sqlx.DB) Instrument {
+	return &instrumentStore{
+		db: db,
+	}
+}
+
+func (s *instrumentStore) Get(ctx context.Context, symbol string) (*models.Instrument, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.instrument").End()
+	}
+
+	instrument := models.Instrument{}
+	query := `
+		SELECT
+			symbol,
+			tick_size,
+			lot_size,
+			min_notional,
+			max_notional,
+			price_band_percent,
+			session_open::text AS session_open,
+			session_close::text AS session_close,
+			time_zone,
+			auction_minutes,
+			volatility_percent,
+			volatility_window_minutes,
+			halt_minutes,
+			halted,
+			halt_reason,
+			halt_until,
+			resumed_at
+		FROM public.instrument
+		WHERE
+		symbol = ?
+	`
+	values := []interface{}{
+		symbol,
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&instrument, query, values...); err != nil {
+		logging.Errorw(ctx, "store get instrument failed", "err", err, "symbol", symbol)
+		return nil, parseError(err)
+	}
+	if _, err := models.LoadTimeZone(instrument.TimeZone); err != nil {
+		logging.Errorw(ctx, "store load time zone of instrument failed", "err", err, "symbol", symbol, "timeZone", instrument.TimeZone)
+		return nil, err
+	}
+	return &instrument, nil
+}
+
+func (s *instrumentStore) Halt(ctx context.Context, symbol, reason string, minutes int) error {
+	query := `
+		UPDATE public.instrument
+		SET
+			halted = true,
+			halt_reason = ?,
+			halt_until = CASE WHEN ? > 0 THEN now() + make_interval(mins => ?) END
+		WHERE
+		symbol = ?
+	`
+	values := []interface{}{
+		reason,
+		minutes,
+		minutes,
+		symbol,
+	}
+	query = s.db.Rebind(query)
+	result, err := s.db.Exec(query, values...)
+	if err != nil {
+		logging.Errorw(ctx, "store halt instrument failed", "err", err, "symbol", symbol)
+		return parseError(err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return models.ErrorNotFound
+	}
+	return nil
+}
+
+func (s *instrumentStore) Resume(ctx context.Context, symbol string) error {
+	query := `
+		UPDATE public.instrument
+		SET
+			halted = false,
+			halt_reason = NULL,
+			halt_until = NULL,
+			resumed_at = now()
+		WHERE
+		symbol = ?
+	`
+	values := []interface{}{
+		symbol,
+	}
+	query = s.db.Rebind(query)
+	result, err := s.db.Exec(query, values...)
+	if err != nil {
+		logging.Errorw(ctx, "store resume instrument failed", "err", err, "symbol", symbol)
+		return parseError(err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return models.ErrorNotFound
+	}
+	return nil
+}
+
+func (s *instrumentStore) SetTimeZone(ctx context.Context, symbol, timeZone string) error {
+	query := `
+		UPDATE public.instrument
+		SET
+			time_zone = ?
+		WHERE
+		symbol = ?
+	`
+	values := []interface{}{
+		timeZone,
+		symbol,
+	}
+	query = s.db.Rebind(query)
+	result, err := s.db.Exec(query, values...)
+	if err != nil {
+		logging.Errorw(ctx, "store set time zone of instrument failed", "err", err, "symbol", symbol, "timeZone", timeZone)
+		return parseError(err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return models.ErrorNotFound
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/jmoiron/sqlx"
+)
+
+type leaseStore struct {
+	db *sqlx.DB
+}
+
+// NewLease returns an implementation of store.Lease
+func NewLease(db *sqlx.DB) Lease {
+	return &leaseStore{
+		db: db,
+	}
+}
+
+func (s *leaseStore) Acquire(ctx context.Context, name, holder, address string, ttl time.Duration) (*models.Lease, error) {
+	lease := models.Lease{}
+	// expiry is decided by the database clock, so replicas with skewed clocks agree on it
+	query := `
+		INSERT INTO public.lease AS l (
+			name,
+			holder,
+			address,
+			expires_at
+		)
+		VALUES (
+			?,
+			?,
+			?,
+			now() + ?::interval
+		)
+		ON CONFLICT (name) DO UPDATE
+		SET
+			holder = EXCLUDED.holder,
+			address = EXCLUDED.address,
+			expires_at = EXCLUDED.expires_at
+		WHERE
+		l.holder = EXCLUDED.holder OR
+		l.expires_at < now()
+		RETURNING
+			name,
+			holder,
+			address,
+			expires_at
+	`
+	values := []interface{}{
+		name,
+		holder,
+		address,
+		fmt.Sprintf("%d milliseconds", ttl.Milliseconds()),
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.QueryRowx(query, values...).StructScan(&lease); err == nil {
+		return &lease, nil
+	} else if err := parseError(err); err != models.ErrorNotFound {
+		logging.Errorw(ctx, "store acquire lease failed", "err", err, "name", name)
+		return nil, err
+	}
+
+	// held by another replica
+	query = `
+		SELECT
+			name,
+			holder,
+			address,
+			expires_at
+		FROM public.lease
+		WHERE
+		name = ?
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&lease, query, name); err != nil {
+		logging.Errorw(ctx, "store get lease failed", "err", err, "name", name)
+		return nil, parseError(err)
+	}
+	return &lease, nil
+}
+
+func (s *leaseStore) Release(ctx context.Context, name, holder string) error {
+	query := `
+		UPDATE public.lease
+		SET
+			expires_at = now()
+		WHERE
+		name = ? AND
+		holder = ?
+	`
+	query = s.db.Rebind(query)
+	if _, err := s.db.Exec(query, name, holder); err != nil {
+		logging.Errorw(ctx, "store release lease failed", "err", err, "name", name)
+		return parseError(err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+)
+
+type instrumentStore struct {
+	db *DB
+}
+
+// NewInstrument returns an in-memory implementation of store.Instrument
+func NewInstrument(db *DB) store.Instrument {
+	return &instrumentStore{
+		db: db,
+	}
+}
+
+func (s *instrumentStore) Get(ctx context.Context, symbol string) (*models.Instrument, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	instrument, ok := s.db.instruments[symbol]
+	if !ok {
+		return nil, models.ErrorNotFound
+	}
+	if _, err := models.LoadTimeZone(instrument.TimeZone); err != nil {
+		return nil, err
+	}
+	i := *instrument
+	return &i, nil
+}
+
+func (s *instrumentStore) Halt(ctx context.Context, symbol, reason string, minutes int) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	instrument, ok := s.db.instruments[symbol]
+	if !ok {
+		return models.ErrorNotFound
+	}
+	instrument.Halted = true
+	instrument.HaltReason = &reason
+	instrument.HaltUntil = nil
+	if minutes > 0 {
+		until := s.db.now().Add(time.Duration(minutes) * time.Minute)
+		instrument.HaltUntil = &until
+	}
+	return nil
+}
+
+func (s *instrumentStore) Resume(ctx context.Context, symbol string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	instrument, ok := s.db.instruments[symbol]
+	if !ok {
+		return models.ErrorNotFound
+	}
+	now := s.db.now()
+	instrument.Halted = false
+	instrument.HaltReason = nil
+	instrument.HaltUntil = nil
+	instrument.ResumedAt = &now
+	return nil
+}
+
+func (s *instrumentStore) SetTimeZone(ctx context.Context, symbol, timeZone string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	instrument, ok := s.db.instruments[symbol]
+	if !ok {
+		return models.ErrorNotFound
+	}
+	instrument.TimeZone = timeZone
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Package memory implements the store interfaces in memory with a given clock, it is used to replay
+orders deterministically without a database. All stores built on the same DB share its data.
+*/
+package memory
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+)
+
+// DB holds the data of all stores built on it, every store operation runs atomically
+type DB struct {
+	mu  sync.Mutex
+	now func() time.Time
+	seq int
+
+	orders      []*models.Order // in the order they are made
+	trades      []*models.Trade
+	positions   map[string]*models.Position
+	instruments map[string]*models.Instrument
+	outbox      []*outboxRow
+	audit       []*models.AuditEntry
+
+	// users are guarded by their own lock, as outbox functions read them while orders are matched
+	userMu      sync.Mutex
+	users       map[string]*models.User
+	preferences map[string]*models.NotificationPreferences
+}
+
+type outboxRow struct {
+	message     models.OutboxMessage
+	availableAt time.Time
+	published   bool
+}
+
+// NewDB returns an empty DB reading the current time from now
+func NewDB(now func() time.Time) *DB {
+	return &DB{
+		now:         now,
+		positions:   map[string]*models.Position{},
+		instruments: map[string]*models.Instrument{},
+		users:       map[string]*models.User{},
+		preferences: map[string]*models.NotificationPreferences{},
+	}
+}
+
+// PutInstrument creates or replaces an instrument
+func (db *DB) PutInstrument(instrument *models.Instrument) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	i := *instrument
+	db.instruments[i.Symbol] = &i
+}
+
+// PutUser creates or replaces a user
+func (db *DB) PutUser(user *models.User) {
+	db.userMu.Lock()
+	defer db.userMu.Unlock()
+	u := *user
+	db.users[u.ID] = &u
+}
+
+// Trades returns all trades in the order they are executed
+func (db *DB) Trades() []*models.Trade {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	trades := make([]*models.Trade, 0, len(db.trades))
+	for _, trade := range db.trades {
+		t := *trade
+		trades = append(trades, &t)
+	}
+	return trades
+}
+
+// Positions returns the positions of all users having traded
+func (db *DB) Positions() []*models.Position {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	positions := make([]*models.Position, 0, len(db.positions))
+	for _, position := range db.positions {
+		p := *position
+		positions = append(positions, &p)
+	}
+	return positions
+}
+
+// recordAudit appends entry to the audit trail chained to the latest entry if it is not nil,
+// it should be called with mu held
+func (db *DB) recordAudit(entry *models.AuditEntry) error {
+	if entry == nil {
+		return nil
+	}
+	prevHash := ""
+	if len(db.audit) > 0 {
+		prevHash = db.audit[len(db.audit)-1].Hash
+	}
+	entry.CreatedAt = db.now()
+	if err := entry.Chain(prevHash); err != nil {
+		return err
+	}
+	db.seq++
+	entry.ID = int64(db.seq)
+	e := *entry
+	db.audit = append(db.audit, &e)
+	return nil
+}
+
+// newID returns a deterministic UUID shaped ID, it should be called with mu held
+func (db *DB) newID() string {
+	db.seq++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", db.seq)
+}
+
+// snapshot returns a function restoring orders, trades, positions and outbox to their current
+// state, it is used to roll back operations failing halfway and should be called with mu held.
+func (db *DB) snapshot() func() {
+	orders := make([]*models.Order, 0, len(db.orders))
+	for _, order := range db.orders {
+		o := *order
+		orders = append(orders, &o)
+	}
+	positions := make(map[string]*models.Position, len(db.positions))
+	for userID, position := range db.positions {
+		p := *position
+		positions[userID] = &p
+	}
+	trades, outbox, seq := len(db.trades), len(db.outbox), db.seq
+	return func() {
+		db.orders = orders
+		db.positions = positions
+		db.trades = db.trades[:trades]
+		db.outbox = db.outbox[:outbox]
+		db.seq = seq
+	}
+}
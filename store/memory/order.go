@@ -0,0 +1,560 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+)
+
+type orderStore struct {
+	db *DB
+}
+
+// NewOrder returns an in-memory implementation of store.Order
+func NewOrder(db *DB) store.Order {
+	return &orderStore{
+		db: db,
+	}
+}
+
+func (s *orderStore) GetLiveOrders(ctx context.Context, action models.OrderAction) ([]*models.Order, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if action != models.Buy && action != models.Sell {
+		return nil, errors.New("invalid order action")
+	}
+	// the board lists the queue reversed, buy orders by ascending price and sell orders by descending price
+	orders := s.db.queue(action)
+	slices.Reverse(orders)
+	for i, order := range orders {
+		o := *order
+		orders[i] = &o
+	}
+	return orders, nil
+}
+
+func (s *orderStore) GetBestPrice(ctx context.Context, action models.OrderAction) (*models.Decimal, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if action != models.Buy && action != models.Sell {
+		return nil, errors.New("invalid order action")
+	}
+	orders := s.db.queue(action)
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	best := orders[0].Price
+	return &best, nil
+}
+
+func (s *orderStore) GetLatestPrice(ctx context.Context) (*models.Decimal, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return s.db.latestPrice(), nil
+}
+
+// latestPrice returns the price of the latest trade on the board, it should be called with mu held
+func (db *DB) latestPrice() *models.Decimal {
+	for i := len(db.trades) - 1; i >= 0; i-- {
+		if !db.trades[i].OffBook {
+			price := db.trades[i].Price
+			return &price
+		}
+	}
+	return nil
+}
+
+func (s *orderStore) GetPriceRange(ctx context.Context, since time.Time) (*models.Decimal, *models.Decimal, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var low, high *models.Decimal
+	for _, trade := range s.db.trades {
+		if trade.OffBook || trade.CreatedAt.Before(since) {
+			continue
+		}
+		if low == nil || trade.Price < *low {
+			price := trade.Price
+			low = &price
+		}
+		if high == nil || trade.Price > *high {
+			price := trade.Price
+			high = &price
+		}
+	}
+	return low, high, nil
+}
+
+// queue returns the open orders of given action in matching priority like the postgres store does, best
+// price first then earliest creation, orders made at the same time are kept in the order they are made.
+// It should be called with mu held.
+func (db *DB) queue(action models.OrderAction) []*models.Order {
+	orders := []*models.Order{}
+	for _, order := range db.orders {
+		if order.Action == action && order.Status == models.OrderOpen {
+			orders = append(orders, order)
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		if orders[i].Price != orders[j].Price {
+			return (orders[i].Price < orders[j].Price) == (action == models.Sell)
+		}
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+	return orders
+}
+
+func (s *orderStore) Get(ctx context.Context, orderID string) (*models.Order, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, order := range s.db.orders {
+		if order.ID == orderID {
+			o := *order
+			return &o, nil
+		}
+	}
+	return nil, models.ErrorNotFound
+}
+
+func (s *orderStore) GetOCOGroup(ctx context.Context, groupID string) ([]*models.Order, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	orders := []*models.Order{}
+	for _, order := range s.db.orders {
+		if order.OCOGroupID != nil && *order.OCOGroupID == groupID {
+			o := *order
+			orders = append(orders, &o)
+		}
+	}
+	// orders without stop price first, like the postgres store
+	sort.SliceStable(orders, func(i, j int) bool {
+		if orders[i].StopPrice == nil || orders[j].StopPrice == nil {
+			return orders[i].StopPrice == nil && orders[j].StopPrice != nil
+		}
+		return *orders[i].StopPrice < *orders[j].StopPrice
+	})
+	return orders, nil
+}
+
+func (s *orderStore) List(ctx context.Context, filter *models.OrderFilter, next string, count int) ([]*models.Order, string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	newer := func(a, b *models.Order) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	}
+	var last *models.Order
+	if next != "" {
+		cursor, err := models.ParseCursor(next)
+		if err != nil {
+			return nil, "", err
+		}
+		last = &models.Order{ID: cursor.ID, CreatedAt: cursor.CreatedAt}
+	}
+	orders := []*models.Order{}
+	for _, order := range s.db.orders {
+		if (filter.UserID != nil && (order.UserID == nil || *order.UserID != *filter.UserID)) ||
+			(filter.Action != nil && order.Action != *filter.Action) ||
+			(filter.Status != nil && order.Status != *filter.Status) ||
+			(filter.From != nil && order.CreatedAt.Before(*filter.From)) ||
+			(filter.To != nil && !order.CreatedAt.Before(*filter.To)) ||
+			(last != nil && !newer(last, order)) {
+			continue
+		}
+		o := *order
+		orders = append(orders, &o)
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		return newer(orders[i], orders[j])
+	})
+	if len(orders) < count {
+		return orders, "", nil
+	}
+	orders = orders[:count]
+	return orders, models.NewCursor(orders[count-1].CreatedAt, orders[count-1].ID), nil
+}
+
+func (s *orderStore) Make(ctx context.Context, order *models.Order, messages []*models.OutboxMessage) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if err := s.db.insertOrder(order); err != nil {
+		return err
+	}
+	s.db.enqueue(messages)
+	return nil
+}
+
+func (s *orderStore) MakeOCO(ctx context.Context, orders []*models.Order, messages []*models.OutboxMessage) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	rollback := s.db.snapshot()
+	groupID := s.db.newID()
+	for _, order := range orders {
+		order.OCOGroupID = &groupID
+		if err := s.db.insertOrder(order); err != nil {
+			rollback()
+			return err
+		}
+	}
+	s.db.enqueue(messages)
+	return nil
+}
+
+// insertOrder creates an order, order.ID is generated if empty and order.Status defaults to open.
+// It should be called with mu held.
+func (db *DB) insertOrder(order *models.Order) error {
+	if order.ID == "" {
+		order.ID = db.newID()
+	}
+	for _, o := range db.orders {
+		if o.ID == order.ID {
+			return models.ErrorDuplicateEntry
+		}
+	}
+	if order.Status == "" {
+		order.Status = models.OrderOpen
+	}
+	order.CreatedAt = db.now()
+	o := *order
+	db.orders = append(db.orders, &o)
+	return nil
+}
+
+func (s *orderStore) Take(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, outbox store.OutboxFunc) (models.Decimal, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var latestPrice models.Decimal
+	rollback := s.db.snapshot()
+	trades := []*models.Trade{}
+	filledIDs := map[string]bool{}
+	sells := s.db.queue(models.Sell)
+	for i := 0; i < len(sells) && quantity > 0; {
+		order := sells[i]
+		if order.Quantity <= 0 {
+			// cancelled by its one-cancels-other group within this take
+			i++
+			continue
+		}
+		filled := min(order.Quantity, quantity)
+		trade, err := s.db.fill(userID, action, order, order.Price, filled)
+		if err != nil {
+			rollback()
+			return 0, err
+		}
+		trades = append(trades, trade)
+		latestPrice = order.Price
+		order.Quantity -= filled
+		quantity -= filled
+		filledIDs[order.ID] = true
+		if order.OCOGroupID != nil {
+			s.db.reduceOCOGroup(order, filled)
+		}
+
+		// a replenished iceberg order takes new time priority, behind the orders resting at its price
+		if order.Replenish(s.db.now()) {
+			models.Requeue(sells, i)
+			continue
+		}
+		i++
+	}
+
+	s.db.closeFilled(filledIDs)
+
+	if outbox != nil {
+		messages, err := outbox(trades)
+		if err != nil {
+			rollback()
+			return 0, err
+		}
+		s.db.enqueue(messages)
+	}
+	return latestPrice, nil
+}
+
+func (s *orderStore) TrailStops(ctx context.Context, latestPrice, tickSize models.Decimal) ([]*models.Order, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	trailed := []*models.Order{}
+	for _, order := range s.db.orders {
+		moved, err := order.Trail(latestPrice, tickSize)
+		if err != nil {
+			return nil, err
+		}
+		if moved {
+			o := *order
+			trailed = append(trailed, &o)
+		}
+	}
+	return trailed, nil
+}
+
+func (s *orderStore) TriggerStops(ctx context.Context, latestPrice models.Decimal) ([]*models.Order, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	triggered := []*models.Order{}
+	for _, order := range s.db.orders {
+		if !order.StopTriggered(latestPrice) {
+			continue
+		}
+		order.Status = models.OrderOpen
+		order.CreatedAt = s.db.now()
+		o := *order
+		triggered = append(triggered, &o)
+	}
+	return triggered, nil
+}
+
+func (s *orderStore) GetIndicativeAuction(ctx context.Context, defaultPrice models.Decimal) (*models.Auction, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	buys, sells := s.db.auctionOrders()
+	return models.Uncross(buys, sells, s.db.referencePrice(defaultPrice)), nil
+}
+
+// auctionOrders returns the open buy and sell orders taking part in an auction in matching priority,
+// orders without owner are left out like the postgres store does. It should be called with mu held.
+func (db *DB) auctionOrders() ([]*models.Order, []*models.Order) {
+	sides := map[models.OrderAction][]*models.Order{}
+	for _, action := range []models.OrderAction{models.Buy, models.Sell} {
+		orders := []*models.Order{}
+		for _, order := range db.queue(action) {
+			if order.UserID != nil {
+				orders = append(orders, order)
+			}
+		}
+		sides[action] = orders
+	}
+	return sides[models.Buy], sides[models.Sell]
+}
+
+// referencePrice returns the latest price, or defaultPrice if nothing has traded yet. It should be
+// called with mu held.
+func (db *DB) referencePrice(defaultPrice models.Decimal) models.Decimal {
+	if latestPrice := db.latestPrice(); latestPrice != nil {
+		return *latestPrice
+	}
+	return defaultPrice
+}
+
+func (s *orderStore) Uncross(ctx context.Context, defaultPrice models.Decimal, outbox store.OutboxFunc) (*models.Auction, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	buys, sells := s.db.auctionOrders()
+	auction := models.Uncross(buys, sells, s.db.referencePrice(defaultPrice))
+	if auction.Price == nil {
+		return auction, nil
+	}
+	price := *auction.Price
+
+	rollback := s.db.snapshot()
+	trades := []*models.Trade{}
+	filledIDs := map[string]bool{}
+	volume := auction.Volume
+	for i, j := 0, 0; volume > 0 && i < len(buys) && j < len(sells); {
+		buy, sell := buys[i], sells[j]
+		if buy.Quantity <= 0 {
+			i++
+			continue
+		}
+		if sell.Quantity <= 0 {
+			j++
+			continue
+		}
+		if buy.Price < price || sell.Price > price {
+			break
+		}
+		quantity := min(buy.Quantity, sell.Quantity, volume)
+		trade, err := s.db.fill(*buy.UserID, models.Buy, sell, price, quantity)
+		if err != nil {
+			rollback()
+			return nil, err
+		}
+		trades = append(trades, trade)
+		volume -= quantity
+		for _, order := range []*models.Order{buy, sell} {
+			order.Quantity -= quantity
+			filledIDs[order.ID] = true
+			if order.OCOGroupID != nil {
+				s.db.reduceOCOGroup(order, quantity)
+			}
+		}
+		// a replenished iceberg order takes new time priority, behind the orders at its price
+		if buy.Replenish(s.db.now()) {
+			models.Requeue(buys, i)
+		}
+		if sell.Replenish(s.db.now()) {
+			models.Requeue(sells, j)
+		}
+	}
+
+	s.db.closeFilled(filledIDs)
+
+	if outbox != nil {
+		messages, err := outbox(trades)
+		if err != nil {
+			rollback()
+			return nil, err
+		}
+		s.db.enqueue(messages)
+	}
+	return auction, nil
+}
+
+// fill records a trade of given quantity at given price between the taker and the resting order,
+// and applies it to the positions of both parties. It should be called with mu held.
+func (db *DB) fill(takerID string, action models.OrderAction, order *models.Order, price, quantity models.Decimal) (*models.Trade, error) {
+	trade := &models.Trade{
+		ID:           db.newID(),
+		MakerOrderID: order.ID,
+		MakerID:      order.UserID,
+		TakerID:      takerID,
+		Action:       action,
+		Price:        price,
+		Quantity:     quantity,
+		CreatedAt:    db.now(),
+	}
+	if err := db.fillPosition(takerID, action, price, quantity); err != nil {
+		return nil, err
+	}
+	if order.UserID != nil {
+		if err := db.fillPosition(*order.UserID, order.Action, price, quantity); err != nil {
+			return nil, err
+		}
+	}
+	t := *trade
+	db.trades = append(db.trades, &t)
+	return trade, nil
+}
+
+// reduceOCOGroup reduces the other open or pending orders of the one-cancels-other group of a filled
+// order by the filled quantity, cancelling those with nothing left like the postgres store does. Those
+// filled already are left alone. It should be called with mu held.
+func (db *DB) reduceOCOGroup(order *models.Order, filled models.Decimal) {
+	for _, o := range db.orders {
+		if o.OCOGroupID == nil || *o.OCOGroupID != *order.OCOGroupID || o.ID == order.ID || o.ClosedAt != nil || o.Quantity <= 0 {
+			continue
+		}
+		o.Quantity = max(o.Quantity-filled, 0)
+		if o.Quantity == 0 {
+			db.close(o, models.OrderCancelled)
+		}
+	}
+}
+
+// closeFilled takes the orders of filledIDs with nothing left off the board as filled, those cancelled
+// by their group in the meantime stay cancelled. It should be called with mu held.
+func (db *DB) closeFilled(filledIDs map[string]bool) {
+	for _, order := range db.orders {
+		if filledIDs[order.ID] && order.ClosedAt == nil && order.Quantity <= 0 {
+			db.close(order, models.OrderFullyFilled)
+		}
+	}
+}
+
+// close keeps order off the board as filled or cancelled, filled orders have nothing left. It should
+// be called with mu held.
+func (db *DB) close(order *models.Order, status models.OrderStatus) {
+	now := db.now()
+	order.Status = status
+	order.ClosedAt = &now
+	if status == models.OrderFullyFilled {
+		order.Quantity = 0
+	}
+}
+
+func (s *orderStore) Delete(ctx context.Context, userID *string, orderID string, audit *models.AuditEntry) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	rollback := s.db.snapshot()
+	if err := s.db.cancel(userID, orderID); err != nil {
+		return err
+	}
+	if err := s.db.recordAudit(audit); err != nil {
+		rollback()
+		return err
+	}
+	return nil
+}
+
+// cancel cancels an open or pending order of the user, or of any user if userID is nil, along with
+// the other orders of its one-cancels-other group. It should be called with mu held.
+func (db *DB) cancel(userID *string, orderID string) error {
+	var groupID *string
+	found := false
+	for _, order := range db.orders {
+		if order.ID == orderID && order.ClosedAt == nil && (userID == nil || (order.UserID != nil && *order.UserID == *userID)) {
+			groupID, found = order.OCOGroupID, true
+		}
+	}
+	if !found {
+		return models.ErrorNotFound
+	}
+	for _, order := range db.orders {
+		if order.ClosedAt != nil {
+			continue
+		}
+		if order.ID == orderID || (groupID != nil && order.OCOGroupID != nil && *order.OCOGroupID == *groupID) {
+			db.close(order, models.OrderCancelled)
+		}
+	}
+	return nil
+}
+
+func (s *orderStore) Batch(ctx context.Context, userID string, items []*models.BatchItem) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	rollback := s.db.snapshot()
+	for i, item := range items {
+		if item.Order != nil {
+			if err := s.db.insertOrder(item.Order); err != nil {
+				rollback()
+				return &models.BatchError{Index: i, Err: err}
+			}
+			s.db.enqueue(item.Messages)
+			continue
+		}
+		if err := s.db.cancel(&userID, item.OrderID); err != nil {
+			rollback()
+			return &models.BatchError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+func (s *orderStore) DeleteByUser(ctx context.Context, userID string, audit *models.AuditEntry) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if err := s.db.recordAudit(audit); err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, order := range s.db.orders {
+		if order.UserID != nil && *order.UserID == userID && order.ClosedAt == nil {
+			s.db.close(order, models.OrderCancelled)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,424 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeIceberg(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+
+	maker, other := "maker", "other"
+	display := models.NewDecimal(2)
+	require.NoError(t, s.Make(ctx, &models.Order{
+		ID:              "iceberg",
+		UserID:          &maker,
+		Action:          models.Sell,
+		Price:           models.NewDecimal(11),
+		Quantity:        display,
+		DisplayQuantity: &display,
+		HiddenQuantity:  models.NewDecimal(3),
+	}, nil))
+	now = now.Add(time.Minute)
+	require.NoError(t, s.Make(ctx, &models.Order{
+		ID:       "plain",
+		UserID:   &other,
+		Action:   models.Sell,
+		Price:    models.NewDecimal(11),
+		Quantity: models.NewDecimal(1),
+	}, nil))
+
+	sells, err := s.GetLiveOrders(ctx, models.Sell)
+	require.NoError(t, err)
+	require.Equal(t, []string{"plain", "iceberg"}, []string{sells[0].ID, sells[1].ID}, "expect earliest order last, at the head of the queue")
+	require.Equal(t, display, sells[1].Quantity, "expect only display quantity to be shown")
+
+	// takes the first slice, then the plain order resting at the same price, then 1 of the replenished slice
+	now = now.Add(time.Minute)
+	latestPrice, err := s.Take(ctx, "taker", models.Buy, models.NewDecimal(4), nil)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(11), latestPrice)
+	trades := db.Trades()
+	require.Equal(t, 3, len(trades))
+	require.Equal(t, []string{"iceberg", "plain", "iceberg"},
+		[]string{trades[0].MakerOrderID, trades[1].MakerOrderID, trades[2].MakerOrderID},
+		"expect visible order to fill before the replenished slice")
+	require.Equal(t, models.NewDecimal(2), trades[0].Quantity)
+	require.Equal(t, models.NewDecimal(1), trades[2].Quantity)
+
+	sells, err = s.GetLiveOrders(ctx, models.Sell)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(sells))
+	require.Equal(t, models.NewDecimal(1), sells[0].Quantity)
+	require.Equal(t, models.NewDecimal(1), sells[0].HiddenQuantity)
+	require.Equal(t, now, sells[0].CreatedAt, "expect replenished slice to take new time priority")
+
+	_, err = s.Take(ctx, "taker", models.Buy, models.NewDecimal(5), nil)
+	require.NoError(t, err)
+	sells, err = s.GetLiveOrders(ctx, models.Sell)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(sells), "expect iceberg to be removed once hidden quantity runs out")
+}
+
+func TestTakeOCO(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+
+	maker := "maker"
+	stopPrice := models.NewDecimal(9)
+	takeProfit := &models.Order{
+		UserID:   &maker,
+		Action:   models.Sell,
+		Price:    models.NewDecimal(12),
+		Quantity: models.NewDecimal(5),
+	}
+	stopLoss := &models.Order{
+		UserID:    &maker,
+		Action:    models.Sell,
+		Price:     models.NewDecimal(8),
+		Quantity:  models.NewDecimal(5),
+		Status:    models.OrderPending,
+		StopPrice: &stopPrice,
+	}
+	require.NoError(t, s.MakeOCO(ctx, []*models.Order{takeProfit, stopLoss}, nil))
+	require.Equal(t, takeProfit.OCOGroupID, stopLoss.OCOGroupID)
+
+	sells, err := s.GetLiveOrders(ctx, models.Sell)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(sells), "expect pending stop order not to be on the board")
+
+	// partially fills the take-profit order
+	_, err = s.Take(ctx, "taker", models.Buy, models.NewDecimal(2), nil)
+	require.NoError(t, err)
+	group, err := s.GetOCOGroup(ctx, *takeProfit.OCOGroupID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(group))
+	require.Equal(t, takeProfit.ID, group[0].ID, "expect limit order first")
+	require.Equal(t, models.NewDecimal(3), group[1].Quantity, "expect stop order to be reduced by the fill")
+
+	triggered, err := s.TriggerStops(ctx, models.NewDecimal(10))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(triggered), "expect sell stop not to be triggered above its stop price")
+	triggered, err = s.TriggerStops(ctx, models.NewDecimal(9))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(triggered))
+	require.Equal(t, models.OrderOpen, triggered[0].Status)
+
+	// fills the triggered stop order at its lower limit price, cancelling the take-profit order
+	_, err = s.Take(ctx, "taker", models.Buy, models.NewDecimal(3), nil)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(8), db.Trades()[1].Price)
+	sells, err = s.GetLiveOrders(ctx, models.Sell)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(sells), "expect take-profit order to be cancelled")
+	group, err = s.GetOCOGroup(ctx, *takeProfit.OCOGroupID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderCancelled, group[0].Status, "expect the cancelled take-profit order to be kept")
+	require.Equal(t, models.OrderFullyFilled, group[1].Status, "expect the filled stop order to be kept")
+	require.Equal(t, models.NewDecimal(0), group[1].Quantity)
+	require.NotNil(t, group[1].ClosedAt)
+}
+
+func TestDeleteOCO(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+
+	maker, other := "maker", "other"
+	stopPrice := models.NewDecimal(9)
+	takeProfit := &models.Order{UserID: &maker, Action: models.Sell, Price: models.NewDecimal(12), Quantity: models.NewDecimal(5)}
+	stopLoss := &models.Order{UserID: &maker, Action: models.Sell, Price: models.NewDecimal(8), Quantity: models.NewDecimal(5), Status: models.OrderPending, StopPrice: &stopPrice}
+	require.NoError(t, s.MakeOCO(ctx, []*models.Order{takeProfit, stopLoss}, nil))
+
+	require.ErrorIs(t, s.Delete(ctx, &other, takeProfit.ID, nil), models.ErrorNotFound, "expect orders of another user not to be found")
+	group, err := s.GetOCOGroup(ctx, *takeProfit.OCOGroupID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(group))
+
+	require.NoError(t, s.Delete(ctx, &maker, takeProfit.ID, nil))
+	group, err = s.GetOCOGroup(ctx, *takeProfit.OCOGroupID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(group), "expect cancelled orders to be kept")
+	for _, order := range group {
+		require.Equal(t, models.OrderCancelled, order.Status, "expect the group to be cancelled along with the order")
+		require.Equal(t, now, *order.ClosedAt)
+	}
+	require.ErrorIs(t, s.Delete(ctx, &maker, stopLoss.ID, nil), models.ErrorNotFound, "expect cancelled orders not to be cancelled again")
+}
+
+func TestTrailStops(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+
+	maker, tickSize := "maker", models.NewDecimal(1)/2
+	trailAmount := models.NewDecimal(1)
+	order := &models.Order{
+		ID:          "trailing",
+		UserID:      &maker,
+		Action:      models.Sell,
+		Quantity:    models.NewDecimal(5),
+		Status:      models.OrderPending,
+		TrailAmount: &trailAmount,
+		LimitOffset: tickSize,
+	}
+	moved, err := order.Trail(models.NewDecimal(10), tickSize)
+	require.NoError(t, err)
+	require.True(t, moved)
+	require.NoError(t, s.Make(ctx, order, nil))
+
+	trailed, err := s.TrailStops(ctx, models.NewDecimal(12), tickSize)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(trailed))
+	require.Equal(t, models.NewDecimal(11), *trailed[0].StopPrice, "expect sell stop to follow rising price")
+	require.Equal(t, models.NewDecimal(11)-tickSize, trailed[0].Price, "expect limit price to keep its offset")
+
+	trailed, err = s.TrailStops(ctx, models.NewDecimal(11)+tickSize, tickSize)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(trailed), "expect sell stop not to follow falling price")
+
+	triggered, err := s.TriggerStops(ctx, models.NewDecimal(11))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(triggered))
+	require.Equal(t, models.NewDecimal(11)-tickSize, triggered[0].Price)
+
+	trailed, err = s.TrailStops(ctx, models.NewDecimal(20), tickSize)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(trailed), "expect triggered order not to trail anymore")
+}
+
+func TestUncross(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 8, 55, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+
+	early, late, seller := "early", "late", "seller"
+	for _, order := range []*models.Order{
+		{ID: "buy-11", UserID: &early, Action: models.Buy, Price: models.NewDecimal(11), Quantity: models.NewDecimal(5)},
+		{ID: "buy-10", UserID: &late, Action: models.Buy, Price: models.NewDecimal(10), Quantity: models.NewDecimal(5)},
+		{ID: "sell-10", UserID: &seller, Action: models.Sell, Price: models.NewDecimal(10), Quantity: models.NewDecimal(3)},
+		{ID: "sell-9", UserID: &seller, Action: models.Sell, Price: models.NewDecimal(9), Quantity: models.NewDecimal(4)},
+	} {
+		require.NoError(t, s.Make(ctx, order, nil))
+	}
+
+	auction, err := s.Uncross(ctx, models.NewDecimal(10), nil)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(10), *auction.Price)
+	require.Equal(t, models.NewDecimal(7), auction.Volume)
+	for _, trade := range db.Trades() {
+		require.Equal(t, models.NewDecimal(10), trade.Price, "expect every trade at the clearing price")
+	}
+	require.Equal(t, 3, len(db.Trades()))
+
+	buys, err := s.GetLiveOrders(ctx, models.Buy)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(buys))
+	require.Equal(t, "buy-10", buys[0].ID, "expect the higher bid to be filled first")
+	require.Equal(t, models.NewDecimal(3), buys[0].Quantity)
+	sells, err := s.GetLiveOrders(ctx, models.Sell)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(sells))
+
+	auction, err = s.Uncross(ctx, models.NewDecimal(10), nil)
+	require.NoError(t, err)
+	require.Nil(t, auction.Price, "expect nothing left to uncross")
+}
+
+func TestUncrossIceberg(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 8, 55, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+
+	buyer, seller := "buyer", "seller"
+	display := models.NewDecimal(2)
+	for _, order := range []*models.Order{
+		// made before ownership was recorded, it takes no part in auctions
+		{ID: "legacy", Action: models.Sell, Price: models.NewDecimal(9), Quantity: models.NewDecimal(3)},
+		{ID: "iceberg", UserID: &seller, Action: models.Sell, Price: models.NewDecimal(10), Quantity: display, DisplayQuantity: &display, HiddenQuantity: models.NewDecimal(3)},
+		{ID: "buy", UserID: &buyer, Action: models.Buy, Price: models.NewDecimal(11), Quantity: models.NewDecimal(4)},
+	} {
+		require.NoError(t, s.Make(ctx, order, nil))
+	}
+
+	indicative, err := s.GetIndicativeAuction(ctx, models.NewDecimal(10))
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(10), *indicative.Price)
+	require.Equal(t, models.NewDecimal(4), indicative.Volume, "expect hidden quantity to count")
+	require.Equal(t, models.NewDecimal(-1), indicative.Imbalance)
+
+	now = now.Add(5 * time.Minute)
+	auction, err := s.Uncross(ctx, models.NewDecimal(10), nil)
+	require.NoError(t, err)
+	require.Equal(t, indicative, auction, "expect the board to uncross where it was indicated")
+	trades := db.Trades()
+	require.Equal(t, 2, len(trades), "expect the replenished slice to be matched")
+	for _, trade := range trades {
+		require.Equal(t, "iceberg", trade.MakerOrderID)
+		require.Equal(t, models.NewDecimal(2), trade.Quantity)
+	}
+
+	sells, err := s.GetLiveOrders(ctx, models.Sell)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(sells))
+	require.Equal(t, "iceberg", sells[0].ID, "expect best price last")
+	require.Equal(t, models.NewDecimal(1), sells[0].Quantity)
+	require.Equal(t, models.NewDecimal(0), sells[0].HiddenQuantity)
+	require.Equal(t, now, sells[0].CreatedAt, "expect replenished slice to take new time priority")
+}
+
+func TestListAndDeleteByUser(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+
+	alice, bob := "alice", "bob"
+	for i, userID := range []*string{&alice, &bob, &alice, &bob} {
+		require.NoError(t, s.Make(ctx, &models.Order{
+			UserID:   userID,
+			Action:   models.Sell,
+			Price:    models.NewDecimal(int64(11 + i)),
+			Quantity: models.NewDecimal(1),
+		}, nil))
+		now = now.Add(time.Minute)
+	}
+
+	orders, next, err := s.List(ctx, &models.OrderFilter{}, "", 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(orders))
+	require.Equal(t, models.NewDecimal(14), orders[0].Price, "expect newest order first")
+	require.Equal(t, models.NewCursor(orders[2].CreatedAt, orders[2].ID), next)
+	// the next page carries on after the last order of the page is gone
+	require.NoError(t, s.Delete(ctx, nil, orders[2].ID, nil))
+	orders, next, err = s.List(ctx, &models.OrderFilter{}, next, 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(orders))
+	require.Equal(t, models.NewDecimal(11), orders[0].Price)
+	require.Equal(t, "", next, "expect no next page after the last one")
+
+	_, _, err = s.List(ctx, &models.OrderFilter{}, "malformed", 2)
+	require.ErrorIs(t, err, models.ErrorWrongParams)
+
+	orders, _, err = s.List(ctx, &models.OrderFilter{UserID: &alice}, "", 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(orders))
+
+	audit := &models.AuditEntry{ActorID: "admin", Action: models.AuditAdminCancelUser, Target: alice}
+	deleted, err := s.DeleteByUser(ctx, alice, audit)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	require.NotZero(t, audit.ID, "expect audit entry to be recorded")
+	orders, _, err = s.List(ctx, &models.OrderFilter{UserID: &alice}, "", 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(orders), "expect cancelled orders to be kept")
+	for _, order := range orders {
+		require.Equal(t, models.OrderCancelled, order.Status)
+	}
+	open := models.OrderOpen
+	orders, _, err = s.List(ctx, &models.OrderFilter{Status: &open}, "", 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(orders))
+	require.Equal(t, &bob, orders[0].UserID)
+
+	// users only delete their own orders
+	require.ErrorIs(t, s.Delete(ctx, &alice, orders[0].ID, nil), models.ErrorNotFound)
+	order, err := s.Get(ctx, orders[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderOpen, order.Status, "expect order of another user to be kept open")
+
+	second := &models.AuditEntry{ActorID: "admin", Action: models.AuditAdminCancelOrder, Target: orders[0].ID}
+	require.NoError(t, s.Delete(ctx, nil, orders[0].ID, second))
+	require.ErrorIs(t, s.Delete(ctx, nil, orders[0].ID, nil), models.ErrorNotFound)
+	require.NotEmpty(t, audit.Hash)
+	require.Equal(t, audit.Hash, second.PrevHash, "expect audit entries to be chained")
+	hash, err := second.Digest()
+	require.NoError(t, err)
+	require.Equal(t, hash, second.Hash)
+}
+
+func TestListTrades(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+	p := NewPosition(db)
+
+	maker := "maker"
+	require.NoError(t, s.Make(ctx, &models.Order{
+		UserID:   &maker,
+		Action:   models.Sell,
+		Price:    models.NewDecimal(11),
+		Quantity: models.NewDecimal(3),
+	}, nil))
+	for i := 0; i < 3; i++ {
+		now = now.Add(time.Minute)
+		_, err := s.Take(ctx, "taker", models.Buy, models.NewDecimal(1), nil)
+		require.NoError(t, err)
+	}
+
+	trades, next, err := p.ListTrades(ctx, &models.TradeFilter{UserID: "taker"}, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(trades))
+	require.Equal(t, now, trades[0].CreatedAt, "expect most recent trade first")
+	require.Equal(t, models.NewCursor(trades[1].CreatedAt, trades[1].ID), next)
+	trades, next, err = p.ListTrades(ctx, &models.TradeFilter{UserID: "taker"}, next, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(trades))
+	require.Equal(t, "", next, "expect no next page after the last one")
+
+	to := now
+	trades, _, err = p.ListTrades(ctx, &models.TradeFilter{UserID: maker, To: &to}, "", 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(trades), "expect trades executed at To to be excluded")
+}
+
+func TestLatestPrice(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	db := NewDB(func() time.Time { return now })
+	s := NewOrder(db)
+
+	latestPrice, err := s.GetLatestPrice(ctx)
+	require.NoError(t, err)
+	require.Nil(t, latestPrice, "expect no latest price before anything trades")
+
+	maker := "maker"
+	for _, price := range []int64{12, 10} {
+		require.NoError(t, s.Make(ctx, &models.Order{
+			UserID:   &maker,
+			Action:   models.Sell,
+			Price:    models.NewDecimal(price),
+			Quantity: models.NewDecimal(1),
+		}, nil))
+	}
+	start := now
+	now = now.Add(time.Minute)
+	_, err = s.Take(ctx, "taker", models.Buy, models.NewDecimal(2), nil)
+	require.NoError(t, err)
+
+	latestPrice, err = s.GetLatestPrice(ctx)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(12), *latestPrice, "expect the last trade of the take")
+	low, high, err := s.GetPriceRange(ctx, start)
+	require.NoError(t, err)
+	require.Equal(t, models.NewDecimal(10), *low)
+	require.Equal(t, models.NewDecimal(12), *high)
+	low, high, err = s.GetPriceRange(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	require.Nil(t, low)
+	require.Nil(t, high)
+}
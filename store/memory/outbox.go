@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+)
+
+type outboxStore struct {
+	db *DB
+}
+
+// NewOutbox returns an in-memory implementation of store.Outbox
+func NewOutbox(db *DB) store.Outbox {
+	return &outboxStore{
+		db: db,
+	}
+}
+
+func (s *outboxStore) Relay(ctx context.Context, limit int, publish func(*models.OutboxMessage) error) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	now := s.db.now()
+	// aggregates with an earlier message pending are skipped to keep messages in order
+	blocked := map[string]bool{}
+	published := 0
+	for _, row := range s.db.outbox {
+		if published >= limit {
+			break
+		}
+		if row.published || blocked[row.message.AggregateID] {
+			continue
+		}
+		if row.availableAt.After(now) {
+			blocked[row.message.AggregateID] = true
+			continue
+		}
+		m := row.message
+		if err := publish(&m); err != nil {
+			row.message.Attempts++
+			backoff := time.Duration(min(1<<row.message.Attempts, 300)) * time.Second
+			row.availableAt = now.Add(backoff)
+			blocked[row.message.AggregateID] = true
+			continue
+		}
+		row.published = true
+		published++
+	}
+	return published, nil
+}
+
+// enqueue appends messages to the outbox, it should be called with mu held
+func (db *DB) enqueue(messages []*models.OutboxMessage) {
+	now := db.now()
+	for _, m := range messages {
+		db.outbox = append(db.outbox, &outboxRow{
+			message: models.OutboxMessage{
+				ID:          int64(len(db.outbox) + 1),
+				AggregateID: m.AggregateID,
+				Topic:       m.Topic,
+				Payload:     m.Payload,
+				CreatedAt:   now,
+			},
+			availableAt: now,
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+)
+
+type positionStore struct {
+	db *DB
+}
+
+// NewPosition returns an in-memory implementation of store.Position
+func NewPosition(db *DB) store.Position {
+	return &positionStore{
+		db: db,
+	}
+}
+
+func (s *positionStore) Get(ctx context.Context, userID string) (*models.Position, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	position, ok := s.db.positions[userID]
+	if !ok {
+		// a user without any trade holds an empty position
+		return &models.Position{UserID: userID}, nil
+	}
+	p := *position
+	return &p, nil
+}
+
+func (s *positionStore) GetTrades(ctx context.Context, userID string, limit int) ([]*models.Trade, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	trades := []*models.Trade{}
+	for i := len(s.db.trades) - 1; i >= 0 && len(trades) < limit; i-- {
+		trade := s.db.trades[i]
+		if trade.TakerID == userID || (trade.MakerID != nil && *trade.MakerID == userID) {
+			t := *trade
+			trades = append(trades, &t)
+		}
+	}
+	return trades, nil
+}
+
+func (s *positionStore) ListTrades(ctx context.Context, filter *models.TradeFilter, next string, count int) ([]*models.Trade, string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var cursor *models.Cursor
+	if next != "" {
+		var err error
+		if cursor, err = models.ParseCursor(next); err != nil {
+			return nil, "", err
+		}
+	}
+	// ordered like the postgres store does, by creation time then ID
+	newer := func(a, b *models.Trade) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	}
+	trades := []*models.Trade{}
+	for _, trade := range s.db.trades {
+		if (trade.TakerID != filter.UserID && (trade.MakerID == nil || *trade.MakerID != filter.UserID)) ||
+			(filter.From != nil && trade.CreatedAt.Before(*filter.From)) ||
+			(filter.To != nil && !trade.CreatedAt.Before(*filter.To)) ||
+			(cursor != nil && !newer(&models.Trade{ID: cursor.ID, CreatedAt: cursor.CreatedAt}, trade)) {
+			continue
+		}
+		t := *trade
+		trades = append(trades, &t)
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		return newer(trades[i], trades[j])
+	})
+	if len(trades) < count {
+		return trades, "", nil
+	}
+	trades = trades[:count]
+	return trades, models.NewCursor(trades[count-1].CreatedAt, trades[count-1].ID), nil
+}
+
+// fillPosition applies a trade to the position of given user, it should be called with mu held
+func (db *DB) fillPosition(userID string, action models.OrderAction, price, quantity models.Decimal) error {
+	position := models.Position{UserID: userID}
+	if p, ok := db.positions[userID]; ok {
+		position = *p
+	}
+	if err := position.Fill(action, price, quantity); err != nil {
+		return err
+	}
+	position.UpdatedAt = db.now()
+	db.positions[userID] = &position
+	return nil
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/kickstart/store"
+)
+
+type userStore struct {
+	db *DB
+}
+
+// NewUser returns an in-memory implementation of store.User
+func NewUser(db *DB) store.User {
+	return &userStore{
+		db: db,
+	}
+}
+
+func (s *userStore) Get(ctx context.Context, userID string) (*models.User, error) {
+	s.db.userMu.Lock()
+	defer s.db.userMu.Unlock()
+
+	user, ok := s.db.users[userID]
+	if !ok {
+		return nil, models.ErrorNotFound
+	}
+	u := *user
+	return &u, nil
+}
+
+func (s *userStore) GetByType(ctx context.Context, userType models.UserType) ([]*models.User, error) {
+	s.db.userMu.Lock()
+	defer s.db.userMu.Unlock()
+
+	users := []*models.User{}
+	for _, user := range s.db.users {
+		if user.Type == userType {
+			u := *user
+			users = append(users, &u)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (s *userStore) GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	s.db.userMu.Lock()
+	defer s.db.userMu.Unlock()
+
+	preferences, ok := s.db.preferences[userID]
+	if !ok {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+	p := *preferences
+	return &p, nil
+}
+
+func (s *userStore) UpdateNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	s.db.userMu.Lock()
+	defer s.db.userMu.Unlock()
+
+	preferences.UpdatedAt = s.db.now()
+	p := *preferences
+	s.db.preferences[p.UserID] = &p
+	return nil
+}
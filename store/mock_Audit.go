@@ -0,0 +1,114 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAudit is an autogenerated mock type for the Audit type
+type MockAudit struct {
+	mock.Mock
+}
+
+// List provides a mock function with given fields: ctx, filter, next, count
+func (_m *MockAudit) List(ctx context.Context, filter *models.AuditFilter, next string, count int) ([]*models.AuditEntry, string, error) {
+	ret := _m.Called(ctx, filter, next, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.AuditEntry
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditFilter, string, int) ([]*models.AuditEntry, string, error)); ok {
+		return rf(ctx, filter, next, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditFilter, string, int) []*models.AuditEntry); ok {
+		r0 = rf(ctx, filter, next, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AuditFilter, string, int) string); ok {
+		r1 = rf(ctx, filter, next, count)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.AuditFilter, string, int) error); ok {
+		r2 = rf(ctx, filter, next, count)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Record provides a mock function with given fields: ctx, entry
+func (_m *MockAudit) Record(ctx context.Context, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Scan provides a mock function with given fields: ctx, afterID, count
+func (_m *MockAudit) Scan(ctx context.Context, afterID int64, count int) ([]*models.AuditEntry, error) {
+	ret := _m.Called(ctx, afterID, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scan")
+	}
+
+	var r0 []*models.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]*models.AuditEntry, error)); ok {
+		return rf(ctx, afterID, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []*models.AuditEntry); ok {
+		r0 = rf(ctx, afterID, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterID, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockAudit creates a new instance of MockAudit. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAudit(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAudit {
+	mock := &MockAudit{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
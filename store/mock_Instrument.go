@@ -0,0 +1,113 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockInstrument is an autogenerated mock type for the Instrument type
+type MockInstrument struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, symbol
+func (_m *MockInstrument) Get(ctx context.Context, symbol string) (*models.Instrument, error) {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.Instrument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Instrument, error)); ok {
+		return rf(ctx, symbol)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Instrument); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Instrument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Halt provides a mock function with given fields: ctx, symbol, reason, minutes
+func (_m *MockInstrument) Halt(ctx context.Context, symbol string, reason string, minutes int) error {
+	ret := _m.Called(ctx, symbol, reason, minutes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Halt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(ctx, symbol, reason, minutes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Resume provides a mock function with given fields: ctx, symbol
+func (_m *MockInstrument) Resume(ctx context.Context, symbol string) error {
+	ret := _m.Called(ctx, symbol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTimeZone provides a mock function with given fields: ctx, symbol, timeZone
+func (_m *MockInstrument) SetTimeZone(ctx context.Context, symbol string, timeZone string) error {
+	ret := _m.Called(ctx, symbol, timeZone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimeZone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, symbol, timeZone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockInstrument creates a new instance of MockInstrument. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInstrument(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInstrument {
+	mock := &MockInstrument{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
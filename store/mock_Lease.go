@@ -0,0 +1,79 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockLease is an autogenerated mock type for the Lease type
+type MockLease struct {
+	mock.Mock
+}
+
+// Acquire provides a mock function with given fields: ctx, name, holder, address, ttl
+func (_m *MockLease) Acquire(ctx context.Context, name string, holder string, address string, ttl time.Duration) (*models.Lease, error) {
+	ret := _m.Called(ctx, name, holder, address, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acquire")
+	}
+
+	var r0 *models.Lease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) (*models.Lease, error)); ok {
+		return rf(ctx, name, holder, address, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) *models.Lease); ok {
+		r0 = rf(ctx, name, holder, address, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Lease)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, name, holder, address, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Release provides a mock function with given fields: ctx, name, holder
+func (_m *MockLease) Release(ctx context.Context, name string, holder string) error {
+	ret := _m.Called(ctx, name, holder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, holder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockLease creates a new instance of MockLease. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLease(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLease {
+	mock := &MockLease{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
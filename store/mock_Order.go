@@ -7,6 +7,8 @@ import (
 
 	models "github.com/A-pen-app/kickstart/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockOrder is an autogenerated mock type for the Order type
@@ -14,17 +16,35 @@ type MockOrder struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: ctx, orderID
-func (_m *MockOrder) Delete(ctx context.Context, orderID string) error {
-	ret := _m.Called(ctx, orderID)
+// Batch provides a mock function with given fields: ctx, userID, items
+func (_m *MockOrder) Batch(ctx context.Context, userID string, items []*models.BatchItem) error {
+	ret := _m.Called(ctx, userID, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*models.BatchItem) error); ok {
+		r0 = rf(ctx, userID, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, userID, orderID, audit
+func (_m *MockOrder) Delete(ctx context.Context, userID *string, orderID string, audit *models.AuditEntry) error {
+	ret := _m.Called(ctx, userID, orderID, audit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, *string, string, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, userID, orderID, audit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,6 +52,154 @@ func (_m *MockOrder) Delete(ctx context.Context, orderID string) error {
 	return r0
 }
 
+// DeleteByUser provides a mock function with given fields: ctx, userID, audit
+func (_m *MockOrder) DeleteByUser(ctx context.Context, userID string, audit *models.AuditEntry) (int, error) {
+	ret := _m.Called(ctx, userID, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUser")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.AuditEntry) (int, error)); ok {
+		return rf(ctx, userID, audit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.AuditEntry) int); ok {
+		r0 = rf(ctx, userID, audit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.AuditEntry) error); ok {
+		r1 = rf(ctx, userID, audit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, orderID
+func (_m *MockOrder) Get(ctx context.Context, orderID string) (*models.Order, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Order, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Order); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBestPrice provides a mock function with given fields: ctx, action
+func (_m *MockOrder) GetBestPrice(ctx context.Context, action models.OrderAction) (*models.Decimal, error) {
+	ret := _m.Called(ctx, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBestPrice")
+	}
+
+	var r0 *models.Decimal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderAction) (*models.Decimal, error)); ok {
+		return rf(ctx, action)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderAction) *models.Decimal); ok {
+		r0 = rf(ctx, action)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Decimal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.OrderAction) error); ok {
+		r1 = rf(ctx, action)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIndicativeAuction provides a mock function with given fields: ctx, defaultPrice
+func (_m *MockOrder) GetIndicativeAuction(ctx context.Context, defaultPrice models.Decimal) (*models.Auction, error) {
+	ret := _m.Called(ctx, defaultPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIndicativeAuction")
+	}
+
+	var r0 *models.Auction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Decimal) (*models.Auction, error)); ok {
+		return rf(ctx, defaultPrice)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Decimal) *models.Auction); ok {
+		r0 = rf(ctx, defaultPrice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Auction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Decimal) error); ok {
+		r1 = rf(ctx, defaultPrice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestPrice provides a mock function with given fields: ctx
+func (_m *MockOrder) GetLatestPrice(ctx context.Context) (*models.Decimal, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestPrice")
+	}
+
+	var r0 *models.Decimal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.Decimal, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.Decimal); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Decimal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLiveOrders provides a mock function with given fields: ctx, action
 func (_m *MockOrder) GetLiveOrders(ctx context.Context, action models.OrderAction) ([]*models.Order, error) {
 	ret := _m.Called(ctx, action)
@@ -62,17 +230,141 @@ func (_m *MockOrder) GetLiveOrders(ctx context.Context, action models.OrderActio
 	return r0, r1
 }
 
-// Make provides a mock function with given fields: ctx, action, price, quantity
-func (_m *MockOrder) Make(ctx context.Context, action models.OrderAction, price int, quantity int) error {
-	ret := _m.Called(ctx, action, price, quantity)
+// GetOCOGroup provides a mock function with given fields: ctx, groupID
+func (_m *MockOrder) GetOCOGroup(ctx context.Context, groupID string) ([]*models.Order, error) {
+	ret := _m.Called(ctx, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOCOGroup")
+	}
+
+	var r0 []*models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Order, error)); ok {
+		return rf(ctx, groupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Order); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPriceRange provides a mock function with given fields: ctx, since
+func (_m *MockOrder) GetPriceRange(ctx context.Context, since time.Time) (*models.Decimal, *models.Decimal, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceRange")
+	}
+
+	var r0 *models.Decimal
+	var r1 *models.Decimal
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (*models.Decimal, *models.Decimal, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *models.Decimal); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Decimal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) *models.Decimal); ok {
+		r1 = rf(ctx, since)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.Decimal)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// List provides a mock function with given fields: ctx, filter, next, count
+func (_m *MockOrder) List(ctx context.Context, filter *models.OrderFilter, next string, count int) ([]*models.Order, string, error) {
+	ret := _m.Called(ctx, filter, next, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Order
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OrderFilter, string, int) ([]*models.Order, string, error)); ok {
+		return rf(ctx, filter, next, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OrderFilter, string, int) []*models.Order); ok {
+		r0 = rf(ctx, filter, next, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.OrderFilter, string, int) string); ok {
+		r1 = rf(ctx, filter, next, count)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.OrderFilter, string, int) error); ok {
+		r2 = rf(ctx, filter, next, count)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Make provides a mock function with given fields: ctx, order, messages
+func (_m *MockOrder) Make(ctx context.Context, order *models.Order, messages []*models.OutboxMessage) error {
+	ret := _m.Called(ctx, order, messages)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Make")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.OrderAction, int, int) error); ok {
-		r0 = rf(ctx, action, price, quantity)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Order, []*models.OutboxMessage) error); ok {
+		r0 = rf(ctx, order, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MakeOCO provides a mock function with given fields: ctx, orders, messages
+func (_m *MockOrder) MakeOCO(ctx context.Context, orders []*models.Order, messages []*models.OutboxMessage) error {
+	ret := _m.Called(ctx, orders, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MakeOCO")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Order, []*models.OutboxMessage) error); ok {
+		r0 = rf(ctx, orders, messages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -80,27 +372,117 @@ func (_m *MockOrder) Make(ctx context.Context, action models.OrderAction, price
 	return r0
 }
 
-// Take provides a mock function with given fields: ctx, action, quantity
-func (_m *MockOrder) Take(ctx context.Context, action models.OrderAction, quantity int) (int, error) {
-	ret := _m.Called(ctx, action, quantity)
+// Take provides a mock function with given fields: ctx, userID, action, quantity, outbox
+func (_m *MockOrder) Take(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, outbox OutboxFunc) (models.Decimal, error) {
+	ret := _m.Called(ctx, userID, action, quantity, outbox)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Take")
 	}
 
-	var r0 int
+	var r0 models.Decimal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.OrderAction, int) (int, error)); ok {
-		return rf(ctx, action, quantity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, OutboxFunc) (models.Decimal, error)); ok {
+		return rf(ctx, userID, action, quantity, outbox)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.OrderAction, int) int); ok {
-		r0 = rf(ctx, action, quantity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.OrderAction, models.Decimal, OutboxFunc) models.Decimal); ok {
+		r0 = rf(ctx, userID, action, quantity, outbox)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(models.Decimal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.OrderAction, models.Decimal, OutboxFunc) error); ok {
+		r1 = rf(ctx, userID, action, quantity, outbox)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TrailStops provides a mock function with given fields: ctx, latestPrice, tickSize
+func (_m *MockOrder) TrailStops(ctx context.Context, latestPrice models.Decimal, tickSize models.Decimal) ([]*models.Order, error) {
+	ret := _m.Called(ctx, latestPrice, tickSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TrailStops")
+	}
+
+	var r0 []*models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Decimal, models.Decimal) ([]*models.Order, error)); ok {
+		return rf(ctx, latestPrice, tickSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Decimal, models.Decimal) []*models.Order); ok {
+		r0 = rf(ctx, latestPrice, tickSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Decimal, models.Decimal) error); ok {
+		r1 = rf(ctx, latestPrice, tickSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerStops provides a mock function with given fields: ctx, latestPrice
+func (_m *MockOrder) TriggerStops(ctx context.Context, latestPrice models.Decimal) ([]*models.Order, error) {
+	ret := _m.Called(ctx, latestPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerStops")
+	}
+
+	var r0 []*models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Decimal) ([]*models.Order, error)); ok {
+		return rf(ctx, latestPrice)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Decimal) []*models.Order); ok {
+		r0 = rf(ctx, latestPrice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Decimal) error); ok {
+		r1 = rf(ctx, latestPrice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Uncross provides a mock function with given fields: ctx, defaultPrice, outbox
+func (_m *MockOrder) Uncross(ctx context.Context, defaultPrice models.Decimal, outbox OutboxFunc) (*models.Auction, error) {
+	ret := _m.Called(ctx, defaultPrice, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Uncross")
+	}
+
+	var r0 *models.Auction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Decimal, OutboxFunc) (*models.Auction, error)); ok {
+		return rf(ctx, defaultPrice, outbox)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Decimal, OutboxFunc) *models.Auction); ok {
+		r0 = rf(ctx, defaultPrice, outbox)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Auction)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.OrderAction, int) error); ok {
-		r1 = rf(ctx, action, quantity)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Decimal, OutboxFunc) error); ok {
+		r1 = rf(ctx, defaultPrice, outbox)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOutbox is an autogenerated mock type for the Outbox type
+type MockOutbox struct {
+	mock.Mock
+}
+
+// Relay provides a mock function with given fields: ctx, limit, publish
+func (_m *MockOutbox) Relay(ctx context.Context, limit int, publish func(*models.OutboxMessage) error) (int, error) {
+	ret := _m.Called(ctx, limit, publish)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Relay")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func(*models.OutboxMessage) error) (int, error)); ok {
+		return rf(ctx, limit, publish)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, func(*models.OutboxMessage) error) int); ok {
+		r0 = rf(ctx, limit, publish)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, func(*models.OutboxMessage) error) error); ok {
+		r1 = rf(ctx, limit, publish)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockOutbox creates a new instance of MockOutbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutbox {
+	mock := &MockOutbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOutboxFunc is an autogenerated mock type for the OutboxFunc type
+type MockOutboxFunc struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: trades
+func (_m *MockOutboxFunc) Execute(trades []*models.Trade) ([]*models.OutboxMessage, error) {
+	ret := _m.Called(trades)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 []*models.OutboxMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*models.Trade) ([]*models.OutboxMessage, error)); ok {
+		return rf(trades)
+	}
+	if rf, ok := ret.Get(0).(func([]*models.Trade) []*models.OutboxMessage); ok {
+		r0 = rf(trades)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OutboxMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*models.Trade) error); ok {
+		r1 = rf(trades)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockOutboxFunc creates a new instance of MockOutboxFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxFunc {
+	mock := &MockOutboxFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
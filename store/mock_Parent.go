@@ -0,0 +1,137 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockParent is an autogenerated mock type for the Parent type
+type MockParent struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, parent
+func (_m *MockParent) Create(ctx context.Context, parent *models.ParentOrder) error {
+	ret := _m.Called(ctx, parent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ParentOrder) error); ok {
+		r0 = rf(ctx, parent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Dispatch provides a mock function with given fields: ctx, now, limit, send
+func (_m *MockParent) Dispatch(ctx context.Context, now time.Time, limit int, send func(*models.ParentOrder) error) (int, error) {
+	ret := _m.Called(ctx, now, limit, send)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Dispatch")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, func(*models.ParentOrder) error) (int, error)); ok {
+		return rf(ctx, now, limit, send)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, func(*models.ParentOrder) error) int); ok {
+		r0 = rf(ctx, now, limit, send)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, func(*models.ParentOrder) error) error); ok {
+		r1 = rf(ctx, now, limit, send)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, parentID
+func (_m *MockParent) Get(ctx context.Context, parentID string) (*models.ParentOrder, error) {
+	ret := _m.Called(ctx, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.ParentOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ParentOrder, error)); ok {
+		return rf(ctx, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ParentOrder); ok {
+		r0 = rf(ctx, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ParentOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, parentID, from, to
+func (_m *MockParent) UpdateStatus(ctx context.Context, parentID string, from []models.ParentOrderStatus, to models.ParentOrderStatus) (*models.ParentOrder, error) {
+	ret := _m.Called(ctx, parentID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *models.ParentOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.ParentOrderStatus, models.ParentOrderStatus) (*models.ParentOrder, error)); ok {
+		return rf(ctx, parentID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.ParentOrderStatus, models.ParentOrderStatus) *models.ParentOrder); ok {
+		r0 = rf(ctx, parentID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ParentOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []models.ParentOrderStatus, models.ParentOrderStatus) error); ok {
+		r1 = rf(ctx, parentID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockParent creates a new instance of MockParent. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockParent(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockParent {
+	mock := &MockParent{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPosition is an autogenerated mock type for the Position type
+type MockPosition struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, userID
+func (_m *MockPosition) Get(ctx context.Context, userID string) (*models.Position, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.Position
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Position, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Position); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Position)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrades provides a mock function with given fields: ctx, userID, limit
+func (_m *MockPosition) GetTrades(ctx context.Context, userID string, limit int) ([]*models.Trade, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrades")
+	}
+
+	var r0 []*models.Trade
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*models.Trade, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*models.Trade); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Trade)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTrades provides a mock function with given fields: ctx, filter, next, count
+func (_m *MockPosition) ListTrades(ctx context.Context, filter *models.TradeFilter, next string, count int) ([]*models.Trade, string, error) {
+	ret := _m.Called(ctx, filter, next, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrades")
+	}
+
+	var r0 []*models.Trade
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TradeFilter, string, int) ([]*models.Trade, string, error)); ok {
+		return rf(ctx, filter, next, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TradeFilter, string, int) []*models.Trade); ok {
+		r0 = rf(ctx, filter, next, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Trade)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TradeFilter, string, int) string); ok {
+		r1 = rf(ctx, filter, next, count)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.TradeFilter, string, int) error); ok {
+		r2 = rf(ctx, filter, next, count)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewMockPosition creates a new instance of MockPosition. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPosition(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPosition {
+	mock := &MockPosition{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,157 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockRFQ is an autogenerated mock type for the RFQ type
+type MockRFQ struct {
+	mock.Mock
+}
+
+// Accept provides a mock function with given fields: ctx, rfqID, quoteID, now, outbox
+func (_m *MockRFQ) Accept(ctx context.Context, rfqID string, quoteID string, now time.Time, outbox OutboxFunc) (*models.Trade, error) {
+	ret := _m.Called(ctx, rfqID, quoteID, now, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accept")
+	}
+
+	var r0 *models.Trade
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, OutboxFunc) (*models.Trade, error)); ok {
+		return rf(ctx, rfqID, quoteID, now, outbox)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, OutboxFunc) *models.Trade); ok {
+		r0 = rf(ctx, rfqID, quoteID, now, outbox)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Trade)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, OutboxFunc) error); ok {
+		r1 = rf(ctx, rfqID, quoteID, now, outbox)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, rfq, messages
+func (_m *MockRFQ) Create(ctx context.Context, rfq *models.RFQ, messages []*models.OutboxMessage) error {
+	ret := _m.Called(ctx, rfq, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RFQ, []*models.OutboxMessage) error); ok {
+		r0 = rf(ctx, rfq, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateQuote provides a mock function with given fields: ctx, quote, now
+func (_m *MockRFQ) CreateQuote(ctx context.Context, quote *models.Quote, now time.Time) error {
+	ret := _m.Called(ctx, quote, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Quote, time.Time) error); ok {
+		r0 = rf(ctx, quote, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, rfqID
+func (_m *MockRFQ) Get(ctx context.Context, rfqID string) (*models.RFQ, error) {
+	ret := _m.Called(ctx, rfqID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.RFQ
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.RFQ, error)); ok {
+		return rf(ctx, rfqID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RFQ); ok {
+		r0 = rf(ctx, rfqID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RFQ)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, rfqID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuotes provides a mock function with given fields: ctx, rfqID
+func (_m *MockRFQ) GetQuotes(ctx context.Context, rfqID string) ([]*models.Quote, error) {
+	ret := _m.Called(ctx, rfqID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuotes")
+	}
+
+	var r0 []*models.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Quote, error)); ok {
+		return rf(ctx, rfqID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Quote); ok {
+		r0 = rf(ctx, rfqID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, rfqID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockRFQ creates a new instance of MockRFQ. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRFQ(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRFQ {
+	mock := &MockRFQ{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
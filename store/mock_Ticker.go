@@ -0,0 +1,61 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockTicker is an autogenerated mock type for the Ticker type
+type MockTicker struct {
+	mock.Mock
+}
+
+// List provides a mock function with given fields: ctx, since
+func (_m *MockTicker) List(ctx context.Context, since time.Time) ([]*models.Ticker, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Ticker
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.Ticker, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.Ticker); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Ticker)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockTicker creates a new instance of MockTicker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTicker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTicker {
+	mock := &MockTicker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package store
+
+import (
+	context "context"
+
+	models "github.com/A-pen-app/kickstart/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUser is an autogenerated mock type for the User type
+type MockUser struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, userID
+func (_m *MockUser) Get(ctx context.Context, userID string) (*models.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByType provides a mock function with given fields: ctx, userType
+func (_m *MockUser) GetByType(ctx context.Context, userType models.UserType) ([]*models.User, error) {
+	ret := _m.Called(ctx, userType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByType")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserType) ([]*models.User, error)); ok {
+		return rf(ctx, userType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserType) []*models.User); ok {
+		r0 = rf(ctx, userType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserType) error); ok {
+		r1 = rf(ctx, userType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotificationPreferences provides a mock function with given fields: ctx, userID
+func (_m *MockUser) GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationPreferences")
+	}
+
+	var r0 *models.NotificationPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.NotificationPreferences, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.NotificationPreferences); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.NotificationPreferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateNotificationPreferences provides a mock function with given fields: ctx, preferences
+func (_m *MockUser) UpdateNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	ret := _m.Called(ctx, preferences)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationPreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.NotificationPreferences) error); ok {
+		r0 = rf(ctx, preferences)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockUser creates a new instance of MockUser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUser(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUser {
+	mock := &MockUser{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
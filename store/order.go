@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/A-pen-app/kickstart/config"
 	"github.com/A-pen-app/kickstart/database"
@@ -15,15 +17,45 @@ import (
 	"github.com/lib/pq"
 )
 
+// bookLockKey identifies the advisory lock held by every transaction changing the board,
+// so orders are matched by one transaction at a time across all replicas.
+const bookLockKey = 7286033003
+
 type orderStore struct {
 	db *sqlx.DB
+	// how long to wait for the book lock before giving up with models.ErrorBookBusy
+	lockTimeout time.Duration
+	// symbol of the instrument traded on the board
+	instrument string
 }
 
 // NewOrder returns an implementation of store.Order
 func NewOrder(db *sqlx.DB) Order {
 	return &orderStore{
-		db: db,
+		db:          db,
+		lockTimeout: config.GetMilliseconds("BOOK_LOCK_TIMEOUT_MS"),
+		instrument:  config.GetString("INSTRUMENT"),
+	}
+}
+
+// lockBook waits at most lockTimeout for the book lock within transaction tx
+func (s *orderStore) lockBook(ctx context.Context, tx *sqlx.Tx) error {
+	return lockBook(ctx, tx, s.lockTimeout)
+}
+
+// lockBook waits at most timeout for the book lock within transaction tx, the timeout also bounds
+// waiting for row locks for the rest of tx. The lock is released when tx commits or rolls back,
+// so no error path can leak it.
+func lockBook(ctx context.Context, tx *sqlx.Tx, timeout time.Duration) error {
+	if _, err := tx.Exec("SELECT set_config('lock_timeout', $1, true)", fmt.Sprintf("%dms", timeout.Milliseconds())); err != nil {
+		logging.Errorw(ctx, "store set book lock timeout failed", "err", err)
+		return parseError(err)
+	}
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", bookLockKey); err != nil {
+		logging.Errorw(ctx, "store acquire book lock failed", "err", err, "timeout", timeout.String())
+		return parseError(err)
 	}
+	return nil
 }
 
 func (s *orderStore) GetLiveOrders(ctx context.Context, action models.OrderAction) ([]*models.Order, error) {
@@ -35,25 +67,37 @@ func (s *orderStore) GetLiveOrders(ctx context.Context, action models.OrderActio
 	query := `
 		SELECT 
 			id,
+			user_id,
 			action,
 			price,
 			quantity,
-			created_at
+			created_at,
+			display_quantity,
+			hidden_quantity,
+			status,
+			stop_price,
+			oco_group_id,
+			trail_amount,
+			trail_percent,
+			limit_offset,
+			closed_at
 		FROM public.order
 		WHERE 
 	`
 	conditions := []string{
 		"action = ?",
+		"status = ?",
 	}
 	values := []interface{}{
 		action,
+		models.OrderOpen,
 	}
 	query = query + strings.Join(conditions, " AND ") + " ORDER BY "
 	switch action {
 	case models.Buy:
-		query = query + "price ASC" // (latest order, highest price)
+		query = query + "price ASC" // (highest price, earliest order) last
 	case models.Sell:
-		query = query + "price DESC" // (latest order, lowest price)
+		query = query + "price DESC" // (lowest price, earliest order) last
 	default:
 		err := errors.New("invalid order action")
 		logging.Errorw(ctx, "store get live orders failed", "err", err)
@@ -62,7 +106,7 @@ func (s *orderStore) GetLiveOrders(ctx context.Context, action models.OrderActio
 	query += ", created_at DESC"
 
 	query = s.db.Rebind(query)
-	if err := s.db.Select(&orders, query, values...); err != nil {
+	if err := s.db.SelectContext(ctx, &orders, query, values...); err != nil {
 		if err == sql.ErrNoRows {
 			return orders, nil
 		}
@@ -72,26 +116,329 @@ func (s *orderStore) GetLiveOrders(ctx context.Context, action models.OrderActio
 	return orders, nil
 }
 
-func (s *orderStore) Make(ctx context.Context, action models.OrderAction, price, quantity int) error {
+func (s *orderStore) GetBestPrice(ctx context.Context, action models.OrderAction) (*models.Decimal, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.orders.best_price").End()
+	}
+
+	var best *models.Decimal
+	query := ""
+	switch action {
+	case models.Buy:
+		query = "SELECT max(price) FROM public.order WHERE action = ? AND status = ?"
+	case models.Sell:
+		query = "SELECT min(price) FROM public.order WHERE action = ? AND status = ?"
+	default:
+		err := errors.New("invalid order action")
+		logging.Errorw(ctx, "store get best price failed", "err", err)
+		return nil, err
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&best, query, action, models.OrderOpen); err != nil {
+		logging.Errorw(ctx, "store get best price failed", "err", err, "action", action)
+		return nil, parseError(err)
+	}
+	return best, nil
+}
+
+func (s *orderStore) GetLatestPrice(ctx context.Context) (*models.Decimal, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.trades.latest_price").End()
+	}
+	return s.latestPrice(ctx, s.db)
+}
+
+// latestPrice reads the price of the latest trade on the board by q, either the database or a transaction
+func (s *orderStore) latestPrice(ctx context.Context, q sqlx.Queryer) (*models.Decimal, error) {
+	var prices []models.Decimal
+	query := `
+		SELECT price
+		FROM public.trade
+		WHERE
+		NOT off_book
+		ORDER BY seq DESC
+		LIMIT 1
+	`
+	if err := sqlx.Select(q, &prices, query); err != nil {
+		logging.Errorw(ctx, "store get latest price failed", "err", err)
+		return nil, parseError(err)
+	}
+	if len(prices) == 0 {
+		return nil, nil
+	}
+	return &prices[0], nil
+}
+
+func (s *orderStore) GetPriceRange(ctx context.Context, since time.Time) (*models.Decimal, *models.Decimal, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.trades.price_range").End()
+	}
+
+	var r struct {
+		Low  *models.Decimal `db:"low"`
+		High *models.Decimal `db:"high"`
+	}
+	query := `
+		SELECT
+			min(price) AS low,
+			max(price) AS high
+		FROM public.trade
+		WHERE
+		NOT off_book AND
+		created_at >= ?::timestamptz
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.GetContext(ctx, &r, query, since); err != nil {
+		logging.Errorw(ctx, "store get price range failed", "err", err, "since", since)
+		return nil, nil, parseError(err)
+	}
+	return r.Low, r.High, nil
+}
+
+func (s *orderStore) Get(ctx context.Context, orderID string) (*models.Order, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.order").End()
+	}
+
+	order := models.Order{}
+	query := `
+		SELECT
+			id,
+			user_id,
+			action,
+			price,
+			quantity,
+			created_at,
+			display_quantity,
+			hidden_quantity,
+			status,
+			stop_price,
+			oco_group_id,
+			trail_amount,
+			trail_percent,
+			limit_offset,
+			closed_at
+		FROM public.order
+		WHERE
+		id = ?
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&order, query, orderID); err != nil {
+		logging.Errorw(ctx, "store get order failed", "err", err, "orderID", orderID)
+		return nil, parseError(err)
+	}
+	return &order, nil
+}
+
+func (s *orderStore) GetOCOGroup(ctx context.Context, groupID string) ([]*models.Order, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.orders.oco_group").End()
+	}
+
+	orders := []*models.Order{}
+	query := `
+		SELECT
+			id,
+			user_id,
+			action,
+			price,
+			quantity,
+			created_at,
+			display_quantity,
+			hidden_quantity,
+			status,
+			stop_price,
+			oco_group_id,
+			trail_amount,
+			trail_percent,
+			limit_offset,
+			closed_at
+		FROM public.order
+		WHERE
+		oco_group_id = ?
+		ORDER BY stop_price NULLS FIRST
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.Select(&orders, query, groupID); err != nil {
+		logging.Errorw(ctx, "store get oco group failed", "err", err, "groupID", groupID)
+		return nil, parseError(err)
+	}
+	return orders, nil
+}
+
+func (s *orderStore) List(ctx context.Context, filter *models.OrderFilter, next string, count int) ([]*models.Order, string, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.list.orders").End()
+	}
+
+	orders := []*models.Order{}
+	query := `
+		SELECT
+			id,
+			user_id,
+			action,
+			price,
+			quantity,
+			created_at,
+			display_quantity,
+			hidden_quantity,
+			status,
+			stop_price,
+			oco_group_id,
+			trail_amount,
+			trail_percent,
+			limit_offset,
+			closed_at
+		FROM public.order
+		WHERE
+	`
+	conditions := []string{
+		"TRUE",
+	}
+	values := []interface{}{}
+	if filter.UserID != nil {
+		conditions = append(conditions, "user_id = ?")
+		values = append(values, *filter.UserID)
+	}
+	if filter.Action != nil {
+		conditions = append(conditions, "action = ?")
+		values = append(values, *filter.Action)
+	}
+	if filter.Status != nil {
+		conditions = append(conditions, "status = ?")
+		values = append(values, *filter.Status)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		values = append(values, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < ?")
+		values = append(values, *filter.To)
+	}
+	if next != "" {
+		cursor, err := models.ParseCursor(next)
+		if err != nil {
+			return nil, "", err
+		}
+		conditions = append(conditions, "(created_at, id) < (?, ?)")
+		values = append(values, cursor.CreatedAt, cursor.ID)
+	}
+	query = query + strings.Join(conditions, " AND ") + " ORDER BY created_at DESC, id DESC LIMIT ?"
+	values = append(values, count)
+
+	query = s.db.Rebind(query)
+	if err := s.db.SelectContext(ctx, &orders, query, values...); err != nil {
+		logging.Errorw(ctx, "store list orders failed", "err", err)
+		return nil, "", parseError(err)
+	}
+	if len(orders) < count {
+		return orders, "", nil
+	}
+	last := orders[len(orders)-1]
+	return orders, models.NewCursor(last.CreatedAt, last.ID), nil
+}
+
+func (s *orderStore) Make(ctx context.Context, order *models.Order, messages []*models.OutboxMessage) error {
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
+		if err := insertOrder(ctx, tx, order); err != nil {
+			return err
+		}
+		return enqueue(ctx, tx, messages)
+	}); err != nil {
+		logging.Errorw(ctx, "store make order action failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *orderStore) MakeOCO(ctx context.Context, orders []*models.Order, messages []*models.OutboxMessage) error {
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
+		var groupID string
+		if err := tx.Get(&groupID, "SELECT uuid_generate_v4()"); err != nil {
+			logging.Errorw(ctx, "store generate oco group id failed", "err", err)
+			return parseError(err)
+		}
+		for _, order := range orders {
+			order.OCOGroupID = &groupID
+			if err := insertOrder(ctx, tx, order); err != nil {
+				return err
+			}
+		}
+		return enqueue(ctx, tx, messages)
+	}); err != nil {
+		logging.Errorw(ctx, "store make oco orders action failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+// insertOrder creates an order within transaction tx, order.ID is generated if empty and
+// order.Status defaults to open
+func insertOrder(ctx context.Context, tx *sqlx.Tx, order *models.Order) error {
+	var orderID *string
+	if order.ID != "" {
+		orderID = &order.ID
+	}
+	if order.Status == "" {
+		order.Status = models.OrderOpen
+	}
 	query := `
 		INSERT INTO public.order (
+			id,
+			user_id,
 			action,
 			price,
-			quantity
+			quantity,
+			display_quantity,
+			hidden_quantity,
+			status,
+			stop_price,
+			oco_group_id,
+			trail_amount,
+			trail_percent,
+			limit_offset
 		)
 		VALUES (
+			COALESCE(?::uuid, uuid_generate_v4()),
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
 			?,
 			?,
 			?
 		)
+		RETURNING id, created_at
 	`
 	values := []interface{}{
-		action,
-		price,
-		quantity,
+		orderID,
+		order.UserID,
+		order.Action,
+		order.Price,
+		order.Quantity,
+		order.DisplayQuantity,
+		order.HiddenQuantity,
+		order.Status,
+		order.StopPrice,
+		order.OCOGroupID,
+		order.TrailAmount,
+		order.TrailPercent,
+		order.LimitOffset,
 	}
-	query = s.db.Rebind(query)
-	if _, err := s.db.Exec(query, values...); err != nil {
+	query = tx.Rebind(query)
+	if err := tx.QueryRowx(query, values...).Scan(&order.ID, &order.CreatedAt); err != nil {
 		logging.Errorw(ctx, "store make order failed", "err", err)
 		return parseError(err)
 	}
@@ -99,28 +446,44 @@ func (s *orderStore) Make(ctx context.Context, action models.OrderAction, price,
 }
 
 // FIXME: currently only buy action is supported
-func (s *orderStore) Take(ctx context.Context, action models.OrderAction, quantity int) (int, error) {
-	var latestPrice int
+func (s *orderStore) Take(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, outbox OutboxFunc) (models.Decimal, error) {
+	var latestPrice models.Decimal
 
 	db := database.GetPostgres()
 	if err := database.Transaction(db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
 		orders := []*models.Order{}
 		query := `
 			SELECT 
 				id,
+				user_id,
+				action,
 				price,
 				quantity,
-				created_at
+				created_at,
+				display_quantity,
+				hidden_quantity,
+				status,
+				stop_price,
+				oco_group_id,
+				trail_amount,
+				trail_percent,
+				limit_offset,
+				closed_at
 			FROM public.order
 			WHERE 
 		`
 		conditions := []string{
 			"action = ?",
+			"status = ?",
 		}
 		values := []interface{}{
 			models.Sell,
+			models.OrderOpen,
 		}
-		query = query + strings.Join(conditions, " AND ") + " ORDER BY price ASC, created_at DESC" // (latest order, lowest price)
+		query = query + strings.Join(conditions, " AND ") + " ORDER BY price ASC, created_at ASC FOR UPDATE" // (lowest price, earliest order)
 
 		query = tx.Rebind(query)
 		if err := tx.Select(&orders, query, values...); err != nil {
@@ -129,12 +492,60 @@ func (s *orderStore) Take(ctx context.Context, action models.OrderAction, quanti
 		}
 
 		orderIDs := []string{}
-		// FIXME: currently this take orders until quantity is 0, but if selling orders are not enough, it should be handled
+		trades := []*models.Trade{}
+		byID := map[string]*models.Order{}
 		for _, order := range orders {
-			if quantity == 0 {
-				break
+			byID[order.ID] = order
+		}
+		// FIXME: currently this take orders until quantity is 0, but if selling orders are not enough, it should be handled
+		for i := 0; i < len(orders) && quantity > 0; {
+			order := orders[i]
+			if order.Quantity <= 0 {
+				// cancelled by its one-cancels-other group within this take
+				i++
+				continue
+			}
+			filled := min(order.Quantity, quantity)
+			trade, err := fill(ctx, tx, s.instrument, userID, action, order, order.Price, filled)
+			if err != nil {
+				return err
+			}
+			trades = append(trades, trade)
+			latestPrice = order.Price
+			order.Quantity -= filled
+			quantity -= filled
+			if order.OCOGroupID != nil {
+				if err := reduceOCOGroup(ctx, tx, order, filled, byID); err != nil {
+					return err
+				}
+			}
+
+			// a replenished iceberg order takes new time priority, behind the orders resting at its price
+			if order.Replenish(time.Now()) {
+				query := `
+					UPDATE public.order
+					SET
+						quantity=?,
+						hidden_quantity=?,
+						created_at=localtimestamp
+					WHERE
+					id=?
+				`
+				values := []interface{}{
+					order.Quantity,
+					order.HiddenQuantity,
+					order.ID,
+				}
+				query = tx.Rebind(query)
+				if _, err := tx.Exec(query, values...); err != nil {
+					logging.Errorw(ctx, "store replenish iceberg order in take order failed", "err", err, "orderID", order.ID)
+					return parseError(err)
+				}
+				models.Requeue(orders, i)
+				continue
 			}
-			if order.Quantity > quantity {
+
+			if order.Quantity > 0 {
 				query := `
 					UPDATE public.order
 					SET
@@ -143,7 +554,7 @@ func (s *orderStore) Take(ctx context.Context, action models.OrderAction, quanti
 					id=?
 				`
 				values := []interface{}{
-					order.Quantity - quantity,
+					order.Quantity,
 					order.ID,
 				}
 				query = tx.Rebind(query)
@@ -151,50 +562,622 @@ func (s *orderStore) Take(ctx context.Context, action models.OrderAction, quanti
 					logging.Errorw(ctx, "store update order in take order failed", "err", err)
 					return parseError(err)
 				}
-				quantity = 0
-				latestPrice = order.Price
 			} else {
 				orderIDs = append(orderIDs, order.ID)
-				quantity = quantity - order.Quantity
 			}
+			i++
+		}
+
+		if err := closeOrders(ctx, tx, orderIDs, models.OrderFullyFilled); err != nil {
+			return err
 		}
+		if outbox == nil {
+			return nil
+		}
+		messages, err := outbox(trades)
+		if err != nil {
+			logging.Errorw(ctx, "store build outbox messages of take order failed", "err", err)
+			return err
+		}
+		return enqueue(ctx, tx, messages)
+	}); err != nil {
+		logging.Errorw(ctx, "store take order action failed", "err", err)
+		return 0, err
+	}
+	return latestPrice, nil
+}
 
-		if len(orderIDs) > 0 {
+func (s *orderStore) GetIndicativeAuction(ctx context.Context, defaultPrice models.Decimal) (*models.Auction, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.orders.indicative_auction").End()
+	}
+
+	referencePrice := defaultPrice
+	latestPrice, err := s.latestPrice(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	if latestPrice != nil {
+		referencePrice = *latestPrice
+	}
+	buys, sells, err := auctionOrders(ctx, s.db, false)
+	if err != nil {
+		return nil, err
+	}
+	return models.Uncross(buys, sells, referencePrice), nil
+}
+
+// auctionOrders reads the open buy and sell orders taking part in an auction by q, either the database
+// or a transaction, in matching priority, best price then earliest creation first. Orders created before
+// ownership was recorded are left out, as they have no owner to trade for. The orders are locked if
+// forUpdate is set, which is only allowed within a transaction.
+func auctionOrders(ctx context.Context, q sqlx.Ext, forUpdate bool) ([]*models.Order, []*models.Order, error) {
+	sides := map[models.OrderAction][]*models.Order{}
+	for action, orderBy := range map[models.OrderAction]string{
+		models.Buy:  "price DESC, created_at ASC",
+		models.Sell: "price ASC, created_at ASC",
+	} {
+		orders := []*models.Order{}
+		query := `
+			SELECT
+				id,
+				user_id,
+				action,
+				price,
+				quantity,
+				created_at,
+				display_quantity,
+				hidden_quantity,
+				status,
+				stop_price,
+				oco_group_id,
+				trail_amount,
+				trail_percent,
+				limit_offset,
+				closed_at
+			FROM public.order
+			WHERE
+			action = ? AND
+			status = ? AND
+			user_id IS NOT NULL
+			ORDER BY ` + orderBy
+		if forUpdate {
+			query += " FOR UPDATE"
+		}
+		query = q.Rebind(query)
+		if err := sqlx.Select(q, &orders, query, action, models.OrderOpen); err != nil {
+			logging.Errorw(ctx, "store get orders to uncross failed", "err", err, "action", action)
+			return nil, nil, parseError(err)
+		}
+		sides[action] = orders
+	}
+	return sides[models.Buy], sides[models.Sell], nil
+}
+
+func (s *orderStore) Uncross(ctx context.Context, defaultPrice models.Decimal, outbox OutboxFunc) (*models.Auction, error) {
+	var auction *models.Auction
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
+		// read under the book lock, so no other replica trades in between
+		referencePrice := defaultPrice
+		latestPrice, err := s.latestPrice(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if latestPrice != nil {
+			referencePrice = *latestPrice
+		}
+		buys, sells, err := auctionOrders(ctx, tx, true)
+		if err != nil {
+			return err
+		}
+
+		auction = models.Uncross(buys, sells, referencePrice)
+		if auction.Price == nil {
+			return nil
+		}
+		price := *auction.Price
+
+		byID := map[string]*models.Order{}
+		for _, order := range append(buys, sells...) {
+			byID[order.ID] = order
+		}
+		filledIDs := map[string]bool{}
+		replenished := map[string]bool{}
+		trades := []*models.Trade{}
+		volume := auction.Volume
+		for i, j := 0, 0; volume > 0 && i < len(buys) && j < len(sells); {
+			buy, sell := buys[i], sells[j]
+			if buy.Quantity <= 0 {
+				i++
+				continue
+			}
+			if sell.Quantity <= 0 {
+				j++
+				continue
+			}
+			if buy.Price < price || sell.Price > price {
+				break
+			}
+			// the buy order takes the sell order at the clearing price
+			filled := min(buy.Quantity, sell.Quantity, volume)
+			trade, err := fill(ctx, tx, s.instrument, *buy.UserID, models.Buy, sell, price, filled)
+			if err != nil {
+				return err
+			}
+			trades = append(trades, trade)
+			volume -= filled
+			for _, order := range []*models.Order{buy, sell} {
+				order.Quantity -= filled
+				filledIDs[order.ID] = true
+				if order.OCOGroupID != nil {
+					if err := reduceOCOGroup(ctx, tx, order, filled, byID); err != nil {
+						return err
+					}
+				}
+			}
+			// a replenished iceberg order takes new time priority, behind the orders at its price
+			if buy.Replenish(time.Now()) {
+				replenished[buy.ID] = true
+				models.Requeue(buys, i)
+			}
+			if sell.Replenish(time.Now()) {
+				replenished[sell.ID] = true
+				models.Requeue(sells, j)
+			}
+		}
+
+		orderIDs := []string{}
+		for id := range filledIDs {
+			order := byID[id]
+			if order.Quantity <= 0 {
+				orderIDs = append(orderIDs, order.ID)
+				continue
+			}
 			query := `
-				DELETE FROM public.order 
-				WHERE 
-				id = ANY(?)
+				UPDATE public.order
+				SET
+					quantity=?,
+					hidden_quantity=?,
+					created_at=CASE WHEN ?::boolean THEN localtimestamp ELSE created_at END
+				WHERE
+				id=?
 			`
 			values := []interface{}{
-				pq.StringArray(orderIDs),
+				order.Quantity,
+				order.HiddenQuantity,
+				replenished[order.ID],
+				order.ID,
 			}
 			query = tx.Rebind(query)
 			if _, err := tx.Exec(query, values...); err != nil {
-				logging.Errorw(ctx, "store delete taken orders failed", "err", err)
+				logging.Errorw(ctx, "store update order in uncross failed", "err", err, "orderID", order.ID)
 				return parseError(err)
 			}
 		}
+		if err := closeOrders(ctx, tx, orderIDs, models.OrderFullyFilled); err != nil {
+			return err
+		}
+		if outbox == nil {
+			return nil
+		}
+		messages, err := outbox(trades)
+		if err != nil {
+			logging.Errorw(ctx, "store build outbox messages of uncross failed", "err", err)
+			return err
+		}
+		return enqueue(ctx, tx, messages)
+	}); err != nil {
+		logging.Errorw(ctx, "store uncross orders failed", "err", err)
+		return nil, err
+	}
+	return auction, nil
+}
+
+// fill records a trade of symbol of given quantity at given price between the taker and the resting order,
+// and applies it to the positions of both parties and the market statistics
+func fill(ctx context.Context, tx *sqlx.Tx, symbol, takerID string, action models.OrderAction, order *models.Order, price, quantity models.Decimal) (*models.Trade, error) {
+	trade := &models.Trade{
+		MakerOrderID: order.ID,
+		MakerID:      order.UserID,
+		TakerID:      takerID,
+		Action:       action,
+		Price:        price,
+		Quantity:     quantity,
+	}
+	query := `
+		INSERT INTO public.trade (
+			maker_order_id,
+			maker_id,
+			taker_id,
+			action,
+			price,
+			quantity
+		)
+		VALUES (
+			?,
+			?,
+			?,
+			?,
+			?,
+			?
+		)
+		RETURNING id, created_at
+	`
+	values := []interface{}{
+		trade.MakerOrderID,
+		trade.MakerID,
+		trade.TakerID,
+		trade.Action,
+		trade.Price,
+		trade.Quantity,
+	}
+	query = tx.Rebind(query)
+	if err := tx.QueryRowx(query, values...).Scan(&trade.ID, &trade.CreatedAt); err != nil {
+		logging.Errorw(ctx, "store insert trade failed", "err", err, "orderID", order.ID)
+		return nil, parseError(err)
+	}
+
+	if err := addToBucket(ctx, tx, symbol, trade); err != nil {
+		return nil, err
+	}
+	if err := fillPosition(ctx, tx, takerID, action, price, quantity); err != nil {
+		return nil, err
+	}
+	// orders created before ownership was recorded have no position to maintain
+	if order.UserID != nil {
+		if err := fillPosition(ctx, tx, *order.UserID, order.Action, price, quantity); err != nil {
+			return nil, err
+		}
+	}
+	return trade, nil
+}
+
+// reduceOCOGroup reduces the other orders of the one-cancels-other group of a filled order by the filled
+// quantity within transaction tx, cancelling those with nothing left. The orders being taken are kept in sync
+// through byID, those already filled within the transaction are left alone.
+func reduceOCOGroup(ctx context.Context, tx *sqlx.Tx, order *models.Order, filled models.Decimal, byID map[string]*models.Order) error {
+	exhausted := []string{order.ID}
+	for id, o := range byID {
+		if o.Quantity <= 0 {
+			exhausted = append(exhausted, id)
+		}
+	}
+	linked := []*models.Order{}
+	query := `
+		UPDATE public.order
+		SET
+			quantity = GREATEST(quantity - ?, 0)
+		WHERE
+		oco_group_id = ? AND
+		closed_at IS NULL AND
+		id <> ALL(?)
+		RETURNING id, quantity
+	`
+	values := []interface{}{
+		filled,
+		order.OCOGroupID,
+		pq.StringArray(exhausted),
+	}
+	query = tx.Rebind(query)
+	if err := tx.Select(&linked, query, values...); err != nil {
+		logging.Errorw(ctx, "store reduce oco group failed", "err", err, "orderID", order.ID)
+		return parseError(err)
+	}
+
+	cancelled := []string{}
+	for _, l := range linked {
+		if o, ok := byID[l.ID]; ok {
+			o.Quantity = l.Quantity
+		}
+		if l.Quantity <= 0 {
+			cancelled = append(cancelled, l.ID)
+		}
+	}
+	return closeOrders(ctx, tx, cancelled, models.OrderCancelled)
+}
+
+// closeOrders takes the orders of given IDs off the board within transaction tx, keeping them as
+// filled or cancelled. Filled orders have nothing left, cancelled ones keep what was left unfilled.
+// Orders closed already, e.g. cancelled by their group earlier in tx, are left as they are.
+func closeOrders(ctx context.Context, tx *sqlx.Tx, orderIDs []string, status models.OrderStatus) error {
+	if len(orderIDs) == 0 {
+		return nil
+	}
+	query := `
+		UPDATE public.order
+		SET
+			status = ?,
+			quantity = CASE WHEN ?::boolean THEN 0 ELSE quantity END,
+			closed_at = now()
+		WHERE
+		id = ANY(?) AND
+		closed_at IS NULL
+	`
+	values := []interface{}{
+		status,
+		status == models.OrderFullyFilled,
+		pq.StringArray(orderIDs),
+	}
+	query = tx.Rebind(query)
+	if _, err := tx.Exec(query, values...); err != nil {
+		logging.Errorw(ctx, "store close orders failed", "err", err, "status", status)
+		return parseError(err)
+	}
+	return nil
+}
+
+func (s *orderStore) TrailStops(ctx context.Context, latestPrice, tickSize models.Decimal) ([]*models.Order, error) {
+	trailed := []*models.Order{}
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
+		orders := []*models.Order{}
+		query := `
+			SELECT
+				id,
+				user_id,
+				action,
+				price,
+				quantity,
+				created_at,
+				display_quantity,
+				hidden_quantity,
+				status,
+				stop_price,
+				oco_group_id,
+				trail_amount,
+				trail_percent,
+				limit_offset,
+				closed_at
+			FROM public.order
+			WHERE
+			status = ? AND
+			(trail_amount IS NOT NULL OR trail_percent IS NOT NULL)
+			FOR UPDATE
+		`
+		query = tx.Rebind(query)
+		if err := tx.Select(&orders, query, models.OrderPending); err != nil {
+			logging.Errorw(ctx, "store get trailing stop orders failed", "err", err)
+			return parseError(err)
+		}
+
+		for _, order := range orders {
+			moved, err := order.Trail(latestPrice, tickSize)
+			if err != nil {
+				logging.Errorw(ctx, "store trail stop order failed", "err", err, "orderID", order.ID)
+				return err
+			}
+			if !moved {
+				continue
+			}
+			query := `
+				UPDATE public.order
+				SET
+					stop_price=?,
+					price=?
+				WHERE
+				id=?
+			`
+			values := []interface{}{
+				order.StopPrice,
+				order.Price,
+				order.ID,
+			}
+			query = tx.Rebind(query)
+			if _, err := tx.Exec(query, values...); err != nil {
+				logging.Errorw(ctx, "store update trailing stop order failed", "err", err, "orderID", order.ID)
+				return parseError(err)
+			}
+			trailed = append(trailed, order)
+		}
 		return nil
 	}); err != nil {
-		logging.Errorw(ctx, "store take order action failed", "err", err)
-		return 0, err
+		logging.Errorw(ctx, "store trail stop orders action failed", "err", err)
+		return nil, err
 	}
-	return latestPrice, nil
+	return trailed, nil
 }
 
-func (s *orderStore) Delete(ctx context.Context, orderID string) error {
+func (s *orderStore) TriggerStops(ctx context.Context, latestPrice models.Decimal) ([]*models.Order, error) {
+	orders := []*models.Order{}
 	query := `
-		DELETE FROM public.order 
-		WHERE 
-		id = ?
+		UPDATE public.order
+		SET
+			status = ?,
+			created_at = localtimestamp
+		WHERE
+		status = ? AND
+		((action = ? AND stop_price >= ?) OR (action = ? AND stop_price <= ?))
+		RETURNING
+			id,
+			user_id,
+			action,
+			price,
+			quantity,
+			created_at,
+			display_quantity,
+			hidden_quantity,
+			status,
+			stop_price,
+			oco_group_id,
+			trail_amount,
+			trail_percent,
+			limit_offset,
+			closed_at
+	`
+	values := []interface{}{
+		models.OrderOpen,
+		models.OrderPending,
+		models.Sell,
+		latestPrice,
+		models.Buy,
+		latestPrice,
+	}
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
+		query = tx.Rebind(query)
+		if err := tx.Select(&orders, query, values...); err != nil {
+			logging.Errorw(ctx, "store trigger stop orders failed", "err", err, "latestPrice", latestPrice.String())
+			return parseError(err)
+		}
+		return nil
+	}); err != nil {
+		logging.Errorw(ctx, "store trigger stop orders action failed", "err", err)
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (s *orderStore) Delete(ctx context.Context, userID *string, orderID string, audit *models.AuditEntry) error {
+	query := `
+		UPDATE public.order
+		SET
+			status = ?,
+			closed_at = now()
+		WHERE
+		closed_at IS NULL AND
+		(
+			id = ? OR
+			oco_group_id = (SELECT oco_group_id FROM public.order WHERE id = ? AND closed_at IS NULL)
+		)
 	`
 	values := []interface{}{
+		models.OrderCancelled,
+		orderID,
 		orderID,
 	}
-	query = s.db.Rebind(query)
-	if _, err := s.db.Exec(query, values...); err != nil {
-		logging.Errorw(ctx, "store delete order failed", "err", err, "orderID", orderID)
-		return parseError(err)
+	if userID != nil {
+		query = cancelOrderQuery
+		values = []interface{}{
+			models.OrderCancelled,
+			*userID,
+			orderID,
+			orderID,
+			*userID,
+		}
+	}
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
+		query = tx.Rebind(query)
+		result, err := tx.Exec(query, values...)
+		if err != nil {
+			logging.Errorw(ctx, "store delete order failed", "err", err, "orderID", orderID)
+			return parseError(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			logging.Errorw(ctx, "store count deleted orders failed", "err", err, "orderID", orderID)
+			return err
+		}
+		if affected == 0 {
+			return models.ErrorNotFound
+		}
+		return recordAudit(ctx, tx, audit)
+	}); err != nil {
+		logging.Errorw(ctx, "store delete order action failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+// cancelOrderQuery cancels an open or pending order of a user, the group of the order is cancelled
+// along with it, groups never span users
+const cancelOrderQuery = `
+	UPDATE public.order
+	SET
+		status = ?,
+		closed_at = now()
+	WHERE
+	user_id = ? AND
+	closed_at IS NULL AND
+	(
+		id = ? OR
+		oco_group_id = (SELECT oco_group_id FROM public.order WHERE id = ? AND user_id = ? AND closed_at IS NULL)
+	)
+`
+
+func (s *orderStore) Batch(ctx context.Context, userID string, items []*models.BatchItem) error {
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
+		query := tx.Rebind(cancelOrderQuery)
+		for i, item := range items {
+			if item.Order != nil {
+				if err := insertOrder(ctx, tx, item.Order); err != nil {
+					return &models.BatchError{Index: i, Err: err}
+				}
+				if err := enqueue(ctx, tx, item.Messages); err != nil {
+					return &models.BatchError{Index: i, Err: err}
+				}
+				continue
+			}
+			result, err := tx.Exec(query, models.OrderCancelled, userID, item.OrderID, item.OrderID, userID)
+			if err != nil {
+				logging.Errorw(ctx, "store cancel order of batch failed", "err", err, "orderID", item.OrderID)
+				return &models.BatchError{Index: i, Err: parseError(err)}
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				logging.Errorw(ctx, "store count cancelled orders of batch failed", "err", err, "orderID", item.OrderID)
+				return err
+			}
+			if affected == 0 {
+				return &models.BatchError{Index: i, Err: models.ErrorNotFound}
+			}
+		}
+		return nil
+	}); err != nil {
+		logging.Errorw(ctx, "store batch orders action failed", "err", err)
+		return err
 	}
 	return nil
 }
+
+func (s *orderStore) DeleteByUser(ctx context.Context, userID string, audit *models.AuditEntry) (int, error) {
+	deleted := 0
+	// orders of a one-cancels-other group always belong to the same user
+	query := `
+		UPDATE public.order
+		SET
+			status = ?,
+			closed_at = now()
+		WHERE
+		user_id = ? AND
+		closed_at IS NULL
+	`
+	values := []interface{}{
+		models.OrderCancelled,
+		userID,
+	}
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		if err := s.lockBook(ctx, tx); err != nil {
+			return err
+		}
+		query = tx.Rebind(query)
+		result, err := tx.Exec(query, values...)
+		if err != nil {
+			logging.Errorw(ctx, "store delete orders of user failed", "err", err, "userID", userID)
+			return parseError(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			logging.Errorw(ctx, "store count deleted orders failed", "err", err, "userID", userID)
+			return err
+		}
+		deleted = int(affected)
+		return recordAudit(ctx, tx, audit)
+	}); err != nil {
+		logging.Errorw(ctx, "store delete orders of user action failed", "err", err)
+		return 0, err
+	}
+	return deleted, nil
+}
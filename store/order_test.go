@@ -58,23 +58,42 @@ func TestOrderDBIntegration(t *testing.T) {
 
 	db := database.GetPostgres()
 	orderStore := NewOrder(db)
+	positionStore := NewPosition(db)
+	makerID := "4b6e7a0e-6a8e-4f47-9f5e-3d2b8c1e0a11"
+	takerID := "9c1d2e3f-4a5b-4c6d-8e7f-0a1b2c3d4e5f"
 
-	sellPrice := 50
-	err := orderStore.Make(ctx, models.Sell, sellPrice, 10)
+	sellPrice := models.NewDecimal(50)
+	err := orderStore.Make(ctx, &models.Order{
+		UserID:   &makerID,
+		Action:   models.Sell,
+		Price:    sellPrice,
+		Quantity: models.NewDecimal(10),
+	}, nil)
 	if err != nil {
 		t.Fatalf("make sell order failed: %s", err.Error())
 	}
 
-	err = orderStore.Make(ctx, models.Buy, 5, 20)
+	err = orderStore.Make(ctx, &models.Order{
+		UserID:   &makerID,
+		Action:   models.Buy,
+		Price:    models.NewDecimal(5),
+		Quantity: models.NewDecimal(20),
+	}, nil)
 	if err != nil {
 		t.Fatalf("make buy order failed: %s", err.Error())
 	}
 
-	newPrice, err := orderStore.Take(ctx, models.Buy, 2)
+	trades := []*models.Trade{}
+	newPrice, err := orderStore.Take(ctx, takerID, models.Buy, models.NewDecimal(2), func(t []*models.Trade) ([]*models.OutboxMessage, error) {
+		trades = t
+		return nil, nil
+	})
 	if err != nil {
 		t.Fatalf("take buy order failed: %s", err.Error())
 	}
-	require.Equal(t, sellPrice, newPrice, fmt.Sprintf("expect new price to be %d, the default price", sellPrice))
+	require.Equal(t, sellPrice, newPrice, fmt.Sprintf("expect new price to be %s, the default price", sellPrice))
+	require.Equal(t, 1, len(trades), "expect take order to fill 1 sell order")
+	require.Equal(t, models.NewDecimal(2), trades[0].Quantity)
 
 	buyOrders, err := orderStore.GetLiveOrders(ctx, models.Buy)
 	if err != nil {
@@ -87,5 +106,232 @@ func TestOrderDBIntegration(t *testing.T) {
 		t.Fatalf("get live sell orders failed: %s", err.Error())
 	}
 	require.Equal(t, true, len(sellOrders) > 0, "expect at least 1 sell order")
-	require.Equal(t, 8, sellOrders[0].Quantity, "expect sell order quantity to be 8")
+	require.Equal(t, models.NewDecimal(8), sellOrders[0].Quantity, "expect sell order quantity to be 8")
+
+	position, err := positionStore.Get(ctx, takerID)
+	if err != nil {
+		t.Fatalf("get taker position failed: %s", err.Error())
+	}
+	require.Equal(t, true, position.Quantity >= models.NewDecimal(2), "expect taker to hold at least 2")
+
+	// clearBook cancels every open and pending order, so each case below only matches its own orders
+	clearBook := func(t *testing.T) {
+		for _, status := range []models.OrderStatus{models.OrderOpen, models.OrderPending} {
+			for {
+				orders, _, err := orderStore.List(ctx, &models.OrderFilter{Status: &status}, "", 100)
+				require.NoError(t, err)
+				if len(orders) == 0 {
+					break
+				}
+				for _, order := range orders {
+					// orders of a group are cancelled along with the first one of them
+					if err := orderStore.Delete(ctx, nil, order.ID, nil); err != nil {
+						require.ErrorIs(t, err, models.ErrorNotFound)
+					}
+				}
+			}
+		}
+	}
+	collect := func(trades *[]*models.Trade) OutboxFunc {
+		return func(t []*models.Trade) ([]*models.OutboxMessage, error) {
+			*trades = t
+			return nil, nil
+		}
+	}
+
+	t.Run("iceberg", func(t *testing.T) {
+		clearBook(t)
+		display := models.NewDecimal(2)
+		iceberg := &models.Order{
+			UserID:          &makerID,
+			Action:          models.Sell,
+			Price:           sellPrice,
+			Quantity:        display,
+			DisplayQuantity: &display,
+			HiddenQuantity:  models.NewDecimal(3),
+		}
+		require.NoError(t, orderStore.Make(ctx, iceberg, nil))
+		plain := &models.Order{UserID: &makerID, Action: models.Sell, Price: sellPrice, Quantity: models.NewDecimal(1)}
+		require.NoError(t, orderStore.Make(ctx, plain, nil))
+
+		// fills the visible slice, the replenished one queues behind the plain order at the same price
+		trades := []*models.Trade{}
+		_, err := orderStore.Take(ctx, takerID, models.Buy, models.NewDecimal(2), collect(&trades))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(trades))
+		require.Equal(t, iceberg.ID, trades[0].MakerOrderID)
+		order, err := orderStore.Get(ctx, iceberg.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.NewDecimal(2), order.Quantity, "expect the iceberg order to be replenished")
+		require.Equal(t, models.NewDecimal(1), order.HiddenQuantity)
+
+		_, err = orderStore.Take(ctx, takerID, models.Buy, models.NewDecimal(2), collect(&trades))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(trades))
+		require.Equal(t, plain.ID, trades[0].MakerOrderID, "expect the replenished slice to lose time priority")
+		require.Equal(t, iceberg.ID, trades[1].MakerOrderID)
+
+		// the last visible unit and the hidden remainder are filled within one take
+		_, err = orderStore.Take(ctx, takerID, models.Buy, models.NewDecimal(2), collect(&trades))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(trades))
+		order, err = orderStore.Get(ctx, iceberg.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.OrderFullyFilled, order.Status, "expect the filled iceberg order to be kept")
+		require.Equal(t, models.NewDecimal(0), order.Quantity+order.HiddenQuantity)
+		require.NotNil(t, order.ClosedAt)
+		order, err = orderStore.Get(ctx, plain.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.OrderFullyFilled, order.Status)
+	})
+
+	t.Run("oco", func(t *testing.T) {
+		clearBook(t)
+		stopPrice := models.NewDecimal(45)
+		takeProfit := &models.Order{UserID: &makerID, Action: models.Sell, Price: models.NewDecimal(60), Quantity: models.NewDecimal(5)}
+		stopLoss := &models.Order{
+			UserID:    &makerID,
+			Action:    models.Sell,
+			Price:     models.NewDecimal(40),
+			Quantity:  models.NewDecimal(5),
+			Status:    models.OrderPending,
+			StopPrice: &stopPrice,
+		}
+		require.NoError(t, orderStore.MakeOCO(ctx, []*models.Order{takeProfit, stopLoss}, nil))
+
+		// a partial fill reduces the other order of the group by the filled quantity
+		_, err := orderStore.Take(ctx, takerID, models.Buy, models.NewDecimal(2), nil)
+		require.NoError(t, err)
+		group, err := orderStore.GetOCOGroup(ctx, *takeProfit.OCOGroupID)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(group))
+		require.Equal(t, takeProfit.ID, group[0].ID, "expect limit order first")
+		require.Equal(t, models.NewDecimal(3), group[0].Quantity)
+		require.Equal(t, models.NewDecimal(3), group[1].Quantity, "expect stop order to be reduced by the fill")
+		require.Equal(t, models.OrderPending, group[1].Status)
+
+		// filling the rest cancels the stop order, both are kept off the board
+		_, err = orderStore.Take(ctx, takerID, models.Buy, models.NewDecimal(3), nil)
+		require.NoError(t, err)
+		group, err = orderStore.GetOCOGroup(ctx, *takeProfit.OCOGroupID)
+		require.NoError(t, err)
+		require.Equal(t, models.OrderFullyFilled, group[0].Status)
+		require.Equal(t, models.OrderCancelled, group[1].Status)
+		require.Equal(t, models.NewDecimal(0), group[1].Quantity)
+		require.NotNil(t, group[1].ClosedAt)
+		require.ErrorIs(t, orderStore.Delete(ctx, &makerID, stopLoss.ID, nil), models.ErrorNotFound, "expect closed orders not to be cancelled")
+
+		// cancelling an order cancels its group
+		takeProfit = &models.Order{UserID: &makerID, Action: models.Sell, Price: models.NewDecimal(60), Quantity: models.NewDecimal(5)}
+		stopLoss = &models.Order{
+			UserID:    &makerID,
+			Action:    models.Sell,
+			Price:     models.NewDecimal(40),
+			Quantity:  models.NewDecimal(5),
+			Status:    models.OrderPending,
+			StopPrice: &stopPrice,
+		}
+		require.NoError(t, orderStore.MakeOCO(ctx, []*models.Order{takeProfit, stopLoss}, nil))
+		require.ErrorIs(t, orderStore.Delete(ctx, &takerID, takeProfit.ID, nil), models.ErrorNotFound, "expect orders of another user not to be found")
+		require.NoError(t, orderStore.Delete(ctx, &makerID, takeProfit.ID, nil))
+		group, err = orderStore.GetOCOGroup(ctx, *takeProfit.OCOGroupID)
+		require.NoError(t, err)
+		for _, order := range group {
+			require.Equal(t, models.OrderCancelled, order.Status)
+		}
+		require.Equal(t, models.NewDecimal(5), group[1].Quantity, "expect cancelled orders to keep what was left")
+	})
+
+	t.Run("stops", func(t *testing.T) {
+		clearBook(t)
+		tickSize := models.NewDecimal(1)
+		stopPrice, trailAmount := models.NewDecimal(45), models.NewDecimal(5)
+		trailing := &models.Order{
+			UserID:      &makerID,
+			Action:      models.Sell,
+			Price:       models.NewDecimal(44),
+			Quantity:    models.NewDecimal(1),
+			Status:      models.OrderPending,
+			StopPrice:   &stopPrice,
+			TrailAmount: &trailAmount,
+			LimitOffset: models.NewDecimal(1),
+		}
+		require.NoError(t, orderStore.Make(ctx, trailing, nil))
+
+		// sell stops follow rising prices only
+		trailed, err := orderStore.TrailStops(ctx, models.NewDecimal(60), tickSize)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(trailed))
+		require.Equal(t, models.NewDecimal(55), *trailed[0].StopPrice)
+		require.Equal(t, models.NewDecimal(54), trailed[0].Price)
+		trailed, err = orderStore.TrailStops(ctx, models.NewDecimal(58), tickSize)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(trailed), "expect sell stops not to follow falling prices")
+		order, err := orderStore.Get(ctx, trailing.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.NewDecimal(55), *order.StopPrice)
+
+		triggered, err := orderStore.TriggerStops(ctx, models.NewDecimal(56))
+		require.NoError(t, err)
+		require.Equal(t, 0, len(triggered), "expect sell stops not to be triggered above their stop price")
+		triggered, err = orderStore.TriggerStops(ctx, models.NewDecimal(55))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(triggered))
+		require.Equal(t, trailing.ID, triggered[0].ID)
+		require.Equal(t, models.OrderOpen, triggered[0].Status)
+
+		sells, err := orderStore.GetLiveOrders(ctx, models.Sell)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(sells), "expect the triggered order to be on the board")
+		require.Equal(t, models.NewDecimal(54), sells[0].Price)
+		trailed, err = orderStore.TrailStops(ctx, models.NewDecimal(70), tickSize)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(trailed), "expect triggered orders not to trail any more")
+	})
+
+	t.Run("uncross", func(t *testing.T) {
+		clearBook(t)
+		display := models.NewDecimal(1)
+		iceberg := &models.Order{
+			UserID:          &makerID,
+			Action:          models.Sell,
+			Price:           models.NewDecimal(50),
+			Quantity:        display,
+			DisplayQuantity: &display,
+			HiddenQuantity:  models.NewDecimal(2),
+		}
+		require.NoError(t, orderStore.Make(ctx, iceberg, nil))
+		above := &models.Order{UserID: &makerID, Action: models.Sell, Price: models.NewDecimal(51), Quantity: models.NewDecimal(1)}
+		require.NoError(t, orderStore.Make(ctx, above, nil))
+		buy := &models.Order{UserID: &takerID, Action: models.Buy, Price: models.NewDecimal(52), Quantity: models.NewDecimal(3)}
+		require.NoError(t, orderStore.Make(ctx, buy, nil))
+
+		// the hidden quantity of the iceberg order counts towards the volume, the indicative auction
+		// is where the board is uncrossed
+		indicative, err := orderStore.GetIndicativeAuction(ctx, sellPrice)
+		require.NoError(t, err)
+		require.NotNil(t, indicative.Price)
+		require.Equal(t, models.NewDecimal(3), indicative.Volume)
+		trades := []*models.Trade{}
+		auction, err := orderStore.Uncross(ctx, sellPrice, collect(&trades))
+		require.NoError(t, err)
+		require.Equal(t, *indicative.Price, *auction.Price)
+		require.Equal(t, indicative.Volume, auction.Volume)
+
+		volume := models.NewDecimal(0)
+		for _, trade := range trades {
+			require.Equal(t, iceberg.ID, trade.MakerOrderID, "expect the iceberg order to be replenished while matched")
+			require.Equal(t, *auction.Price, trade.Price)
+			volume += trade.Quantity
+		}
+		require.Equal(t, auction.Volume, volume)
+		for _, id := range []string{iceberg.ID, buy.ID} {
+			order, err := orderStore.Get(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, models.OrderFullyFilled, order.Status)
+		}
+		order, err := orderStore.Get(ctx, above.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.OrderOpen, order.Status, "expect orders beyond the volume to stay on the board")
+	})
 }
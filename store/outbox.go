@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+
+	"github.com/A-pen-app/kickstart/database"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/jmoiron/sqlx"
+)
+
+// outboxRelayLockKey identifies the advisory lock held by the outbox relay,
+// so only one replica relays messages at a time and per aggregate ordering holds.
+const outboxRelayLockKey = 7286033001
+
+type outboxStore struct {
+	db *sqlx.DB
+}
+
+// NewOutbox returns an implementation of store.Outbox
+func NewOutbox(db *sqlx.DB) Outbox {
+	return &outboxStore{
+		db: db,
+	}
+}
+
+func (s *outboxStore) Relay(ctx context.Context, limit int, publish func(*models.OutboxMessage) error) (int, error) {
+	published := 0
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		locked := false
+		if err := tx.Get(&locked, "SELECT pg_try_advisory_xact_lock($1)", outboxRelayLockKey); err != nil {
+			logging.Errorw(ctx, "store acquire outbox relay lock failed", "err", err)
+			return parseError(err)
+		}
+		if !locked {
+			// another replica is relaying
+			return nil
+		}
+
+		// skip aggregates whose earlier message is waiting for retry
+		messages := []*models.OutboxMessage{}
+		query := `
+			SELECT
+				o.id,
+				o.aggregate_id,
+				o.topic,
+				o.payload,
+				o.attempts,
+				o.created_at
+			FROM public.outbox o
+			WHERE
+				o.published_at IS NULL AND
+				o.available_at <= now() AND
+				NOT EXISTS (
+					SELECT 1 FROM public.outbox p
+					WHERE
+						p.aggregate_id = o.aggregate_id AND
+						p.published_at IS NULL AND
+						p.id < o.id AND
+						p.available_at > now()
+				)
+			ORDER BY o.id ASC
+			LIMIT ?
+		`
+		query = tx.Rebind(query)
+		if err := tx.Select(&messages, query, limit); err != nil {
+			logging.Errorw(ctx, "store get pending outbox messages failed", "err", err)
+			return parseError(err)
+		}
+
+		failed := map[string]bool{}
+		for _, m := range messages {
+			// keep the order within aggregate, later messages wait for the failed one
+			if failed[m.AggregateID] {
+				continue
+			}
+			if err := publish(m); err != nil {
+				failed[m.AggregateID] = true
+				logging.Errorw(ctx, "publish outbox message failed", "err", err, "id", m.ID, "topic", m.Topic, "attempts", m.Attempts)
+				query := `
+					UPDATE public.outbox
+					SET
+						attempts = attempts + 1,
+						last_error = ?,
+						available_at = now() + LEAST(power(2, attempts), 300) * interval '1 second'
+					WHERE
+					id = ?
+				`
+				query = tx.Rebind(query)
+				if _, err := tx.Exec(query, err.Error(), m.ID); err != nil {
+					logging.Errorw(ctx, "store mark outbox message failed failed", "err", err, "id", m.ID)
+					return parseError(err)
+				}
+				continue
+			}
+
+			query := `
+				UPDATE public.outbox
+				SET
+					attempts = attempts + 1,
+					published_at = now()
+				WHERE
+				id = ?
+			`
+			query = tx.Rebind(query)
+			if _, err := tx.Exec(query, m.ID); err != nil {
+				logging.Errorw(ctx, "store mark outbox message published failed", "err", err, "id", m.ID)
+				return parseError(err)
+			}
+			published++
+		}
+		return nil
+	}); err != nil {
+		logging.Errorw(ctx, "store relay outbox messages failed", "err", err)
+		return 0, err
+	}
+	return published, nil
+}
+
+// enqueue writes messages to the outbox within transaction tx
+func enqueue(ctx context.Context, tx *sqlx.Tx, messages []*models.OutboxMessage) error {
+	for _, m := range messages {
+		query := `
+			INSERT INTO public.outbox (
+				aggregate_id,
+				topic,
+				payload
+			)
+			VALUES (
+				?,
+				?,
+				?
+			)
+		`
+		values := []interface{}{
+			m.AggregateID,
+			m.Topic,
+			[]byte(m.Payload),
+		}
+		query = tx.Rebind(query)
+		if _, err := tx.Exec(query, values...); err != nil {
+			logging.Errorw(ctx, "store enqueue outbox message failed", "err", err, "topic", m.Topic)
+			return parseError(err)
+		}
+	}
+	return nil
+}
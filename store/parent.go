@@ -0,0 +1,250 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/database"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/A-pen-app/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// parentDispatchLockKey identifies the advisory lock held while dispatching parent orders,
+// so only one replica sends their child orders at a time.
+const parentDispatchLockKey = 7286033002
+
+type parentStore struct {
+	db *sqlx.DB
+}
+
+// NewParent returns an implementation of store.Parent
+func NewParent(db *sqlx.DB) Parent {
+	return &parentStore{
+		db: db,
+	}
+}
+
+func (s *parentStore) Create(ctx context.Context, parent *models.ParentOrder) error {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.create.parent_order").End()
+	}
+
+	if parent.Status == "" {
+		parent.Status = models.ParentActive
+	}
+	query := `
+		INSERT INTO public.parent_order (
+			user_id,
+			action,
+			quantity,
+			limit_price,
+			slices,
+			start_at,
+			end_at,
+			status,
+			next_slice_at
+		)
+		VALUES (
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?
+		)
+		RETURNING id, created_at, updated_at
+	`
+	values := []interface{}{
+		parent.UserID,
+		parent.Action,
+		parent.Quantity,
+		parent.LimitPrice,
+		parent.Slices,
+		parent.StartAt,
+		parent.EndAt,
+		parent.Status,
+		parent.NextSliceAt,
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.QueryRowx(query, values...).Scan(&parent.ID, &parent.CreatedAt, &parent.UpdatedAt); err != nil {
+		logging.Errorw(ctx, "store create parent order failed", "err", err)
+		return parseError(err)
+	}
+	return nil
+}
+
+func (s *parentStore) Get(ctx context.Context, parentID string) (*models.ParentOrder, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.parent_order").End()
+	}
+
+	parent := models.ParentOrder{}
+	query := `
+		SELECT
+			id,
+			user_id,
+			action,
+			quantity,
+			limit_price,
+			slices,
+			start_at,
+			end_at,
+			status,
+			slices_sent,
+			sent_quantity,
+			next_slice_at,
+			last_error,
+			created_at,
+			updated_at
+		FROM public.parent_order
+		WHERE
+		id = ?
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&parent, query, parentID); err != nil {
+		logging.Errorw(ctx, "store get parent order failed", "err", err, "parentID", parentID)
+		return nil, parseError(err)
+	}
+	return &parent, nil
+}
+
+func (s *parentStore) UpdateStatus(ctx context.Context, parentID string, from []models.ParentOrderStatus, to models.ParentOrderStatus) (*models.ParentOrder, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.update.parent_order.status").End()
+	}
+
+	statuses := pq.StringArray{}
+	for _, status := range from {
+		statuses = append(statuses, string(status))
+	}
+	parent := models.ParentOrder{}
+	query := `
+		UPDATE public.parent_order
+		SET
+			status = ?,
+			next_slice_at = CASE WHEN ?::boolean THEN NULL ELSE next_slice_at END,
+			updated_at = now()
+		WHERE
+		id = ? AND
+		status = ANY(?)
+		RETURNING
+			id,
+			user_id,
+			action,
+			quantity,
+			limit_price,
+			slices,
+			start_at,
+			end_at,
+			status,
+			slices_sent,
+			sent_quantity,
+			next_slice_at,
+			last_error,
+			created_at,
+			updated_at
+	`
+	values := []interface{}{
+		to,
+		to == models.ParentCancelled,
+		parentID,
+		statuses,
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&parent, query, values...); err != nil {
+		logging.Errorw(ctx, "store update parent order status failed", "err", err, "parentID", parentID, "status", to)
+		return nil, parseError(err)
+	}
+	return &parent, nil
+}
+
+func (s *parentStore) Dispatch(ctx context.Context, now time.Time, limit int, send func(*models.ParentOrder) error) (int, error) {
+	dispatched := 0
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		locked := false
+		if err := tx.Get(&locked, "SELECT pg_try_advisory_xact_lock($1)", parentDispatchLockKey); err != nil {
+			logging.Errorw(ctx, "store acquire parent dispatch lock failed", "err", err)
+			return parseError(err)
+		}
+		if !locked {
+			// another replica is dispatching
+			return nil
+		}
+
+		parents := []*models.ParentOrder{}
+		query := `
+			SELECT
+				id,
+				user_id,
+				action,
+				quantity,
+				limit_price,
+				slices,
+				start_at,
+				end_at,
+				status,
+				slices_sent,
+				sent_quantity,
+				next_slice_at,
+				last_error,
+				created_at,
+				updated_at
+			FROM public.parent_order
+			WHERE
+			status = ? AND
+			next_slice_at <= ?
+			ORDER BY next_slice_at ASC
+			LIMIT ?
+			FOR UPDATE
+		`
+		query = tx.Rebind(query)
+		if err := tx.Select(&parents, query, models.ParentActive, now, limit); err != nil {
+			logging.Errorw(ctx, "store get due parent orders failed", "err", err)
+			return parseError(err)
+		}
+
+		for _, p := range parents {
+			if err := send(p); err != nil {
+				return err
+			}
+			query := `
+				UPDATE public.parent_order
+				SET
+					status = ?,
+					slices_sent = ?,
+					sent_quantity = ?,
+					next_slice_at = ?,
+					last_error = ?,
+					updated_at = now()
+				WHERE
+				id = ?
+			`
+			values := []interface{}{
+				p.Status,
+				p.SlicesSent,
+				p.SentQuantity,
+				p.NextSliceAt,
+				p.LastError,
+				p.ID,
+			}
+			query = tx.Rebind(query)
+			if _, err := tx.Exec(query, values...); err != nil {
+				logging.Errorw(ctx, "store update parent order progress failed", "err", err, "parentID", p.ID)
+				return parseError(err)
+			}
+			dispatched++
+		}
+		return nil
+	}); err != nil {
+		logging.Errorw(ctx, "store dispatch parent orders failed", "err", err)
+		return 0, err
+	}
+	return dispatched, nil
+}
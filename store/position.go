@@ -0,0 +1,215 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/A-pen-app/tracing"
+	"github.com/jmoiron/sqlx"
+)
+
+type positionStore struct {
+	db *sqlx.DB
+}
+
+// NewPosition returns an implementation of store.Position
+func NewPosition(db *sqlx.DB) Position {
+	return &positionStore{
+		db: db,
+	}
+}
+
+func (s *positionStore) Get(ctx context.Context, userID string) (*models.Position, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.position").End()
+	}
+
+	position := models.Position{}
+	query := `
+		SELECT
+			user_id,
+			quantity,
+			cost,
+			realized_pnl,
+			updated_at
+		FROM public.position
+		WHERE
+		user_id = ?
+	`
+	values := []interface{}{
+		userID,
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&position, query, values...); err != nil {
+		if err == sql.ErrNoRows {
+			// a user without any trade holds an empty position
+			return &models.Position{UserID: userID}, nil
+		}
+		logging.Errorw(ctx, "store get position failed", "err", err, "userID", userID)
+		return nil, parseError(err)
+	}
+	return &position, nil
+}
+
+func (s *positionStore) GetTrades(ctx context.Context, userID string, limit int) ([]*models.Trade, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.trades").End()
+	}
+
+	trades := []*models.Trade{}
+	query := `
+		SELECT
+			t.id,
+			t.maker_order_id,
+			t.maker_id,
+			t.taker_id,
+			t.action,
+			t.price,
+			t.quantity,
+			t.created_at,
+			t.off_book
+		FROM public.trade t
+		WHERE
+		t.taker_id = ? OR
+		t.maker_id = ?
+		ORDER BY t.created_at DESC
+		LIMIT ?
+	`
+	values := []interface{}{
+		userID,
+		userID,
+		limit,
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.Select(&trades, query, values...); err != nil {
+		logging.Errorw(ctx, "store get trades failed", "err", err, "userID", userID)
+		return nil, parseError(err)
+	}
+	return trades, nil
+}
+
+func (s *positionStore) ListTrades(ctx context.Context, filter *models.TradeFilter, next string, count int) ([]*models.Trade, string, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.list.trades").End()
+	}
+
+	trades := []*models.Trade{}
+	query := `
+		SELECT
+			t.id,
+			t.maker_order_id,
+			t.maker_id,
+			t.taker_id,
+			t.action,
+			t.price,
+			t.quantity,
+			t.created_at,
+			t.off_book
+		FROM public.trade t
+		WHERE
+	`
+	conditions := []string{
+		"(t.taker_id = ? OR t.maker_id = ?)",
+	}
+	values := []interface{}{
+		filter.UserID,
+		filter.UserID,
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "t.created_at >= ?")
+		values = append(values, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "t.created_at < ?")
+		values = append(values, *filter.To)
+	}
+	if next != "" {
+		cursor, err := models.ParseCursor(next)
+		if err != nil {
+			return nil, "", err
+		}
+		conditions = append(conditions, "(t.created_at, t.id) < (?, ?)")
+		values = append(values, cursor.CreatedAt, cursor.ID)
+	}
+	query = query + strings.Join(conditions, " AND ") + " ORDER BY t.created_at DESC, t.id DESC LIMIT ?"
+	values = append(values, count)
+
+	query = s.db.Rebind(query)
+	if err := s.db.SelectContext(ctx, &trades, query, values...); err != nil {
+		logging.Errorw(ctx, "store list trades failed", "err", err, "userID", filter.UserID)
+		return nil, "", parseError(err)
+	}
+	if len(trades) < count {
+		return trades, "", nil
+	}
+	last := trades[len(trades)-1]
+	return trades, models.NewCursor(last.CreatedAt, last.ID), nil
+}
+
+// fillPosition applies a trade to the position of given user within transaction tx
+func fillPosition(ctx context.Context, tx *sqlx.Tx, userID string, action models.OrderAction, price, quantity models.Decimal) error {
+	position := models.Position{}
+	query := `
+		SELECT
+			user_id,
+			quantity,
+			cost,
+			realized_pnl,
+			updated_at
+		FROM public.position
+		WHERE
+		user_id = ?
+		FOR UPDATE
+	`
+	query = tx.Rebind(query)
+	if err := tx.Get(&position, query, userID); err != nil {
+		if err != sql.ErrNoRows {
+			logging.Errorw(ctx, "store get position for update failed", "err", err, "userID", userID)
+			return parseError(err)
+		}
+		position.UserID = userID
+	}
+
+	if err := position.Fill(action, price, quantity); err != nil {
+		logging.Errorw(ctx, "store fill position failed", "err", err, "userID", userID)
+		return err
+	}
+
+	query = `
+		INSERT INTO public.position (
+			user_id,
+			quantity,
+			cost,
+			realized_pnl,
+			updated_at
+		)
+		VALUES (
+			?,
+			?,
+			?,
+			?,
+			now()
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			quantity = EXCLUDED.quantity,
+			cost = EXCLUDED.cost,
+			realized_pnl = EXCLUDED.realized_pnl,
+			updated_at = EXCLUDED.updated_at
+	`
+	values := []interface{}{
+		position.UserID,
+		position.Quantity,
+		position.Cost,
+		position.RealizedPnL,
+	}
+	query = tx.Rebind(query)
+	if _, err := tx.Exec(query, values...); err != nil {
+		logging.Errorw(ctx, "store update position failed", "err", err, "userID", userID)
+		return parseError(err)
+	}
+	return nil
+}
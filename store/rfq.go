@@ -0,0 +1,313 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/database"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/A-pen-app/tracing"
+	"github.com/jmoiron/sqlx"
+)
+
+type rfqStore struct {
+	db *sqlx.DB
+	// how long to wait for the book lock before giving up with models.ErrorBookBusy
+	lockTimeout time.Duration
+}
+
+// NewRFQ returns an implementation of store.RFQ
+func NewRFQ(db *sqlx.DB) RFQ {
+	return &rfqStore{
+		db:          db,
+		lockTimeout: config.GetMilliseconds("BOOK_LOCK_TIMEOUT_MS"),
+	}
+}
+
+func (s *rfqStore) Create(ctx context.Context, rfq *models.RFQ, messages []*models.OutboxMessage) error {
+	var rfqID *string
+	if rfq.ID != "" {
+		rfqID = &rfq.ID
+	}
+	if rfq.Status == "" {
+		rfq.Status = models.RFQOpen
+	}
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		query := `
+			INSERT INTO public.rfq (
+				id,
+				requester_id,
+				action,
+				quantity,
+				status,
+				expires_at
+			)
+			VALUES (
+				COALESCE(?::uuid, uuid_generate_v4()),
+				?,
+				?,
+				?,
+				?,
+				?
+			)
+			RETURNING id, created_at
+		`
+		values := []interface{}{
+			rfqID,
+			rfq.RequesterID,
+			rfq.Action,
+			rfq.Quantity,
+			rfq.Status,
+			rfq.ExpiresAt,
+		}
+		query = tx.Rebind(query)
+		if err := tx.QueryRowx(query, values...).Scan(&rfq.ID, &rfq.CreatedAt); err != nil {
+			logging.Errorw(ctx, "store insert rfq failed", "err", err)
+			return parseError(err)
+		}
+		return enqueue(ctx, tx, messages)
+	}); err != nil {
+		logging.Errorw(ctx, "store create rfq failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *rfqStore) Get(ctx context.Context, rfqID string) (*models.RFQ, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.rfq").End()
+	}
+
+	rfq := models.RFQ{}
+	query := `
+		SELECT
+			id,
+			requester_id,
+			action,
+			quantity,
+			status,
+			expires_at,
+			trade_id,
+			created_at
+		FROM public.rfq
+		WHERE
+		id = ?
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&rfq, query, rfqID); err != nil {
+		logging.Errorw(ctx, "store get rfq failed", "err", err, "rfqID", rfqID)
+		return nil, parseError(err)
+	}
+	return &rfq, nil
+}
+
+func (s *rfqStore) GetQuotes(ctx context.Context, rfqID string) ([]*models.Quote, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.quotes").End()
+	}
+
+	quotes := []*models.Quote{}
+	query := `
+		SELECT
+			id,
+			rfq_id,
+			market_maker_id,
+			price,
+			expires_at,
+			created_at
+		FROM public.quote
+		WHERE
+		rfq_id = ?
+		ORDER BY created_at ASC
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.Select(&quotes, query, rfqID); err != nil {
+		logging.Errorw(ctx, "store get quotes failed", "err", err, "rfqID", rfqID)
+		return nil, parseError(err)
+	}
+	return quotes, nil
+}
+
+func (s *rfqStore) CreateQuote(ctx context.Context, quote *models.Quote, now time.Time) error {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.create.quote").End()
+	}
+
+	query := `
+		INSERT INTO public.quote (
+			rfq_id,
+			market_maker_id,
+			price,
+			expires_at
+		)
+		SELECT
+			r.id,
+			?,
+			?,
+			?
+		FROM public.rfq r
+		WHERE
+		r.id = ? AND
+		r.status = ? AND
+		r.expires_at > ?
+		RETURNING id, created_at
+	`
+	values := []interface{}{
+		quote.MarketMakerID,
+		quote.Price,
+		quote.ExpiresAt,
+		quote.RFQID,
+		models.RFQOpen,
+		now,
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.QueryRowx(query, values...).Scan(&quote.ID, &quote.CreatedAt); err != nil {
+		logging.Errorw(ctx, "store create quote failed", "err", err, "rfqID", quote.RFQID)
+		return parseError(err)
+	}
+	return nil
+}
+
+func (s *rfqStore) Accept(ctx context.Context, rfqID, quoteID string, now time.Time, outbox OutboxFunc) (*models.Trade, error) {
+	var trade *models.Trade
+	if err := database.Transaction(s.db, func(tx *sqlx.Tx) error {
+		// positions are only filled under the book lock, so block trades and trades on the board never interleave
+		if err := lockBook(ctx, tx, s.lockTimeout); err != nil {
+			return err
+		}
+		rfq := models.RFQ{}
+		query := `
+			SELECT
+				id,
+				requester_id,
+				action,
+				quantity,
+				status,
+				expires_at,
+				trade_id,
+				created_at
+			FROM public.rfq
+			WHERE
+			id = ?
+			FOR UPDATE
+		`
+		query = tx.Rebind(query)
+		if err := tx.Get(&rfq, query, rfqID); err != nil {
+			logging.Errorw(ctx, "store get rfq to accept failed", "err", err, "rfqID", rfqID)
+			return parseError(err)
+		}
+		if !rfq.Expire(now) {
+			return models.ErrorNotAllowed
+		}
+
+		quote := models.Quote{}
+		query = `
+			SELECT
+				id,
+				rfq_id,
+				market_maker_id,
+				price,
+				expires_at,
+				created_at
+			FROM public.quote
+			WHERE
+			id = ? AND
+			rfq_id = ?
+		`
+		query = tx.Rebind(query)
+		if err := tx.Get(&quote, query, quoteID, rfqID); err != nil {
+			logging.Errorw(ctx, "store get quote to accept failed", "err", err, "quoteID", quoteID)
+			return parseError(err)
+		}
+		if !now.Before(quote.ExpiresAt) {
+			return models.ErrorNotAllowed
+		}
+
+		trade = &models.Trade{
+			MakerOrderID: quote.ID,
+			MakerID:      &quote.MarketMakerID,
+			TakerID:      rfq.RequesterID,
+			Action:       rfq.Action,
+			Price:        quote.Price,
+			Quantity:     rfq.Quantity,
+			OffBook:      true,
+		}
+		query = `
+			INSERT INTO public.trade (
+				maker_order_id,
+				maker_id,
+				taker_id,
+				action,
+				price,
+				quantity,
+				off_book
+			)
+			VALUES (
+				?,
+				?,
+				?,
+				?,
+				?,
+				?,
+				?
+			)
+			RETURNING id, created_at
+		`
+		values := []interface{}{
+			trade.MakerOrderID,
+			trade.MakerID,
+			trade.TakerID,
+			trade.Action,
+			trade.Price,
+			trade.Quantity,
+			trade.OffBook,
+		}
+		query = tx.Rebind(query)
+		if err := tx.QueryRowx(query, values...).Scan(&trade.ID, &trade.CreatedAt); err != nil {
+			logging.Errorw(ctx, "store insert off-book trade failed", "err", err, "rfqID", rfqID)
+			return parseError(err)
+		}
+
+		// the market maker takes the other side
+		marketMakerAction := models.Sell
+		if rfq.Action == models.Sell {
+			marketMakerAction = models.Buy
+		}
+		if err := fillPosition(ctx, tx, rfq.RequesterID, rfq.Action, quote.Price, rfq.Quantity); err != nil {
+			return err
+		}
+		if err := fillPosition(ctx, tx, quote.MarketMakerID, marketMakerAction, quote.Price, rfq.Quantity); err != nil {
+			return err
+		}
+
+		query = `
+			UPDATE public.rfq
+			SET
+				status = ?,
+				trade_id = ?
+			WHERE
+			id = ?
+		`
+		query = tx.Rebind(query)
+		if _, err := tx.Exec(query, models.RFQAccepted, trade.ID, rfqID); err != nil {
+			logging.Errorw(ctx, "store mark rfq accepted failed", "err", err, "rfqID", rfqID)
+			return parseError(err)
+		}
+
+		if outbox == nil {
+			return nil
+		}
+		messages, err := outbox([]*models.Trade{trade})
+		if err != nil {
+			logging.Errorw(ctx, "store build outbox messages of accepted quote failed", "err", err)
+			return err
+		}
+		return enqueue(ctx, tx, messages)
+	}); err != nil {
+		logging.Errorw(ctx, "store accept quote failed", "err", err, "rfqID", rfqID, "quoteID", quoteID)
+		return nil, err
+	}
+	return trade, nil
+}
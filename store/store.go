@@ -6,15 +6,152 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/A-pen-app/kickstart/models"
 )
 
+// Order changes the board one transaction at a time across all replicas, methods changing it return
+// models.ErrorBookBusy if they cannot acquire the book in time.
 type Order interface {
 	GetLiveOrders(ctx context.Context, action models.OrderAction) ([]*models.Order, error)
-	Make(ctx context.Context, action models.OrderAction, price, quantity int) error
-	Take(ctx context.Context, action models.OrderAction, quantity int) (int, error)
-	Delete(ctx context.Context, orderID string) error
+	// GetBestPrice returns the highest buy price or the lowest sell price, or nil if there is no order of given action
+	GetBestPrice(ctx context.Context, action models.OrderAction) (*models.Decimal, error)
+	// GetLatestPrice returns the price of the latest trade on the board, or nil if nothing has traded yet
+	GetLatestPrice(ctx context.Context) (*models.Decimal, error)
+	// GetIndicativeAuction returns where Uncross would uncross the board now, from the same orders and
+	// reference price, without executing any trade
+	GetIndicativeAuction(ctx context.Context, defaultPrice models.Decimal) (*models.Auction, error)
+	// GetPriceRange returns the lowest and highest prices traded on the board since given time, both nil
+	// if nothing has traded since then
+	GetPriceRange(ctx context.Context, since time.Time) (low, high *models.Decimal, err error)
+	// Get returns an order, filled and cancelled orders are kept off the board and returned as well
+	Get(ctx context.Context, orderID string) (*models.Order, error)
+	// GetOCOGroup returns the orders of a one-cancels-other group, including filled and cancelled ones
+	GetOCOGroup(ctx context.Context, groupID string) ([]*models.Order, error)
+	// Make creates an order and enqueues messages to the outbox in the same transaction,
+	// order.ID is generated if empty and order.CreatedAt is filled in.
+	Make(ctx context.Context, order *models.Order, messages []*models.OutboxMessage) error
+	// MakeOCO creates orders linked in a new one-cancels-other group and enqueues messages to the outbox
+	// in the same transaction, filling any of them reduces the others by the filled quantity.
+	MakeOCO(ctx context.Context, orders []*models.Order, messages []*models.OutboxMessage) error
+	// Take fills resting orders and enqueues the messages built by outbox from the executed trades
+	// in the same transaction, it returns the price of the last trade. Filled orders are kept as filled.
+	Take(ctx context.Context, userID string, action models.OrderAction, quantity models.Decimal, outbox OutboxFunc) (models.Decimal, error)
+	// Uncross matches open buy and sell orders crossing each other at the single clearing price executing
+	// the highest volume, and enqueues the messages built by outbox from the executed trades in the same
+	// transaction. Ties are broken by the latest price, or by defaultPrice if nothing has traded yet.
+	// Iceberg orders are replenished while matched, orders without owner are left out and filled orders
+	// are kept as filled.
+	// Price of the returned auction is nil if the board does not cross.
+	Uncross(ctx context.Context, defaultPrice models.Decimal, outbox OutboxFunc) (*models.Auction, error)
+	// TrailStops moves the stop prices of pending trailing stop orders to follow given latest price,
+	// and returns the moved orders
+	TrailStops(ctx context.Context, latestPrice, tickSize models.Decimal) ([]*models.Order, error)
+	// TriggerStops puts pending stop orders reached by given latest price on the board with new time priority
+	TriggerStops(ctx context.Context, latestPrice models.Decimal) ([]*models.Order, error)
+	// List returns orders of all users matching filter, including filled and cancelled ones, newest first,
+	// along with the cursor of the next page which is empty on the last page
+	List(ctx context.Context, filter *models.OrderFilter, next string, count int) ([]*models.Order, string, error)
+	// Delete cancels an open or pending order of the user, or of any user if userID is nil, along with the
+	// other orders of its one-cancels-other group, and records audit to the audit trail in the same transaction
+	// if it is not nil. Cancelled orders are kept off the board. It returns ErrorNotFound if there is no such
+	// order or it is closed already.
+	Delete(ctx context.Context, userID *string, orderID string, audit *models.AuditEntry) error
+	// Batch makes and cancels orders of a user in the order of items within a single transaction,
+	// only orders of the user are cancelled. The first failing item rolls back all of them and is
+	// reported as a *models.BatchError.
+	Batch(ctx context.Context, userID string, items []*models.BatchItem) error
+	// DeleteByUser cancels all open and pending orders of a user and records audit in the same transaction,
+	// it returns the number of cancelled orders
+	DeleteByUser(ctx context.Context, userID string, audit *models.AuditEntry) (int, error)
+}
+
+// OutboxFunc builds the messages to enqueue to the outbox for the trades executed by an order
+type OutboxFunc func(trades []*models.Trade) ([]*models.OutboxMessage, error)
+
+type Parent interface {
+	// Create creates a parent order, parent.ID, CreatedAt and UpdatedAt are filled in
+	Create(ctx context.Context, parent *models.ParentOrder) error
+	Get(ctx context.Context, parentID string) (*models.ParentOrder, error)
+	// UpdateStatus changes the status of a parent order currently in one of from, cancelling it
+	// also clears its schedule. It returns models.ErrorNotFound if the parent order is in none of them.
+	UpdateStatus(ctx context.Context, parentID string, from []models.ParentOrderStatus, to models.ParentOrderStatus) (*models.ParentOrder, error)
+	// Dispatch calls send for at most limit active parent orders whose next slice is due at now,
+	// then saves the progress recorded by send, and returns the number of parent orders dispatched.
+	// Only one replica dispatches at a time.
+	Dispatch(ctx context.Context, now time.Time, limit int, send func(*models.ParentOrder) error) (int, error)
+}
+
+type RFQ interface {
+	// Create creates an RFQ and enqueues messages to the outbox in the same transaction,
+	// rfq.ID is generated if empty and rfq.CreatedAt is filled in.
+	Create(ctx context.Context, rfq *models.RFQ, messages []*models.OutboxMessage) error
+	Get(ctx context.Context, rfqID string) (*models.RFQ, error)
+	GetQuotes(ctx context.Context, rfqID string) ([]*models.Quote, error)
+	// CreateQuote creates a quote of an RFQ open at now, quote.ID and CreatedAt are filled in.
+	// It returns models.ErrorNotFound if the RFQ is not open.
+	CreateQuote(ctx context.Context, quote *models.Quote, now time.Time) error
+	// Accept executes a quote of an RFQ as an off-book trade between the requester and the market maker,
+	// and enqueues the messages built by outbox from the trade in the same transaction. It returns
+	// models.ErrorNotAllowed if either the RFQ or the quote is no longer open at now.
+	Accept(ctx context.Context, rfqID, quoteID string, now time.Time, outbox OutboxFunc) (*models.Trade, error)
+}
+
+type Position interface {
+	Get(ctx context.Context, userID string) (*models.Position, error)
+	// GetTrades returns at most limit trades taken or made by a user, most recent first
+	GetTrades(ctx context.Context, userID string, limit int) ([]*models.Trade, error)
+	// ListTrades returns trades matching filter, most recent first
+	ListTrades(ctx context.Context, filter *models.TradeFilter, next string, count int) ([]*models.Trade, string, error)
+}
+
+type Instrument interface {
+	Get(ctx context.Context, symbol string) (*models.Instrument, error)
+	// Halt stops trading of the instrument for given minutes, or until resumed if minutes is 0
+	Halt(ctx context.Context, symbol, reason string, minutes int) error
+	Resume(ctx context.Context, symbol string) error
+	// SetTimeZone sets the IANA time zone the trading session of the instrument is in
+	SetTimeZone(ctx context.Context, symbol, timeZone string) error
+}
+
+type Ticker interface {
+	// List returns the statistics of trades on the board since given time per instrument, off-book
+	// trades are not counted. Statistics are kept for the last 25 hours only.
+	List(ctx context.Context, since time.Time) ([]*models.Ticker, error)
+}
+
+type User interface {
+	Get(ctx context.Context, userID string) (*models.User, error)
+	// GetByType returns all users of given type, e.g. the market makers quoting RFQs
+	GetByType(ctx context.Context, userType models.UserType) ([]*models.User, error)
+	// GetNotificationPreferences returns the default preferences if the user never set them
+	GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error
+}
+
+type Outbox interface {
+	// Relay calls publish for pending messages in the order they are created, skipping aggregates
+	// with a message waiting for retry, and returns the number of messages published.
+	Relay(ctx context.Context, limit int, publish func(*models.OutboxMessage) error) (int, error)
+}
+
+type Lease interface {
+	// Acquire takes or renews the named lease for holder until ttl from now, unless another holder's
+	// lease has not expired yet. It returns the lease as it is afterwards, held by holder or not.
+	Acquire(ctx context.Context, name, holder, address string, ttl time.Duration) (*models.Lease, error)
+	// Release expires the named lease if it is held by holder, so another replica takes it over at once
+	Release(ctx context.Context, name, holder string) error
+}
+
+type Audit interface {
+	// Record appends entry to the audit trail chained to the latest entry, entry.ID, CreatedAt,
+	// PrevHash and Hash are filled in
+	Record(ctx context.Context, entry *models.AuditEntry) error
+	// List returns entries matching filter, newest first
+	List(ctx context.Context, filter *models.AuditFilter, next string, count int) ([]*models.AuditEntry, string, error)
+	// Scan returns at most count entries with ID greater than afterID in the order they are chained
+	Scan(ctx context.Context, afterID int64, count int) ([]*models.AuditEntry, error)
 }
 
 type Crypto interface {
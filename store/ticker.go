@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/A-pen-app/tracing"
+	"github.com/jmoiron/sqlx"
+)
+
+// bucketRetention is how long the statistics of a minute are kept, an hour more than the tickers of
+// service.Market cover, so the clocks of the replicas and the database may drift
+const bucketRetention = 25 * time.Hour
+
+type tickerStore struct {
+	db *sqlx.DB
+}
+
+// NewTicker returns an implementation of store.Ticker
+func NewTicker(db *sqlx.DB) Ticker {
+	return &tickerStore{
+		db: db,
+	}
+}
+
+func (s *tickerStore) List(ctx context.Context, since time.Time) ([]*models.Ticker, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.list.tickers").End()
+	}
+
+	tickers := []*models.Ticker{}
+	query := `
+		SELECT
+			symbol,
+			(array_agg(open ORDER BY minute ASC))[1] AS open,
+			MAX(high) AS high,
+			MIN(low) AS low,
+			(array_agg(close ORDER BY minute DESC))[1] AS last,
+			SUM(volume) AS volume,
+			TRUNC(SUM(notional) / SUM(volume), ?) AS vwap
+		FROM public.trade_bucket
+		WHERE
+		minute >= date_trunc('minute', ?::timestamptz)
+		GROUP BY symbol
+		ORDER BY symbol
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.SelectContext(ctx, &tickers, query, models.DecimalScale, since); err != nil {
+		logging.Errorw(ctx, "store list tickers failed", "err", err)
+		return nil, parseError(err)
+	}
+	return tickers, nil
+}
+
+// addToBucket adds a trade of symbol on the board to the statistics of the current minute within
+// transaction tx, and deletes the minutes of symbol older than bucketRetention. Trades are serialized
+// by the book lock, so the last one added closes the minute.
+func addToBucket(ctx context.Context, tx *sqlx.Tx, symbol string, trade *models.Trade) error {
+	query := `
+		INSERT INTO public.trade_bucket (
+			symbol,
+			minute,
+			open,
+			high,
+			low,
+			close,
+			volume,
+			notional
+		)
+		VALUES (
+			?,
+			date_trunc('minute', now()),
+			?,
+			?,
+			?,
+			?,
+			?,
+			?::numeric * ?::numeric
+		)
+		ON CONFLICT (symbol, minute) DO UPDATE SET
+			high = GREATEST(trade_bucket.high, EXCLUDED.high),
+			low = LEAST(trade_bucket.low, EXCLUDED.low),
+			close = EXCLUDED.close,
+			volume = trade_bucket.volume + EXCLUDED.volume,
+			notional = trade_bucket.notional + EXCLUDED.notional
+	`
+	values := []interface{}{
+		symbol,
+		trade.Price,
+		trade.Price,
+		trade.Price,
+		trade.Price,
+		trade.Quantity,
+		trade.Price,
+		trade.Quantity,
+	}
+	query = tx.Rebind(query)
+	if _, err := tx.Exec(query, values...); err != nil {
+		logging.Errorw(ctx, "store add trade to bucket failed", "err", err, "symbol", symbol)
+		return parseError(err)
+	}
+
+	query = `
+		DELETE FROM public.trade_bucket
+		WHERE
+		symbol = ? AND
+		minute < date_trunc('minute', now()) - make_interval(secs => ?)
+	`
+	query = tx.Rebind(query)
+	if _, err := tx.Exec(query, symbol, bucketRetention.Seconds()); err != nil {
+		logging.Errorw(ctx, "store prune buckets failed", "err", err, "symbol", symbol)
+		return parseError(err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/A-pen-app/kickstart/config"
+	"github.com/A-pen-app/kickstart/models"
+	"github.com/A-pen-app/logging"
+	"github.com/A-pen-app/tracing"
+	"github.com/jmoiron/sqlx"
+)
+
+type userStore struct {
+	db *sqlx.DB
+}
+
+// NewUser returns an implementation of store.User
+func NewUser(db *sqlx.DB) User {
+	return &userStore{
+		db: db,
+	}
+}
+
+func (s *userStore) Get(ctx context.Context, userID string) (*models.User, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.user").End()
+	}
+
+	user := models.User{}
+	query := `
+		SELECT
+			id,
+			name,
+			picture,
+			type,
+			push_token,
+			email,
+			phone,
+			locale,
+			created_at,
+			updated_at
+		FROM public.user
+		WHERE
+		id = ?
+	`
+	values := []interface{}{
+		userID,
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&user, query, values...); err != nil {
+		logging.Errorw(ctx, "store get user failed", "err", err, "userID", userID)
+		return nil, parseError(err)
+	}
+	return &user, nil
+}
+
+func (s *userStore) GetByType(ctx context.Context, userType models.UserType) ([]*models.User, error) {
+	if !config.GetBool("TESTING") {
+		defer tracing.Start(ctx, "store.get.users.type").End()
+	}
+
+	users := []*models.User{}
+	query := `
+		SELECT
+			id,
+			name,
+			picture,
+			type,
+			push_token,
+			email,
+			phone,
+			locale,
+			created_at,
+			updated_at
+		FROM public.user
+		WHERE
+		type = ?
+		ORDER BY id
+	`
+	query = s.db.Rebind(query)
+	if err := s.db.Select(&users, query, userType); err != nil {
+		logging.Errorw(ctx, "store get users by type failed", "err", err, "type", userType)
+		return nil, parseError(err)
+	}
+	return users, nil
+}
+
+func (s *userStore) GetNotificationPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	preferences := models.NotificationPreferences{}
+	query := `
+		SELECT
+			user_id,
+			email,
+			sms,
+			push,
+			updated_at
+		FROM public.notification_preference
+		WHERE
+		user_id = ?
+	`
+	values := []interface{}{
+		userID,
+	}
+	query = s.db.Rebind(query)
+	if err := s.db.Get(&preferences, query, values...); err != nil {
+		if err == sql.ErrNoRows {
+			return models.DefaultNotificationPreferences(userID), nil
+		}
+		logging.Errorw(ctx, "store get notification preferences failed", "err", err, "userID", userID)
+		return nil, parseError(err)
+	}
+	return &preferences, nil
+}
+
+func (s *userStore) UpdateNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	query := `
+		INSERT INTO public.notification_preference (
+			user_id,
+			email,
+			sms,
+			push,
+			updated_at
+		)
+		VALUES (
+			?,
+			?,
+			?,
+			?,
+			now()
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			email = EXCLUDED.email,
+			sms = EXCLUDED.sms,
+			push = EXCLUDED.push,
+			updated_at = EXCLUDED.updated_at
+	`
+	values := []interface{}{
+		preferences.UserID,
+		preferences.Email,
+		preferences.SMS,
+		preferences.Push,
+	}
+	query = s.db.Rebind(query)
+	if _, err := s.db.Exec(query, values...); err != nil {
+		logging.Errorw(ctx, "store update notification preferences failed", "err", err, "userID", preferences.UserID)
+		return parseError(err)
+	}
+	return nil
+}
@@ -1,23 +1,19 @@
 package util
 
-import (
-	"errors"
-)
-
 func init() {
 }
+
+// ChErrHandler returns the first non-nil error received from errCh, which each of spawned goroutines
+// sends at most one value to. It waits for spawned values unless errCh is closed first, so goroutines
+// only sending on failure should close errCh once they are all done. Prefer errgroup for new code.
 func ChErrHandler(errCh <-chan error, spawned int) error {
 	for i := 0; i < spawned; i++ {
-		select {
-		case errVal, ok := <-errCh:
-			if ok {
-				if errVal != nil {
-					return errVal
-				}
-			} else {
-				return errors.New("error channel closed")
-			}
-		default:
+		errVal, ok := <-errCh
+		if !ok {
+			return nil
+		}
+		if errVal != nil {
+			return errVal
 		}
 	}
 	return nil